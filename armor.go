@@ -0,0 +1,329 @@
+package jase93
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ArmorLineLen is the number of encoded characters per line of an armored block.
+const ArmorLineLen = 64
+
+var (
+	// ErrInvalidArmor indicates that an armored block, or the arguments to create one, were malformed.
+	ErrInvalidArmor = errors.New("jase93: invalid armor")
+	// ErrChecksum indicates that decoded data did not match its checksum.
+	ErrChecksum = errors.New("jase93: checksum mismatch")
+)
+
+// Block is an armored block of data.
+type Block struct {
+	Type   string            // The block type, such as "PRIVATE KEY"
+	Header map[string]string // Optional headers
+	Body   io.Reader         // The decoded contents, which returns a *ChecksumError at the end if they were corrupted
+}
+
+const (
+	armorBegin = "-----BEGIN "
+	armorEnd   = "-----END "
+	armorDash  = "-----"
+)
+
+// Armor returns an io.WriteCloser that writes data to w as an armored block, analogous to PEM:
+//
+//	-----BEGIN TYPE-----
+//	Key: Value
+//
+//	<encoded data, wrapped at ArmorLineLen characters>
+//	=<encoded CRC-24 of the data>
+//	-----END TYPE-----
+//
+// Data is encoded with SpaceFreeEncoding, so no line ends in a space that mail software or an editor might strip. The
+// blank line following the headers is always present. The Line-Checksum header is reserved; see
+// ArmorWithLineChecksums. Close must be called to write the checksum and END lines; it does not close w.
+func Armor(w io.Writer, blockType string, headers map[string]string) (io.WriteCloser, error) {
+	return armor(w, blockType, headers, false)
+}
+
+func armor(w io.Writer, blockType string, headers map[string]string, lineSums bool) (io.WriteCloser, error) {
+	if blockType == "" || strings.ContainsAny(blockType, "\r\n") || strings.Contains(blockType, armorDash) {
+		return nil, ErrInvalidArmor
+	}
+
+	keys := make([]string, 0, len(headers))
+	for k, v := range headers {
+		if k == "" || strings.ContainsAny(k, ":\r\n") || strings.ContainsAny(v, "\r\n") || k == lineChecksumHeader {
+			return nil, ErrInvalidArmor
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	b := bufio.NewWriter(w)
+	b.WriteString(armorBegin + blockType + armorDash + "\n")
+	for _, k := range keys {
+		b.WriteString(k + ": " + headers[k] + "\n")
+	}
+	if lineSums {
+		b.WriteString(lineChecksumHeader + ": " + lineChecksumCRC8 + "\n")
+	}
+	b.WriteString("\n")
+
+	a := &armorWriter{b: b, blockType: blockType, lines: newLineWriter(b, ArmorLineLen), crc: crc24Init}
+	a.lines.sums = lineSums
+	a.enc = SpaceFreeEncoding.NewEncoder(a.lines)
+	return a, nil
+}
+
+type armorWriter struct {
+	b         *bufio.Writer
+	blockType string
+	lines     *lineWriter
+	enc       *Encoder
+	crc       uint32
+}
+
+func (a *armorWriter) Write(data []byte) (int, error) {
+	a.crc = crc24(a.crc, data)
+	return a.enc.Write(data)
+}
+
+func (a *armorWriter) Close() error {
+	if err := a.enc.Close(); err != nil {
+		return err
+	}
+	a.lines.Close()
+
+	a.b.WriteString("=")
+	a.b.Write(SpaceFreeEncoding.Encode(nil, []byte{byte(a.crc >> 16), byte(a.crc >> 8), byte(a.crc)}))
+	a.b.WriteString("\n" + armorEnd + a.blockType + armorDash + "\n")
+	return a.b.Flush()
+}
+
+// lineWriter wraps written data into lines of at most width characters, optionally followed by their checksums.
+type lineWriter struct {
+	w     io.Writer
+	width int
+	col   int
+	buf   []byte
+	sums  bool
+	line  int   // the index of the current line
+	crc   uint8 // the checksum of the current line
+}
+
+func newLineWriter(w io.Writer, width int) *lineWriter {
+	return &lineWriter{w: w, width: width}
+}
+
+func (l *lineWriter) Write(data []byte) (int, error) {
+	l.buf = l.buf[:0]
+	for i, c := range data {
+		if l.col == l.width {
+			l.buf = l.endLine(l.buf)
+		}
+		if l.col == 0 {
+			l.crc = uint8(l.line)
+		}
+		l.buf = append(l.buf, c)
+		l.col++
+		if l.sums {
+			l.crc = crc8(l.crc, data[i:i+1])
+		}
+	}
+
+	if _, err := l.w.Write(l.buf); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// Close terminates the current line, if any. It does not close the wrapped io.Writer.
+func (l *lineWriter) Close() error {
+	if l.col == 0 {
+		return nil
+	}
+	_, err := l.w.Write(l.endLine(nil))
+	return err
+}
+
+// endLine appends the end of the current line to dst.
+func (l *lineWriter) endLine(dst []byte) []byte {
+	if l.sums {
+		lo, hi := SpaceFreeEncoding.EncodeWord(uint16(l.crc))
+		dst = append(dst, lo, hi)
+	}
+	l.col = 0
+	l.line++
+	return append(dst, '\n')
+}
+
+// Dearmor reads the first armored block from r, skipping any preceding text. Lines may end with "\n" or "\r\n".
+func Dearmor(r io.Reader) (*Block, error) {
+	lines := &armorLineReader{r: bufio.NewReader(r)}
+
+	var line string
+	var err error
+	for {
+		if line, err = lines.readLine(); err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(line, armorBegin) && strings.HasSuffix(line, armorDash) && len(line) > len(armorBegin)+len(armorDash) {
+			break
+		}
+	}
+
+	blockType := line[len(armorBegin) : len(line)-len(armorDash)]
+	block := &Block{Type: blockType, Header: make(map[string]string)}
+	sums := false
+
+	for {
+		if line, err = lines.readLine(); err != nil {
+			return nil, err
+		}
+		if line == "" {
+			break
+		}
+
+		i := strings.Index(line, ": ")
+		if i < 1 {
+			return nil, &ArmorError{Line: lines.n}
+		}
+		if line[:i] == lineChecksumHeader {
+			// Line checksums are handled here rather than reported as a header
+			if line[i+2:] != lineChecksumCRC8 {
+				return nil, &ArmorError{Line: lines.n}
+			}
+			sums = true
+			continue
+		}
+		block.Header[line[:i]] = line[i+2:]
+	}
+
+	body := &armorBodyReader{lines: lines, end: armorEnd + blockType + armorDash, sums: sums}
+	block.Body = &dearmorReader{dec: SpaceFreeEncoding.NewDecoder(body), body: body, crc: crc24Init}
+	return block, nil
+}
+
+// armorLineReader reads lines without their line endings, counting them.
+type armorLineReader struct {
+	r *bufio.Reader
+	n int
+}
+
+func (l *armorLineReader) readLine() (string, error) {
+	line, err := l.r.ReadString('\n')
+	if err == io.EOF {
+		if line == "" {
+			return "", io.ErrUnexpectedEOF
+		}
+	} else if err != nil {
+		return "", err
+	}
+
+	l.n++
+	line = strings.TrimSuffix(line, "\n")
+	return strings.TrimSuffix(line, "\r"), nil
+}
+
+// armorBodyReader reads the encoded lines of an armored block, holding back the checksum line preceding the END line.
+type armorBodyReader struct {
+	lines        *armorLineReader
+	end          string
+	started      bool
+	line         string
+	next         string
+	checksum     string
+	checksumLine int
+	done         bool
+	sums         bool // whether lines end with checksums
+	index        int  // the index of the next line, for checksums
+}
+
+func (a *armorBodyReader) Read(data []byte) (n int, err error) {
+	for n < len(data) {
+		if len(a.line) > 0 {
+			c := copy(data[n:], a.line)
+			a.line = a.line[c:]
+			n += c
+			continue
+		}
+		if a.done {
+			break
+		}
+
+		if !a.started {
+			a.started = true
+			if a.next, err = a.lines.readLine(); err != nil {
+				return
+			}
+		}
+
+		a.line = a.next
+		if a.next, err = a.lines.readLine(); err != nil {
+			return
+		}
+
+		if a.next == a.end {
+			a.checksum = a.line
+			a.checksumLine = a.lines.n - 1
+			a.line = ""
+			a.done = true
+		} else if a.sums {
+			if a.line, err = a.verifyLine(a.line); err != nil {
+				return
+			}
+		}
+	}
+
+	if a.done && n == 0 {
+		err = io.EOF
+	}
+	return
+}
+
+// dearmorReader decodes the body of an armored block and verifies its checksum.
+type dearmorReader struct {
+	dec  *Decoder
+	body *armorBodyReader
+	crc  uint32
+}
+
+func (d *dearmorReader) Read(data []byte) (int, error) {
+	n, err := d.dec.Read(data)
+	d.crc = crc24(d.crc, data[:n])
+
+	if err == io.EOF {
+		if !strings.HasPrefix(d.body.checksum, "=") {
+			return n, &ArmorError{Line: d.body.checksumLine}
+		}
+		sum, derr := SpaceFreeEncoding.Decode(nil, []byte(d.body.checksum[1:]))
+		if derr != nil || len(sum) != 3 {
+			return n, &ArmorError{Line: d.body.checksumLine}
+		}
+		if want := uint32(sum[0])<<16 | uint32(sum[1])<<8 | uint32(sum[2]); want != d.crc {
+			return n, &ChecksumError{Want: want, Got: d.crc}
+		}
+	}
+	return n, err
+}
+
+const (
+	crc24Init = 0xb704ce
+	crc24Poly = 0x1864cfb
+)
+
+// crc24 updates the OpenPGP CRC-24 crc with data. The CRC of no data is crc24Init.
+func crc24(crc uint32, data []byte) uint32 {
+	for _, c := range data {
+		crc ^= uint32(c) << 16
+		for i := 0; i < 8; i++ {
+			crc <<= 1
+			if crc&0x1000000 != 0 {
+				crc ^= crc24Poly
+			}
+		}
+	}
+	return crc & 0xffffff
+}
@@ -0,0 +1,126 @@
+package jase93
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestCRC24(t *testing.T) {
+	if crc := crc24(crc24Init, []byte("123456789")); crc != 0x21cf02 {
+		t.Errorf("crc24 = %#x != 0x21cf02", crc)
+	}
+}
+
+func TestArmor(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	headers := map[string]string{"Comment": "test: data", "Version": "1"}
+
+	for _, n := range []int{0, 1, 51, 52, 1000} {
+		src := make([]byte, n)
+		rng.Read(src)
+
+		var buf bytes.Buffer
+		buf.WriteString("preamble\n")
+		w, err := Armor(&buf, "TEST DATA", headers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(src); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		armored := buf.String()
+
+		lines := strings.Split(strings.TrimSuffix(armored, "\n"), "\n")
+		if lines[1] != "-----BEGIN TEST DATA-----" || lines[2] != "Comment: test: data" || lines[4] != "" || lines[len(lines)-1] != "-----END TEST DATA-----" {
+			t.Errorf("Armor(%d) = %q", n, armored)
+		}
+		for _, line := range lines[5 : len(lines)-2] {
+			if len(line) > ArmorLineLen {
+				t.Errorf("line of %d characters", len(line))
+			}
+		}
+
+		block, err := Dearmor(strings.NewReader(strings.Replace(armored, "\n", "\r\n", -1)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if block.Type != "TEST DATA" || len(block.Header) != 2 || block.Header["Comment"] != "test: data" {
+			t.Errorf("Dearmor(%d) = %q, %q", n, block.Type, block.Header)
+		}
+
+		dec, err := ioutil.ReadAll(block.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(dec, src) {
+			t.Errorf("Dearmor(Armor(%x)) = %x", src, dec)
+		}
+	}
+}
+
+func TestArmorStrippedWhitespace(t *testing.T) {
+	// Mail software and editors strip trailing whitespace from lines, which must not corrupt the block
+	rng := rand.New(rand.NewSource(0))
+	trailing := regexp.MustCompile(`[ \t]+\n`)
+	for _, armor := range []func(io.Writer, string, map[string]string) (io.WriteCloser, error){Armor, ArmorWithLineChecksums} {
+		for i := 0; i < 100; i++ {
+			src := make([]byte, rng.Intn(2000))
+			rng.Read(src)
+
+			var buf bytes.Buffer
+			w, _ := armor(&buf, "DATA", nil)
+			w.Write(src)
+			w.Close()
+			if trailing.Match(buf.Bytes()) {
+				t.Fatalf("Armor(%x) has trailing whitespace", src)
+			}
+
+			block, err := Dearmor(strings.NewReader(trailing.ReplaceAllString(buf.String(), "\n")))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if dec, err := ioutil.ReadAll(block.Body); err != nil || !bytes.Equal(dec, src) {
+				t.Fatalf("Dearmor(stripped Armor(%d bytes)) = %d bytes, %v", len(src), len(dec), err)
+			}
+		}
+	}
+}
+
+func TestDearmorErrors(t *testing.T) {
+	var buf bytes.Buffer
+	w, _ := Armor(&buf, "TEST", nil)
+	w.Write([]byte("Man is distinguished"))
+	w.Close()
+	armored := buf.String()
+	body := strings.Split(armored, "\n")[2]
+
+	for _, tc := range []struct {
+		in  string
+		err error
+	}{
+		{strings.Replace(armored, body, "!"+body[1:], 1), ErrChecksum},
+		{strings.Replace(armored, "\n=", "\n!", 1), ErrInvalidArmor},
+		{strings.Replace(armored, "-----END TEST-----\n", "", 1), io.ErrUnexpectedEOF},
+	} {
+		block, err := Dearmor(strings.NewReader(tc.in))
+		if err == nil {
+			_, err = ioutil.ReadAll(block.Body)
+		}
+		if !errors.Is(err, tc.err) {
+			t.Errorf("Dearmor(%q) = %v != %v", tc.in, err, tc.err)
+		}
+	}
+
+	if _, err := Armor(&buf, "BAD-----TYPE", nil); err != ErrInvalidArmor {
+		t.Errorf("Armor(BAD-----TYPE) = %v", err)
+	}
+}
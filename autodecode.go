@@ -0,0 +1,113 @@
+package jase93
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"unicode/utf8"
+)
+
+// autoMinLen is the length of the shortest input AutoDecode takes to be hex or base64 rather than text.
+const autoMinLen = 8
+
+// ErrUnknownFormat indicates that AutoDecode did not recognize the format of its input.
+var ErrUnknownFormat = errors.New("jase93: unrecognized format")
+
+// AutoDecode detects whether src is encoded as hex, base64, base64url, or with a registered Encoding, or is text, and
+// returns it decoded, with the name of its format: "hex", "base64", "base64url", the name of the Codec, such as
+// "jase93", or "text", so services can accept payloads from clients that encode them differently without configuring
+// each one. Text is returned unchanged. AutoDecode returns ErrUnknownFormat if src is none of these.
+//
+// Formats are tried from the most specific to the least, ignoring surrounding whitespace and line breaks. Hex must have
+// an even number of digits of one case, and base64 must decode strictly and have padding, symbols, or letters of both
+// cases mixed with digits, and at least 8 characters of either, so that short words are taken as text. The alphabet of
+// a jase93 encoding is detected by DetectEncoding, and the data must look encoded to CheckDoubleEncoded.
+func AutoDecode(src []byte) (out []byte, format string, err error) {
+	trimmed := bytes.TrimSpace(src)
+	compact := bytes.Map(func(r rune) rune {
+		if r == '\r' || r == '\n' {
+			return -1
+		}
+		return r
+	}, trimmed)
+
+	if len(compact) >= autoMinLen && isHex(compact) {
+		decoded, err := hex.DecodeString(string(compact))
+		return decoded, "hex", err
+	}
+
+	if len(compact) >= autoMinLen && looksBase64(compact) {
+		for _, f := range []struct {
+			name string
+			enc  *base64.Encoding
+		}{
+			{"base64", base64.StdEncoding},
+			{"base64", base64.RawStdEncoding},
+			{"base64url", base64.URLEncoding},
+			{"base64url", base64.RawURLEncoding},
+		} {
+			if decoded, err := f.enc.Strict().DecodeString(string(compact)); err == nil {
+				return decoded, f.name, nil
+			}
+		}
+	}
+
+	var derr *DoubleEncodedError
+	if errors.As(CheckDoubleEncoded(compact), &derr) && derr.Codec != "base64" {
+		c, err := Lookup(derr.Codec)
+		if err != nil {
+			return nil, "", err
+		}
+		enc := c.(*Encoding)
+		if detected, err := DetectEncoding(trimmed); err == nil {
+			enc = detected
+		}
+		decoded, err := enc.Decode(nil, compact)
+		return decoded, derr.Codec, err
+	}
+
+	if utf8.Valid(src) {
+		return src, "text", nil
+	}
+	return nil, "", ErrUnknownFormat
+}
+
+// isHex reports whether src consists of hexadecimal digits of one case, and has an even length.
+func isHex(src []byte) bool {
+	if len(src)%2 != 0 {
+		return false
+	}
+	var upper, lower bool
+	for _, c := range src {
+		switch {
+		case '0' <= c && c <= '9':
+		case 'a' <= c && c <= 'f':
+			lower = true
+		case 'A' <= c && c <= 'F':
+			upper = true
+		default:
+			return false
+		}
+	}
+	return !(upper && lower)
+}
+
+// looksBase64 reports whether src has the features of base64 rather than a word: padding, symbols, or letters of both
+// cases mixed with digits.
+func looksBase64(src []byte) bool {
+	var upper, lower, digit bool
+	for _, c := range src {
+		switch {
+		case 'A' <= c && c <= 'Z':
+			upper = true
+		case 'a' <= c && c <= 'z':
+			lower = true
+		case '0' <= c && c <= '9':
+			digit = true
+		case c == '+' || c == '/' || c == '-' || c == '_' || c == '=':
+			return true
+		}
+	}
+	return upper && lower && digit
+}
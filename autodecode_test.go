@@ -0,0 +1,47 @@
+package jase93
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestAutoDecode(t *testing.T) {
+	const text = "Man is distinguished, not only by his reason, but by this singular passion from other animals."
+	src := make([]byte, 100)
+	rand.New(rand.NewSource(0)).Read(src)
+
+	for _, tc := range []struct {
+		name   string
+		data   []byte
+		format string
+	}{
+		{"hex", []byte(hex.EncodeToString(src) + "\n"), "hex"},
+		{"upper hex", []byte(strings.ToUpper(hex.EncodeToString(src))), "hex"},
+		{"base64", []byte(base64.StdEncoding.EncodeToString(src)), "base64"},
+		{"raw base64", []byte(base64.RawStdEncoding.EncodeToString(src[:31])), "base64"},
+		{"base64url", []byte(base64.URLEncoding.EncodeToString(src)), "base64url"},
+		{"jase93", Encode(nil, src), "jase93"},
+		{"wrapped jase93", StdEncoding.WithLineLength(MIMELineLen).Encode(nil, src), "jase93"},
+		{"headered human", HumanEncoding.WithHeader().Encode(nil, src), "jase93-human"},
+	} {
+		decoded, format, err := AutoDecode(tc.data)
+		if err != nil || format != tc.format || !bytes.Equal(decoded, src[:len(decoded)]) || len(decoded) < 31 {
+			t.Errorf("%s: AutoDecode = %x, %q, %v, want %q", tc.name, decoded, format, err, tc.format)
+		}
+	}
+
+	for _, text := range []string{text, "deadbeef cafe", "password", "Hello, world!\n"} {
+		if decoded, format, err := AutoDecode([]byte(text)); err != nil || format != "text" || string(decoded) != text {
+			t.Errorf("AutoDecode(%q) = %q, %q, %v, want text", text, decoded, format, err)
+		}
+	}
+
+	if _, _, err := AutoDecode(src); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("AutoDecode(binary) = %v, want ErrUnknownFormat", err)
+	}
+}
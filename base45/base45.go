@@ -0,0 +1,188 @@
+// Package base45 implements the base45 encoding of RFC 9285, which is efficient in the alphanumeric mode of QR codes,
+// with the same API as package jase93.
+package base45 // import "github.com/jdknezek/jase93-go/base45"
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+const alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+var decodeMap [256]int8
+
+func init() {
+	for i := range decodeMap {
+		decodeMap[i] = -1
+	}
+	for i := 0; i < len(alphabet); i++ {
+		decodeMap[alphabet[i]] = int8(i)
+	}
+}
+
+// CorruptInputError reports invalid base45 input at the given offset: a character outside the alphabet, a group
+// encoding too large a value, or a truncated group. It matches jase93.ErrInvalidData.
+type CorruptInputError int64
+
+func (e CorruptInputError) Error() string {
+	return fmt.Sprintf("base45: invalid input at offset %d", int64(e))
+}
+
+// Is reports whether target is jase93.ErrInvalidData.
+func (e CorruptInputError) Is(target error) bool {
+	return target == jase93.ErrInvalidData
+}
+
+// MaxEncodedLen returns the number of bytes necessary to encode n source bytes.
+func MaxEncodedLen(n int) int {
+	return n/2*3 + n%2*2
+}
+
+// Encode encodes src and appends it to dst.
+func Encode(dst, src []byte) []byte {
+	for len(src) >= 2 {
+		v := uint(src[0])<<8 | uint(src[1])
+		dst = append(dst, alphabet[v%45], alphabet[v/45%45], alphabet[v/(45*45)])
+		src = src[2:]
+	}
+	if len(src) == 1 {
+		v := uint(src[0])
+		dst = append(dst, alphabet[v%45], alphabet[v/45])
+	}
+	return dst
+}
+
+// Decode decodes src and appends it to dst.
+func Decode(dst, src []byte) ([]byte, error) {
+	var d decoder
+	dst, err := d.write(dst, src)
+	if err == nil {
+		dst, err = d.flush(dst)
+	}
+	return dst, err
+}
+
+type decoder struct {
+	offset int64
+	group  [3]byte
+	n      int
+}
+
+// write decodes src and appends it to dst, retaining any incomplete group.
+func (d *decoder) write(dst, src []byte) ([]byte, error) {
+	for _, c := range src {
+		if decodeMap[c] == -1 {
+			return dst, CorruptInputError(d.offset + int64(d.n))
+		}
+		d.group[d.n] = c
+		d.n++
+		if d.n < 3 {
+			continue
+		}
+
+		v := uint(decodeMap[d.group[0]]) + uint(decodeMap[d.group[1]])*45 + uint(decodeMap[d.group[2]])*45*45
+		if v > 0xffff {
+			return dst, CorruptInputError(d.offset)
+		}
+		dst = append(dst, byte(v>>8), byte(v))
+		d.offset += 3
+		d.n = 0
+	}
+	return dst, nil
+}
+
+// flush decodes the final group, which may consist of two characters encoding a single byte, and appends it to dst.
+func (d *decoder) flush(dst []byte) ([]byte, error) {
+	switch d.n {
+	case 0:
+		return dst, nil
+	case 2:
+		if v := uint(decodeMap[d.group[0]]) + uint(decodeMap[d.group[1]])*45; v <= 0xff {
+			d.n = 0
+			return append(dst, byte(v)), nil
+		}
+	}
+	return dst, CorruptInputError(d.offset)
+}
+
+// Encoder encodes data to a wrapped io.Writer.
+type Encoder struct {
+	w    io.Writer
+	odd  bool
+	last byte
+	buf  []byte
+}
+
+// NewEncoder creates a new Encoder that encodes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Write encodes data to the wrapped io.Writer.
+func (e *Encoder) Write(data []byte) (int, error) {
+	n := len(data)
+	e.buf = e.buf[:0]
+	if e.odd && len(data) > 0 {
+		e.buf = Encode(e.buf, []byte{e.last, data[0]})
+		e.odd = false
+		data = data[1:]
+	}
+
+	even := len(data) &^ 1
+	e.buf = Encode(e.buf, data[:even])
+	if even < len(data) {
+		e.odd, e.last = true, data[even]
+	}
+
+	if _, err := e.w.Write(e.buf); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// Close flushes any buffered byte to the wrapped io.Writer. It does not close the wrapped io.Writer.
+func (e *Encoder) Close() error {
+	if !e.odd {
+		return nil
+	}
+	e.odd = false
+	_, err := e.w.Write(Encode(e.buf[:0], []byte{e.last}))
+	return err
+}
+
+// Decoder decodes data from a wrapped io.Reader.
+type Decoder struct {
+	r   io.Reader
+	dec decoder
+	in  []byte
+	buf []byte
+	err error
+}
+
+// NewDecoder creates a new Decoder that decodes from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r, in: make([]byte, 4096)}
+}
+
+// Read decodes data from the wrapped io.Reader.
+func (d *Decoder) Read(data []byte) (int, error) {
+	for len(d.buf) == 0 && d.err == nil {
+		rn, err := d.r.Read(d.in)
+		d.buf, d.err = d.dec.write(d.buf[:0], d.in[:rn])
+		if d.err == nil && err == io.EOF {
+			d.buf, d.err = d.dec.flush(d.buf)
+		}
+		if d.err == nil {
+			d.err = err
+		}
+	}
+
+	n := copy(data, d.buf)
+	d.buf = d.buf[n:]
+	if len(d.buf) == 0 && d.err != nil {
+		return n, d.err
+	}
+	return n, nil
+}
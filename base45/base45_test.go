@@ -0,0 +1,94 @@
+package base45
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"math/rand"
+	"testing"
+	"testing/iotest"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+// Examples from RFC 9285
+var rfcTests = []struct {
+	raw, encoded string
+}{
+	{"", ""},
+	{"AB", "BB8"},
+	{"Hello!!", "%69 VD92EX0"},
+	{"base-45", "UJCLQE7W581"},
+	{"ietf!", "QED8WEX0"},
+}
+
+func TestEncode(t *testing.T) {
+	for _, tt := range rfcTests {
+		if got := string(Encode(nil, []byte(tt.raw))); got != tt.encoded {
+			t.Errorf("Encode(%q) = %q, want %q", tt.raw, got, tt.encoded)
+		}
+		if n := MaxEncodedLen(len(tt.raw)); n != len(tt.encoded) {
+			t.Errorf("MaxEncodedLen(%d) = %d", len(tt.raw), n)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	for _, tt := range rfcTests {
+		if got, err := Decode(nil, []byte(tt.encoded)); err != nil || string(got) != tt.raw {
+			t.Errorf("Decode(%q) = %q, %v", tt.encoded, got, err)
+		}
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	for _, tt := range []struct {
+		encoded string
+		offset  int64
+	}{
+		{"GGW", 0},    // 65536
+		{"BB8a", 3},   // lowercase
+		{"BB8G", 3},   // truncated group
+		{"BB8GW", 3},  // 1456
+		{"BB8:::", 3}, // 91124
+	} {
+		_, err := Decode(nil, []byte(tt.encoded))
+		if err != CorruptInputError(tt.offset) {
+			t.Errorf("Decode(%q) = %v, want offset %d", tt.encoded, err, tt.offset)
+		}
+		if !errors.Is(err, jase93.ErrInvalidData) {
+			t.Errorf("Decode(%q) error %v does not match jase93.ErrInvalidData", tt.encoded, err)
+		}
+	}
+}
+
+func TestStream(t *testing.T) {
+	src := make([]byte, 10001)
+	rand.New(rand.NewSource(1)).Read(src)
+	want := Encode(nil, src)
+
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+	for i := 0; i < len(src); i += 7 {
+		end := i + 7
+		if end > len(src) {
+			end = len(src)
+		}
+		e.Write(src[i:end])
+	}
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatal("Encoder output differs from Encode")
+	}
+
+	got, err := ioutil.ReadAll(NewDecoder(iotest.OneByteReader(bytes.NewReader(want))))
+	if err != nil || !bytes.Equal(got, src) {
+		t.Errorf("Decoder = %d bytes, %v", len(got), err)
+	}
+
+	if _, err := ioutil.ReadAll(NewDecoder(bytes.NewReader(want[:len(want)-1]))); !errors.Is(err, jase93.ErrInvalidData) {
+		t.Errorf("Decoder(truncated) = %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package jase93
+
+import (
+	"io"
+	"net"
+)
+
+// SetBatchSize sets the Encoder to hold the encoded data of each Write until at least n bytes are pending, and then
+// write them all at once, reducing the number of system calls made for small, frequent writes. When the wrapped
+// io.Writer is a net.Conn, each batch is written as net.Buffers, with a single writev system call and without first
+// being copied into one buffer. Batches for other writers are joined and written with a single Write. Errors writing
+// a batch are returned by the Write that triggers it.
+//
+// SetBatchSize should be called before the first Write. Flush writes pending data early, and Close writes it along
+// with the end of the encoding. If n is not positive, each Write is written immediately, which is the default. The
+// batch size is retained by Reset, but pending data is not.
+func (e *Encoder) SetBatchSize(n int) {
+	if n <= 0 {
+		e.batch = nil
+		return
+	}
+	if e.batch == nil {
+		e.batch = new(batch)
+	}
+	e.batch.size = n
+}
+
+// Flush writes any encoded data held by a batch to the wrapped io.Writer. It does not flush the encoding state, which
+// would end the encoding; see Close.
+func (e *Encoder) Flush() error {
+	if e.batch == nil {
+		return nil
+	}
+	return e.flushBatch()
+}
+
+func (e *Encoder) flushBatch() error {
+	n, err := e.batch.writeTo(e.w)
+	e.stats.EncodedBytes += n
+	recordEncode(0, int(n), err)
+	return err
+}
+
+// batch holds encoded chunks to be written together.
+type batch struct {
+	size    int
+	chunks  [][]byte // pending chunks, whose buffers are reused after they are written
+	pending int
+	vec     net.Buffers
+	flat    []byte
+}
+
+func (b *batch) reset() {
+	b.chunks = b.chunks[:0]
+	b.pending = 0
+}
+
+// next returns an empty buffer for the next chunk, reusing the buffer of a previously written chunk if possible.
+func (b *batch) next() []byte {
+	if len(b.chunks) < cap(b.chunks) {
+		return b.chunks[:len(b.chunks)+1][len(b.chunks)][:0]
+	}
+	return nil
+}
+
+// add appends chunk, which should have been returned by next, and reports whether the batch is full.
+func (b *batch) add(chunk []byte) bool {
+	b.chunks = append(b.chunks, chunk)
+	b.pending += len(chunk)
+	return b.pending >= b.size
+}
+
+// writeTo writes the pending chunks to w.
+func (b *batch) writeTo(w io.Writer) (int64, error) {
+	if b.pending == 0 {
+		return 0, nil
+	}
+
+	defer b.reset()
+	if _, ok := w.(net.Conn); !ok {
+		// Other writers would be written each chunk in turn, so join them instead
+		b.flat = b.flat[:0]
+		for _, chunk := range b.chunks {
+			b.flat = append(b.flat, chunk...)
+		}
+		n, err := w.Write(b.flat)
+		return int64(n), err
+	}
+
+	// WriteTo consumes vec, so the chunks are kept for reuse
+	b.vec = append(b.vec[:0], b.chunks...)
+	return b.vec.WriteTo(w)
+}
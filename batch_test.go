@@ -0,0 +1,105 @@
+package jase93
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"testing"
+)
+
+type countingWriter struct {
+	bytes.Buffer
+	writes int
+}
+
+func (w *countingWriter) Write(data []byte) (int, error) {
+	w.writes++
+	return w.Buffer.Write(data)
+}
+
+func TestEncoderBatch(t *testing.T) {
+	src := bytes.Repeat([]byte("small frequent write "), 20)
+	want := Encode(nil, src)
+
+	var w countingWriter
+	e := NewEncoder(&w)
+	e.SetBatchSize(64)
+	calls := 0
+	for i := 0; i < len(src); i += 7 {
+		calls++
+		end := i + 7
+		if end > len(src) {
+			end = len(src)
+		}
+		if _, err := e.Write(src[i:end]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if w.Len() == 0 || w.Len() == len(want) {
+		t.Errorf("%d bytes written before Close, want a partial batch pending", w.Len())
+	}
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(w.Bytes(), want) {
+		t.Errorf("batched encoding = %q, want %q", w.Bytes(), want)
+	}
+	if max := len(want)/64 + 1; w.writes > max {
+		t.Errorf("%d Writes were made for %d calls, want at most %d", w.writes, calls, max)
+	}
+	if got := e.Stats().EncodedBytes; got != int64(len(want)) {
+		t.Errorf("EncodedBytes = %d, want %d", got, len(want))
+	}
+
+	// Flush writes a partial batch without ending the encoding
+	w.Reset()
+	e.Reset(&w)
+	e.Write(src[:10])
+	if w.Len() != 0 {
+		t.Fatalf("%d bytes written before Flush", w.Len())
+	}
+	if err := e.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if w.Len() == 0 {
+		t.Error("nothing written by Flush")
+	}
+}
+
+func TestEncoderBatchConn(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip(err)
+	}
+	defer l.Close()
+
+	src := bytes.Repeat([]byte{0x00, 0xff, 0x93}, 1000)
+	go func() {
+		c, err := net.Dial("tcp", l.Addr().String())
+		if err != nil {
+			return
+		}
+		e := NewEncoder(c)
+		e.SetBatchSize(1 << 10)
+		for i := 0; i < len(src); i += 3 {
+			e.Write(src[i : i+3])
+		}
+		e.Close()
+		c.Close()
+	}()
+
+	c, err := l.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	dec, err := ioutil.ReadAll(NewDecoder(c))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(dec, src) {
+		t.Error("decoded data differs")
+	}
+}
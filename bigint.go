@@ -0,0 +1,47 @@
+package jase93
+
+import "math/big"
+
+// Sign bytes of the encoded form of a big.Int.
+const (
+	bigIntPositive = 0
+	bigIntNegative = 1
+)
+
+// EncodeBigInt encodes x, such as a cryptographic integer, compactly for storage in JSON. The encoded bytes are a sign
+// byte, 0 for positive and 1 for negative, followed by the big-endian magnitude without leading zeros. Zero is encoded
+// as the empty string. A nil x is encoded as zero.
+func EncodeBigInt(x *big.Int) string {
+	if x == nil || x.Sign() == 0 {
+		return ""
+	}
+
+	buf := make([]byte, 1+(x.BitLen()+7)/8)
+	if x.Sign() < 0 {
+		buf[0] = bigIntNegative
+	}
+	x.FillBytes(buf[1:])
+	return string(Encode(nil, buf))
+}
+
+// DecodeBigInt decodes the output of EncodeBigInt. It rejects any other encoding of the same integer, such as one with
+// leading zeros or a negative zero, so the encoded form of a value is unique.
+func DecodeBigInt(s string) (*big.Int, error) {
+	if s == "" {
+		return new(big.Int), nil
+	}
+
+	buf, err := Decode(nil, []byte(s))
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) < 2 || buf[0] > bigIntNegative || buf[1] == 0 || string(Encode(nil, buf)) != s {
+		return nil, ErrInvalidData
+	}
+
+	x := new(big.Int).SetBytes(buf[1:])
+	if buf[0] == bigIntNegative {
+		x.Neg(x)
+	}
+	return x, nil
+}
@@ -0,0 +1,65 @@
+package jase93
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"testing/quick"
+)
+
+func TestBigInt(t *testing.T) {
+	p256, _ := new(big.Int).SetString("ffffffff00000001000000000000000000000000ffffffffffffffffffffffff", 16)
+	for _, x := range []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(-1),
+		big.NewInt(255),
+		big.NewInt(-256),
+		p256,
+		new(big.Int).Neg(p256),
+	} {
+		s := EncodeBigInt(x)
+		got, err := DecodeBigInt(s)
+		if err != nil || got.Cmp(x) != 0 {
+			t.Errorf("DecodeBigInt(EncodeBigInt(%v)) = %v, %v", x, got, err)
+		}
+	}
+	if s := EncodeBigInt(nil); s != "" {
+		t.Errorf("EncodeBigInt(nil) = %q", s)
+	}
+
+	if err := quick.Check(func(mag []byte, neg bool) bool {
+		x := new(big.Int).SetBytes(mag)
+		if neg {
+			x.Neg(x)
+		}
+		got, err := DecodeBigInt(EncodeBigInt(x))
+		if err != nil || got.Cmp(x) != 0 {
+			t.Errorf("DecodeBigInt(EncodeBigInt(%v)) = %v, %v", x, got, err)
+			return false
+		}
+		return true
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDecodeBigIntNonCanonical(t *testing.T) {
+	for _, buf := range [][]byte{
+		{0},          // Zero with a sign
+		{1},          // Negative zero
+		{0, 0, 1},    // Leading zero
+		{1, 0, 1},    // Negative with a leading zero
+		{2, 1},       // Invalid sign
+		{0xff, 0xff}, // Invalid sign
+	} {
+		s := string(Encode(nil, buf))
+		if x, err := DecodeBigInt(s); !errors.Is(err, ErrInvalidData) {
+			t.Errorf("DecodeBigInt(%q) (% x) = %v, %v", s, buf, x, err)
+		}
+	}
+
+	if _, err := DecodeBigInt("~"); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("DecodeBigInt(%q) = %v", "~", err)
+	}
+}
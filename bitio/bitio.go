@@ -0,0 +1,119 @@
+// Package bitio implements the bit accumulators underlying jase93's packing of bytes into words.
+//
+// Bits are packed least significant first. An n-bit word whose value is less than a threshold, full, carries one
+// extra bit, since the threshold is chosen so that setting bit n cannot exceed the largest encodable word.
+package bitio // import "github.com/jdknezek/jase93-go/bitio"
+
+// Bit returns 1 if b is true and 0 otherwise. The compiler implements it without branching.
+func Bit(b bool) uint {
+	var n uint
+	if b {
+		n = 1
+	}
+	return n
+}
+
+// BitReader accumulates bytes and reads them back as bit fields.
+type BitReader struct {
+	state uint64
+	bits  uint
+}
+
+// Reset discards any accumulated bits.
+func (r *BitReader) Reset() {
+	r.state = 0
+	r.bits = 0
+}
+
+// Len returns the number of accumulated bits.
+func (r *BitReader) Len() uint {
+	return r.bits
+}
+
+// Push accumulates the 8 bits of c.
+func (r *BitReader) Push(c byte) {
+	r.state |= uint64(c) << r.bits
+	r.bits += 8
+}
+
+// Push16 accumulates the 16 bits of v, equivalent to pushing its low byte and then its high byte.
+func (r *BitReader) Push16(v uint16) {
+	r.state |= uint64(v) << r.bits
+	r.bits += 16
+}
+
+// PushBits accumulates the low n bits of v, at most 32.
+func (r *BitReader) PushBits(v uint32, n uint) {
+	r.state |= uint64(v&(1<<n-1)) << r.bits
+	r.bits += n
+}
+
+// ReadBits reads an n-bit field. If fewer than n bits are accumulated, the missing high bits are zero.
+func (r *BitReader) ReadBits(n uint) uint32 {
+	v := uint32(r.state & (1<<n - 1))
+	r.state >>= n
+	if n > r.bits {
+		n = r.bits
+	}
+	r.bits -= n
+	return v
+}
+
+// ReadWord reads an n-bit word, extended by one more bit if its value is less than full.
+// At least n+1 bits should be accumulated.
+func (r *BitReader) ReadWord(n uint, full uint32) uint32 {
+	// If the low n bits are less than full, we can fit one more bit into word without exceeding the maximum. Computing
+	// the width without branching avoids mispredictions, since the outcome is effectively random.
+	n += Bit(uint32(r.state&(1<<n-1)) < full)
+	word := uint32(r.state & (1<<n - 1))
+	r.state >>= n
+	r.bits -= n
+	return word
+}
+
+// BitWriter accumulates bit fields and reads them back as bytes.
+type BitWriter struct {
+	state uint64
+	bits  uint
+}
+
+// Reset discards any accumulated bits.
+func (w *BitWriter) Reset() {
+	w.state = 0
+	w.bits = 0
+}
+
+// Len returns the number of accumulated bits.
+func (w *BitWriter) Len() uint {
+	return w.bits
+}
+
+// Bits returns the accumulated bits, which occupy the low Len() bits.
+func (w *BitWriter) Bits() uint64 {
+	return w.state
+}
+
+// WriteBits accumulates the low n bits of v.
+func (w *BitWriter) WriteBits(v uint32, n uint) {
+	w.state |= uint64(v&(1<<n-1)) << w.bits
+	w.bits += n
+}
+
+// WriteWord accumulates an n-bit word, extended by one more bit if its low n bits are less than full.
+// It reports the number of bits accumulated.
+func (w *BitWriter) WriteWord(word uint32, n uint, full uint32) uint {
+	// If the lower n bits aren't a full word, then we know this word includes an extra bit
+	n += Bit(word&(1<<n-1) < full)
+	w.WriteBits(word, n)
+	return n
+}
+
+// AppendBytes appends all whole accumulated bytes to dst.
+func (w *BitWriter) AppendBytes(dst []byte) []byte {
+	for w.bits >= 8 {
+		dst = append(dst, byte(w.state))
+		w.state >>= 8
+		w.bits -= 8
+	}
+	return dst
+}
@@ -0,0 +1,110 @@
+package bitio
+
+import (
+	"bytes"
+	"testing"
+	"testing/quick"
+)
+
+func TestBitReader(t *testing.T) {
+	var r BitReader
+	r.Push(0xa5)
+	r.Push(0x0f)
+
+	if v := r.ReadBits(4); v != 0x5 {
+		t.Errorf("ReadBits(4) = %#x != 0x5", v)
+	}
+	if v := r.ReadBits(8); v != 0xfa {
+		t.Errorf("ReadBits(8) = %#x != 0xfa", v)
+	}
+	if l := r.Len(); l != 4 {
+		t.Errorf("Len() = %d != 4", l)
+	}
+	if v := r.ReadBits(8); v != 0x0 {
+		t.Errorf("ReadBits(8) = %#x != 0x0", v)
+	}
+	if l := r.Len(); l != 0 {
+		t.Errorf("Len() = %d != 0", l)
+	}
+}
+
+func TestReadWord(t *testing.T) {
+	for _, tc := range []struct {
+		in   []byte
+		word uint32
+		bits uint
+	}{
+		// 13-bit words below 457 take a 14th bit
+		{[]byte{0x00, 0x20}, 0x2000, 2},
+		{[]byte{0xc8, 0x21}, 0x21c8, 2},
+		{[]byte{0xc8, 0x01}, 0x1c8, 2},
+		{[]byte{0xc9, 0x21}, 0x1c9, 3},
+		{[]byte{0xff, 0xff}, 0x1fff, 3},
+	} {
+		var r BitReader
+		for _, c := range tc.in {
+			r.Push(c)
+		}
+		if word := r.ReadWord(13, 457); word != tc.word || r.Len() != tc.bits {
+			t.Errorf("ReadWord(%x) = %#x, %d != %#x, %d", tc.in, word, r.Len(), tc.word, tc.bits)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	if err := quick.Check(func(in []byte) bool {
+		var r BitReader
+		var w BitWriter
+		var out []byte
+		for _, c := range in {
+			r.Push(c)
+			for r.Len() > 13 {
+				w.WriteWord(r.ReadWord(13, 457), 13, 457)
+				out = w.AppendBytes(out)
+			}
+		}
+		n := r.Len()
+		w.WriteBits(r.ReadBits(n), n)
+		w.WriteBits(0, 7)
+		out = w.AppendBytes(out)
+
+		return bytes.Equal(in, out[:len(in)])
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBit(t *testing.T) {
+	if Bit(true) != 1 || Bit(false) != 0 {
+		t.Errorf("Bit(true), Bit(false) = %d, %d", Bit(true), Bit(false))
+	}
+}
+
+func TestPush16(t *testing.T) {
+	var a, b BitReader
+	a.Push(0x5a)
+	a.Push(0x12)
+	a.Push(0x34)
+	b.Push(0x5a)
+	b.Push16(0x3412)
+	if a.Len() != b.Len() || a.ReadBits(24) != b.ReadBits(24) {
+		t.Error("Push16 differs from two Pushes")
+	}
+}
+
+func TestPushBits(t *testing.T) {
+	var a, b BitReader
+	a.Push(0x5a)
+	a.Push(0x12)
+	b.PushBits(0xa, 4)
+	b.PushBits(0xff25, 8)
+	b.PushBits(0x1, 4)
+	if a.Len() != b.Len() || a.ReadBits(16) != b.ReadBits(16) {
+		t.Error("PushBits differs from Push")
+	}
+
+	b.PushBits(0xffffffff, 32)
+	if b.Len() != 32 || b.ReadBits(32) != 0xffffffff {
+		t.Error("PushBits(32 bits) lost bits")
+	}
+}
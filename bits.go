@@ -0,0 +1,112 @@
+package jase93
+
+import (
+	"io"
+
+	"github.com/jdknezek/jase93-go/bitio"
+)
+
+// WriteBits encodes the low n bits of v, at most 64, without padding them to a byte boundary, so bit-oriented payloads,
+// such as packed sensor readings or Golomb-coded data, keep the density of the encoding. Bits are packed least
+// significant first, continuing the byte written by any previous WriteBits, and are read back with Decoder.ReadBits.
+//
+// A Write following WriteBits, and Close, first pad the current byte with zero bits, so bytes written with Write are
+// read back with Read after the same calls to ReadBits. Any length trailer records the number of bytes including
+// padding. Data written with WriteBits is not passed to the io.Writer set by SetTee or the hash set by SetDigest.
+func (e *Encoder) WriteBits(v uint64, n uint8) error {
+	if n > 64 {
+		panic("jase93: WriteBits of more than 64 bits")
+	}
+	if e.closed {
+		return ErrWriteAfterClose
+	}
+	e.mark()
+	e.trace.start("jase93.Encode")
+
+	raw := e.enc.raw
+	defer func() { e.stats.RawBytes += e.enc.raw - raw }()
+	if e.batch != nil {
+		if e.batch.add(e.enc.writeBits(e.batch.next(), v, uint(n))) {
+			return e.flushBatch()
+		}
+		return nil
+	}
+
+	e.buf = e.enc.writeBits(e.buf[:0], v, uint(n))
+	wn, err := e.w.Write(e.buf)
+	e.stats.EncodedBytes += int64(wn)
+	recordEncode(0, wn, err)
+	return err
+}
+
+// writeBits encodes the low n bits of v and appends the encoding of any words they complete to dst.
+func (e *encoder) writeBits(dst []byte, v uint64, n uint) []byte {
+	start := len(dst)
+	dst = e.start(dst)
+	e.raw += int64((e.partial+n+7)/8 - (e.partial+7)/8)
+	e.partial = (e.partial + n) % 8
+
+	enc := e.encoding
+	for n > 0 {
+		k := n
+		if k > 32 {
+			k = 32
+		}
+		e.bits.PushBits(uint32(v), k)
+		v >>= k
+		n -= k
+
+		for e.bits.Len() > enc.wordBits {
+			word := e.bits.ReadWord(enc.wordBits, enc.wordFull)
+			e.words++
+			e.extra += int64(bitio.Bit(word&enc.wordMask < enc.wordFull))
+
+			div := enc.div(word)
+			dst = append(dst, enc.encode[word-div*enc.base], enc.encode[div])
+		}
+	}
+	return e.wrap(dst, start)
+}
+
+// align pads the byte partly written by writeBits, if any, with zero bits.
+func (e *encoder) align(dst []byte) []byte {
+	if e.partial == 0 {
+		return dst
+	}
+	return e.writeBits(dst, 0, 8-e.partial)
+}
+
+// ReadBits reads the next n bits of decoded data, at most 64, written by Encoder.WriteBits, and returns them in the low
+// n bits of the result. Bits are read least significant first, continuing the byte read by any previous ReadBits; the
+// padding in the final byte of a stream reads as zero bits. It returns io.EOF if no data remains, and
+// io.ErrUnexpectedEOF if the data ends within the n bits.
+func (d *Decoder) ReadBits(n uint8) (uint64, error) {
+	if n > 64 {
+		panic("jase93: ReadBits of more than 64 bits")
+	}
+
+	var v uint64
+	for shift := uint(0); shift < uint(n); {
+		k := uint(n) - shift
+		if k > 32 {
+			k = 32
+		}
+		for d.bits.Len() < k {
+			var b [1]byte
+			rn, err := d.read(b[:])
+			if rn == 1 {
+				d.bits.Push(b[0])
+				continue
+			}
+			if err == io.EOF && (shift > 0 || d.bits.Len() > 0) {
+				err = io.ErrUnexpectedEOF
+			}
+			if err != nil {
+				return 0, err
+			}
+		}
+		v |= uint64(d.bits.ReadBits(k)) << shift
+		shift += k
+	}
+	return v, nil
+}
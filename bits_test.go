@@ -0,0 +1,88 @@
+package jase93
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+)
+
+func TestWriteBits(t *testing.T) {
+	type field struct {
+		v uint64
+		n uint8
+	}
+	rng := rand.New(rand.NewSource(0))
+	var fields []field
+	for i := 0; i < 1000; i++ {
+		n := uint8(rng.Intn(65))
+		fields = append(fields, field{rng.Uint64() & (1<<n - 1), n})
+	}
+
+	for _, enc := range []*Encoding{StdEncoding, StdEncoding.WithLengthTrailer(), HumanEncoding.WithHeader()} {
+		var buf bytes.Buffer
+		e := enc.NewEncoder(&buf)
+		for i, f := range fields {
+			if err := e.WriteBits(f.v, f.n); err != nil {
+				t.Fatal(err)
+			}
+			if i%100 == 99 {
+				e.Write([]byte("aligned"))
+			}
+		}
+		if err := e.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		d := enc.NewDecoder(&buf)
+		for i, f := range fields {
+			v, err := d.ReadBits(f.n)
+			if err != nil || v != f.v {
+				t.Fatalf("ReadBits(%d) #%d = %#x, %v, want %#x", f.n, i, v, err, f.v)
+			}
+			if i%100 == 99 {
+				var b [7]byte
+				if _, err := io.ReadFull(d, b[:]); err != nil || string(b[:]) != "aligned" {
+					t.Fatalf("Read #%d = %q, %v", i, b[:], err)
+				}
+			}
+		}
+		if v, err := d.ReadBits(8); err != io.EOF {
+			t.Errorf("ReadBits at EOF = %#x, %v", v, err)
+		}
+	}
+}
+
+func TestWriteBitsDensity(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+	for i := 0; i < 80; i++ {
+		e.WriteBits(uint64(i), 7)
+	}
+	e.Close()
+
+	if want := MaxEncodedLen(70); buf.Len() > want {
+		t.Errorf("80 7-bit values encoded to %d bytes, want at most %d", buf.Len(), want)
+	}
+	if stats := e.Stats(); stats.RawBytes != 70 {
+		t.Errorf("RawBytes = %d, want 70", stats.RawBytes)
+	}
+}
+
+func TestReadBitsEOF(t *testing.T) {
+	encoded := Encode(nil, []byte{0xa5, 0x0f})
+	d := NewDecoder(bytes.NewReader(encoded))
+	if v, err := d.ReadBits(4); err != nil || v != 0x5 {
+		t.Errorf("ReadBits(4) = %#x, %v", v, err)
+	}
+	if v, err := d.ReadBits(20); err != io.ErrUnexpectedEOF {
+		t.Errorf("ReadBits(20) = %#x, %v, want io.ErrUnexpectedEOF", v, err)
+	}
+
+	d = NewDecoder(bytes.NewReader(encoded))
+	d.ReadBits(4)
+	var b [2]byte
+	if n, err := d.Read(b[:]); n != 1 || b[0] != 0x0f {
+		t.Errorf("Read after ReadBits = %d, %v, %#x", n, err, b[:n])
+	}
+}
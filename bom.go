@@ -0,0 +1,133 @@
+package jase93
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Byte order marks.
+var (
+	bomUTF8    = []byte{0xef, 0xbb, 0xbf}
+	bomUTF16LE = []byte{0xff, 0xfe}
+	bomUTF16BE = []byte{0xfe, 0xff}
+)
+
+// StripBOM returns a reader of the text of r without any leading byte order mark, as added by some editors, notably on
+// Windows. Text marked as UTF-16, little- or big-endian, is converted to UTF-8, so it can be decoded too. Other text is
+// passed through unchanged.
+func StripBOM(r io.Reader) io.Reader {
+	br := bufio.NewReader(r)
+	bom, _ := br.Peek(len(bomUTF8))
+	switch {
+	case bytes.HasPrefix(bom, bomUTF8):
+		br.Discard(len(bomUTF8))
+	case bytes.HasPrefix(bom, bomUTF16LE):
+		br.Discard(len(bomUTF16LE))
+		return &runeByteReader{rr: &utf16Reader{r: br, order: binary.LittleEndian, next: -1}}
+	case bytes.HasPrefix(bom, bomUTF16BE):
+		br.Discard(len(bomUTF16BE))
+		return &runeByteReader{rr: &utf16Reader{r: br, order: binary.BigEndian, next: -1}}
+	}
+	return br
+}
+
+// NewRuneDecoder creates a new Decoder that decodes the runes read from rr, such as those of a text transformer, skipping
+// any leading byte order mark. Offsets reported by errors refer to the UTF-8 encoding of the runes.
+func (enc *Encoding) NewRuneDecoder(rr io.RuneReader) *Decoder {
+	return enc.NewDecoder(&runeByteReader{rr: rr, skipBOM: true})
+}
+
+// NewRuneDecoder creates a new Decoder that decodes the runes read from rr with StdEncoding.
+func NewRuneDecoder(rr io.RuneReader) *Decoder {
+	return StdEncoding.NewRuneDecoder(rr)
+}
+
+// runeByteReader reads the UTF-8 encoding of the runes read from rr.
+type runeByteReader struct {
+	rr      io.RuneReader
+	skipBOM bool
+	buf     [utf8.UTFMax]byte
+	pending []byte // the remainder of a rune that did not fit
+}
+
+func (r *runeByteReader) Read(data []byte) (int, error) {
+	n := copy(data, r.pending)
+	r.pending = r.pending[n:]
+
+	for n < len(data) {
+		c, _, err := r.rr.ReadRune()
+		if err != nil {
+			if n > 0 {
+				return n, nil
+			}
+			return 0, err
+		}
+		if r.skipBOM {
+			r.skipBOM = false
+			if c == '\ufeff' {
+				continue
+			}
+		}
+
+		if c < utf8.RuneSelf {
+			data[n] = byte(c)
+			n++
+			continue
+		}
+		size := utf8.EncodeRune(r.buf[:], c)
+		m := copy(data[n:], r.buf[:size])
+		r.pending = r.buf[m:size]
+		n += m
+	}
+	return n, nil
+}
+
+// utf16Reader reads the runes of UTF-16 text. Unpaired surrogates are read as utf8.RuneError.
+type utf16Reader struct {
+	r     io.Reader
+	order binary.ByteOrder
+	unit  [2]byte
+	next  rune // a unit read after an unpaired surrogate, or -1
+}
+
+func (r *utf16Reader) ReadRune() (rune, int, error) {
+	c := r.next
+	r.next = -1
+	if c < 0 {
+		var err error
+		if c, err = r.readUnit(); err != nil {
+			return 0, 0, err
+		}
+	}
+	if c < 0xd800 || c >= 0xdc00 {
+		// Not a high surrogate
+		if utf16.IsSurrogate(c) {
+			return utf8.RuneError, 2, nil
+		}
+		return c, 2, nil
+	}
+
+	c2, err := r.readUnit()
+	if err == io.EOF {
+		return utf8.RuneError, 2, nil
+	} else if err != nil {
+		return 0, 0, err
+	}
+	if dec := utf16.DecodeRune(c, c2); dec != utf8.RuneError {
+		return dec, 4, nil
+	}
+	r.next = c2
+	return utf8.RuneError, 2, nil
+}
+
+// readUnit reads a code unit, returning io.ErrUnexpectedEOF if the text ends in the middle of one.
+func (r *utf16Reader) readUnit() (rune, error) {
+	if _, err := io.ReadFull(r.r, r.unit[:]); err != nil {
+		return 0, err
+	}
+	return rune(r.order.Uint16(r.unit[:])), nil
+}
@@ -0,0 +1,85 @@
+package jase93
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"unicode/utf16"
+)
+
+func encodeUTF16(s string, order binary.AppendByteOrder, bom bool) []byte {
+	var buf []byte
+	if bom {
+		buf = order.AppendUint16(buf, 0xfeff)
+	}
+	for _, c := range utf16.Encode([]rune(s)) {
+		buf = order.AppendUint16(buf, c)
+	}
+	return buf
+}
+
+func TestStripBOM(t *testing.T) {
+	src := []byte("Man is distinguished, not only by his reason")
+	encoded := string(Encode(nil, src))
+
+	for _, tc := range []struct {
+		name string
+		in   []byte
+	}{
+		{"none", []byte(encoded)},
+		{"UTF-8", append([]byte("\ufeff"), encoded...)},
+		{"UTF-16LE", encodeUTF16(encoded, binary.LittleEndian, true)},
+		{"UTF-16BE", encodeUTF16(encoded, binary.BigEndian, true)},
+	} {
+		r := StripBOM(iotest.OneByteReader(bytes.NewReader(tc.in)))
+		dec, err := ioutil.ReadAll(NewDecoder(r))
+		if err != nil || !bytes.Equal(dec, src) {
+			t.Errorf("%s: got %q, %v", tc.name, dec, err)
+		}
+	}
+
+	// Only a leading mark is removed
+	if out, _ := ioutil.ReadAll(StripBOM(strings.NewReader("a\ufeff"))); string(out) != "a\ufeff" {
+		t.Errorf("StripBOM removed an inner mark: %q", out)
+	}
+}
+
+func TestUTF16Reader(t *testing.T) {
+	for _, tc := range []struct {
+		units []uint16
+		want  string
+	}{
+		{[]uint16{'a', 0xd83d, 0xde00, 'b'}, "a\U0001f600b"},
+		{[]uint16{'a', 0xde00, 'b'}, "a\ufffdb"}, // Unpaired low surrogate
+		{[]uint16{'a', 0xd83d, 'b'}, "a\ufffdb"}, // Unpaired high surrogate
+		{[]uint16{'a', 0xd83d}, "a\ufffd"},       // Truncated pair
+		{[]uint16{0xd83d, 0xd83d, 0xde00}, "\ufffd\U0001f600"},
+	} {
+		var in []byte
+		for _, u := range tc.units {
+			in = binary.BigEndian.AppendUint16(in, u)
+		}
+		out, err := ioutil.ReadAll(&runeByteReader{rr: &utf16Reader{r: bytes.NewReader(in), order: binary.BigEndian, next: -1}})
+		if err != nil || string(out) != tc.want {
+			t.Errorf("%x: got %q, %v, want %q", tc.units, out, err, tc.want)
+		}
+	}
+}
+
+func TestNewRuneDecoder(t *testing.T) {
+	src := []byte("Man is distinguished, not only by his reason")
+	encoded := string(Encode(nil, src))
+
+	dec, err := ioutil.ReadAll(NewRuneDecoder(strings.NewReader("\ufeff" + encoded)))
+	if err != nil || !bytes.Equal(dec, src) {
+		t.Errorf("got %q, %v", dec, err)
+	}
+
+	_, err = ioutil.ReadAll(NewRuneDecoder(strings.NewReader(encoded[:4] + "“")))
+	if cerr, ok := err.(*CorruptInputError); !ok || cerr.Offset != 4 || cerr.Rune != '“' {
+		t.Errorf("got %v, want invalid U+201C at offset 4", err)
+	}
+}
@@ -0,0 +1,30 @@
+package jase93
+
+// Bytes is a byte slice that is stored jase93-encoded by text-based encodings such as JSON, and by gob, so that it
+// remains text-safe when those encodings are embedded in JSON or text logs.
+type Bytes []byte
+
+// MarshalText implements encoding.TextMarshaler, encoding b with StdEncoding.
+func (b Bytes) MarshalText() ([]byte, error) {
+	return Encode(nil, b), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, decoding text with StdEncoding.
+func (b *Bytes) UnmarshalText(text []byte) error {
+	return b.GobDecode(text)
+}
+
+// GobEncode implements gob.GobEncoder, encoding b with StdEncoding.
+func (b Bytes) GobEncode() ([]byte, error) {
+	return Encode(nil, b), nil
+}
+
+// GobDecode implements gob.GobDecoder, decoding data with StdEncoding.
+func (b *Bytes) GobDecode(data []byte) error {
+	dec, err := Decode(nil, data)
+	if err != nil {
+		return err
+	}
+	*b = dec
+	return nil
+}
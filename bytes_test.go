@@ -0,0 +1,56 @@
+package jase93
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestBytesGob(t *testing.T) {
+	type record struct {
+		Name string
+		Data Bytes
+	}
+	in := record{Name: "n", Data: Bytes("\x00\x01binary\xfe\xff")}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(buf.Bytes(), Encode(nil, in.Data)) {
+		t.Errorf("gob stream %q does not contain encoded data", buf.Bytes())
+	}
+
+	var out record
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Name != in.Name || !bytes.Equal(out.Data, in.Data) {
+		t.Errorf("gob round trip = %+v, want %+v", out, in)
+	}
+}
+
+func TestBytesGobDecodeInvalid(t *testing.T) {
+	var b Bytes
+	if err := b.GobDecode([]byte(`"`)); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("GobDecode = %v", err)
+	}
+}
+
+func TestBytesJSON(t *testing.T) {
+	in := Bytes("\x00\x01binary\xfe\xff")
+	data, err := json.Marshal(map[string]Bytes{"data": in})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out map[string]Bytes
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out["data"], in) {
+		t.Errorf("JSON round trip of %s = %q, want %q", data, out["data"], in)
+	}
+}
@@ -0,0 +1,245 @@
+package jase93
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"strconv"
+)
+
+// DefaultChunkSize is the number of bytes of data per chunk used by NewChunkWriter when size is not positive. Its lines
+// are a little under 80 KiB long.
+const DefaultChunkSize = 64 << 10
+
+// ErrChunkSequence indicates that a chunk was missing, duplicated, or out of order.
+var ErrChunkSequence = errors.New("jase93: chunk out of sequence")
+
+// chunk is a line of the chunked format.
+type chunk struct {
+	Seq  int64  `json:"seq"`
+	CRC  string `json:"crc"`
+	Data string `json:"data"`
+	Last bool   `json:"last,omitempty"`
+
+	// Parity chunks; see ChunkWriter.SetParity
+	Parity int `json:"parity,omitempty"`
+	Shards int `json:"shards,omitempty"`
+}
+
+// ChunkWriter splits a stream into chunks of newline-delimited JSON (NDJSON), one per line, so it can be carried by
+// transports that limit the size of each message:
+//
+//	{"seq":0,"crc":"8587d865","data":"<encoded data>"}
+//	{"seq":1,"crc":"0b8b1cc0","data":"<encoded data>","last":true}
+//
+// seq numbers the chunks from 0, crc is the CRC-32 (IEEE) of the chunk's data in hexadecimal, and data is its
+// encoding. The final chunk, which may be empty, is marked by last, so truncated streams are detected.
+type ChunkWriter struct {
+	enc    *json.Encoder
+	size   int
+	buf    []byte
+	seq    int64
+	closed bool
+
+	// Parity groups; see SetParity
+	k, m   int
+	shards [][]byte
+}
+
+// NewChunkWriter creates a ChunkWriter that writes chunks of size bytes of data to w. Each line is at most
+// MaxEncodedLen(size) plus 70 bytes long. If size is not positive, DefaultChunkSize is used.
+func NewChunkWriter(w io.Writer, size int) *ChunkWriter {
+	if size <= 0 {
+		size = DefaultChunkSize
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &ChunkWriter{enc: enc, size: size}
+}
+
+// Write writes a chunk for each size bytes of data accumulated, holding back the remainder.
+func (c *ChunkWriter) Write(data []byte) (int, error) {
+	if c.closed {
+		return 0, ErrWriteAfterClose
+	}
+
+	n := len(data)
+	if len(c.buf) > 0 {
+		m := c.size - len(c.buf)
+		if m > len(data) {
+			m = len(data)
+		}
+		c.buf = append(c.buf, data[:m]...)
+		data = data[m:]
+		if len(c.buf) < c.size {
+			return n, nil
+		}
+		if err := c.writeChunk(c.buf, false); err != nil {
+			return n - len(data), err
+		}
+		c.buf = c.buf[:0]
+	}
+
+	for ; len(data) >= c.size; data = data[c.size:] {
+		if err := c.writeChunk(data[:c.size], false); err != nil {
+			return n - len(data), err
+		}
+	}
+	c.buf = append(c.buf, data...)
+	return n, nil
+}
+
+// Close writes the final chunk, holding any remaining data. It does not close the wrapped io.Writer.
+func (c *ChunkWriter) Close() error {
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	return c.writeChunk(c.buf, true)
+}
+
+func (c *ChunkWriter) writeChunk(data []byte, last bool) error {
+	err := c.enc.Encode(chunk{
+		Seq:  c.seq,
+		CRC:  fmt.Sprintf("%08x", crc32.ChecksumIEEE(data)),
+		Data: string(Encode(nil, data)),
+		Last: last,
+	})
+	c.seq++
+	if err != nil || c.k == 0 {
+		return err
+	}
+	return c.addShard(data, last)
+}
+
+// ChunkReader reassembles a stream from the chunks written by a ChunkWriter.
+type ChunkReader struct {
+	r      io.Reader
+	dec    *json.Decoder
+	next   int64
+	buf    []byte
+	done   bool
+	err    error
+	window int
+	held   map[int64]heldChunk
+
+	// Parity groups; see SetParity
+	k, m        int
+	lines       *bufio.Reader
+	group       int64
+	groupData   [][]byte
+	groupParity [][]byte
+	groupLen    int
+	groupNext   int
+	pending     *chunk
+}
+
+// heldChunk is a verified chunk that arrived ahead of its turn.
+type heldChunk struct {
+	data []byte
+	last bool
+}
+
+// NewChunkReader creates a ChunkReader that reads chunks from r. Read returns a *ChunkError if a chunk is invalid,
+// missing, or out of sequence, and io.ErrUnexpectedEOF if r ends before the final chunk.
+func NewChunkReader(r io.Reader) *ChunkReader {
+	return &ChunkReader{r: r, dec: json.NewDecoder(r)}
+}
+
+// SetWindow sets the ChunkReader to tolerate chunks arriving out of order, as from an unordered queue, holding up to n
+// chunks that arrive ahead of the next one in sequence until it arrives. Duplicates of chunks already read or held are
+// ignored, so at-least-once delivery is tolerated too. A chunk more than n ahead of the next one in sequence is an
+// error, as is the end of the input while chunks are held. If n is not positive, chunks must arrive in order, which is
+// the default.
+func (c *ChunkReader) SetWindow(n int) {
+	if n < 0 {
+		n = 0
+	}
+	c.window = n
+	if c.held == nil && n > 0 {
+		c.held = make(map[int64]heldChunk)
+	}
+}
+
+// Read reads reassembled data.
+func (c *ChunkReader) Read(data []byte) (int, error) {
+	for len(c.buf) == 0 {
+		if c.err != nil {
+			return 0, c.err
+		}
+		if c.done {
+			return 0, io.EOF
+		}
+		if c.k > 0 {
+			c.buf, c.err = c.readParity()
+		} else {
+			c.buf, c.err = c.readChunk()
+		}
+	}
+
+	n := copy(data, c.buf)
+	c.buf = c.buf[n:]
+	return n, nil
+}
+
+// readChunk returns the data of the next chunk in sequence, reading and verifying chunks until it arrives.
+func (c *ChunkReader) readChunk() ([]byte, error) {
+	for {
+		if h, ok := c.held[c.next]; ok {
+			delete(c.held, c.next)
+			c.next++
+			c.done = h.last
+			return h.data, nil
+		}
+
+		var ch chunk
+		if err := c.dec.Decode(&ch); err == io.EOF {
+			if len(c.held) > 0 {
+				return nil, &ChunkError{Seq: c.next, Err: ErrChunkSequence}
+			}
+			return nil, io.ErrUnexpectedEOF
+		} else if err != nil {
+			return nil, err
+		}
+
+		if c.window > 0 {
+			if _, ok := c.held[ch.Seq]; ok || ch.Seq < c.next {
+				// A duplicate
+				continue
+			}
+			if ch.Seq > c.next+int64(c.window) {
+				return nil, &ChunkError{Seq: ch.Seq, Err: ErrChunkSequence}
+			}
+		} else if ch.Seq != c.next {
+			return nil, &ChunkError{Seq: ch.Seq, Err: ErrChunkSequence}
+		}
+
+		data, err := verifyChunk(&ch)
+		if err != nil {
+			return nil, err
+		}
+		if ch.Seq != c.next {
+			c.held[ch.Seq] = heldChunk{data: data, last: ch.Last}
+			continue
+		}
+
+		c.next++
+		c.done = ch.Last
+		return data, nil
+	}
+}
+
+// verifyChunk decodes the data of ch and verifies its checksum.
+func verifyChunk(ch *chunk) ([]byte, error) {
+	data, err := Decode(nil, []byte(ch.Data))
+	if err != nil {
+		return nil, &ChunkError{Seq: ch.Seq, Err: err}
+	}
+	if crc, err := strconv.ParseUint(ch.CRC, 16, 32); err != nil || len(ch.CRC) != 8 || uint32(crc) != crc32.ChecksumIEEE(data) {
+		return nil, &ChunkError{Seq: ch.Seq, Err: ErrChecksum}
+	}
+	return data, nil
+}
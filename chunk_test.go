@@ -0,0 +1,122 @@
+package jase93
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func writeChunks(t *testing.T, src []byte, size int) []string {
+	var buf bytes.Buffer
+	w := NewChunkWriter(&buf, size)
+	for i := 0; i < len(src); i += 37 {
+		end := i + 37
+		if end > len(src) {
+			end = len(src)
+		}
+		if _, err := w.Write(src[i:end]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return strings.SplitAfter(strings.TrimSuffix(buf.String(), "\n"), "\n")
+}
+
+func TestChunkWriter(t *testing.T) {
+	src := make([]byte, 1000)
+	rand.New(rand.NewSource(0)).Read(src)
+
+	for _, size := range []int{1, 100, 1000, 4096} {
+		lines := writeChunks(t, src, size)
+		if want := len(src)/size + 1; len(lines) != want {
+			t.Errorf("size %d: %d chunks, want %d", size, len(lines), want)
+		}
+		for _, line := range lines {
+			if max := MaxEncodedLen(size) + 70; len(line) > max {
+				t.Errorf("size %d: line of %d bytes, want at most %d", size, len(line), max)
+			}
+		}
+		if !strings.Contains(lines[len(lines)-1], `"last":true`) {
+			t.Errorf("size %d: final chunk %q is not marked", size, lines[len(lines)-1])
+		}
+
+		dec, err := ioutil.ReadAll(NewChunkReader(strings.NewReader(strings.Join(lines, ""))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(dec, src) {
+			t.Errorf("size %d: reassembled data differs", size)
+		}
+	}
+}
+
+func TestChunkReaderErrors(t *testing.T) {
+	lines := writeChunks(t, []byte("Man is distinguished, not only by his reason"), 10)
+	join := func(lines ...string) string { return strings.Join(lines, "") }
+
+	for _, tc := range []struct {
+		in  string
+		seq int64
+		err error
+	}{
+		{join(lines[0], lines[2]), 2, ErrChunkSequence},
+		{join(lines[0], lines[0]), 0, ErrChunkSequence},
+		{join(lines[0], strings.Replace(lines[1], `"crc":"`, `"crc":"0`, 1)), 1, ErrChecksum},
+	} {
+		_, err := ioutil.ReadAll(NewChunkReader(strings.NewReader(tc.in)))
+		var cerr *ChunkError
+		if !errors.As(err, &cerr) || cerr.Seq != tc.seq || !errors.Is(err, tc.err) || !errors.Is(err, ErrInvalidData) {
+			t.Errorf("ReadAll(%q) = %v, want %v at chunk %d", tc.in, err, tc.err, tc.seq)
+		}
+	}
+
+	if _, err := ioutil.ReadAll(NewChunkReader(strings.NewReader(join(lines[:len(lines)-1]...)))); err != io.ErrUnexpectedEOF {
+		t.Errorf("ReadAll(truncated) = %v", err)
+	}
+}
+
+func TestChunkReaderWindow(t *testing.T) {
+	src := make([]byte, 1000)
+	rand.New(rand.NewSource(0)).Read(src)
+	lines := writeChunks(t, src, 100)
+
+	// Each chunk arrives up to 3 places late, and some twice
+	shuffled := append([]string(nil), lines...)
+	for i := 0; i+3 < len(shuffled); i += 4 {
+		shuffled[i], shuffled[i+3] = shuffled[i+3], shuffled[i]
+	}
+	shuffled = append(shuffled[:5], append([]string{lines[0], lines[4]}, shuffled[5:]...)...)
+
+	r := NewChunkReader(strings.NewReader(strings.Join(shuffled, "")))
+	r.SetWindow(3)
+	dec, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(dec, src) {
+		t.Error("reassembled data differs")
+	}
+
+	// Chunks too far ahead, or never followed by the missing chunk, are reported
+	for _, tc := range []struct {
+		in  []string
+		seq int64
+	}{
+		{[]string{lines[0], lines[4]}, 4},
+		{[]string{lines[0], lines[2], lines[3]}, 1},
+	} {
+		r := NewChunkReader(strings.NewReader(strings.Join(tc.in, "")))
+		r.SetWindow(2)
+		_, err := ioutil.ReadAll(r)
+		var cerr *ChunkError
+		if !errors.As(err, &cerr) || cerr.Seq != tc.seq || !errors.Is(err, ErrChunkSequence) {
+			t.Errorf("ReadAll(%q) = %v, want chunk %d out of sequence", tc.in, err, tc.seq)
+		}
+	}
+}
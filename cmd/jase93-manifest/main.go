@@ -0,0 +1,58 @@
+// Command jase93-manifest writes a JSON manifest of the files in a directory, mapping their paths to their
+// jase93-encoded contents. See package jase93fs, whose Manifest.FS loads the files back as an fs.FS.
+//
+// Usage:
+//
+//	jase93-manifest [-o FILE] DIR
+//
+// Paths in the manifest are slash-separated and relative to DIR. Only regular files are included.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jdknezek/jase93-go/jase93fs"
+)
+
+func main() {
+	out := flag.String("o", "", "write to `FILE` instead of standard output")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: jase93-manifest [-o FILE] DIR")
+		os.Exit(2)
+	}
+
+	if err := writeOutput(*out, flag.Arg(0)); err != nil {
+		fmt.Fprintln(os.Stderr, "jase93-manifest:", err)
+		os.Exit(1)
+	}
+}
+
+func writeManifest(w io.Writer, dir string) error {
+	m, err := jase93fs.Build(os.DirFS(dir))
+	if err != nil {
+		return err
+	}
+	_, err = m.WriteTo(w)
+	return err
+}
+
+// writeOutput writes the manifest of dir to the file name, or standard output if name is empty, and closes the file,
+// so that an error writing any of it is reported.
+func writeOutput(name, dir string) error {
+	if name == "" {
+		return writeManifest(os.Stdout, dir)
+	}
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := writeManifest(f, dir); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
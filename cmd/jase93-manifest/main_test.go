@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jdknezek/jase93-go/jase93fs"
+)
+
+func TestWriteManifest(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string][]byte{
+		"a.bin":     {0x00, 0x93, 0xff},
+		"sub/b.txt": []byte("hello"),
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(name)), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := writeManifest(&buf, dir); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := jase93fs.Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fsys, err := m.FS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range files {
+		if data, err := fs.ReadFile(fsys, name); err != nil || !bytes.Equal(data, want) {
+			t.Errorf("%s = %q, %v, want %q", name, data, err, want)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Command jase93-vectors writes JSON test vectors for implementations of jase93 in other languages.
+//
+// Usage:
+//
+//	jase93-vectors [-go PKG] [-o FILE]
+//
+// The output has the form:
+//
+//	{
+//		"encoding": "jase93",
+//		"vectors": [
+//			{"name": "empty", "hex": "", "encoded": ""},
+//			...
+//		]
+//	}
+//
+// where hex is the raw data and encoded is its canonical encoding. The vectors cover every byte value, word
+// boundaries, and words with and without an extra bit. testdata/vectors.json is generated by this command.
+//
+// With -go, the vectors are instead written as Go source declaring them in package PKG, which is how the jase93
+// command embeds them for its selftest command.
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"math/rand"
+	"os"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+func main() {
+	out := flag.String("o", "", "write to `FILE` instead of standard output")
+	pkg := flag.String("go", "", "write Go source in package `PKG` instead of JSON")
+	flag.Parse()
+
+	write := writeVectors
+	if *pkg != "" {
+		write = func(w io.Writer) error { return writeGoVectors(w, *pkg) }
+	}
+	if err := writeOutput(*out, write); err != nil {
+		fmt.Fprintln(os.Stderr, "jase93-vectors:", err)
+		os.Exit(1)
+	}
+}
+
+// writeOutput calls write with the file name, or standard output if name is empty, and closes the file, so that an
+// error writing any of it is reported.
+func writeOutput(name string, write func(io.Writer) error) error {
+	if name == "" {
+		return write(os.Stdout)
+	}
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+type vector struct {
+	Name    string `json:"name"`
+	Hex     string `json:"hex"`
+	Encoded string `json:"encoded"`
+}
+
+type vectors struct {
+	Encoding string   `json:"encoding"`
+	Vectors  []vector `json:"vectors"`
+}
+
+func (v *vectors) add(name string, data []byte) {
+	v.Vectors = append(v.Vectors, vector{name, hex.EncodeToString(data), string(jase93.Encode(nil, data))})
+}
+
+// generate returns the test vectors.
+func generate() *vectors {
+	v := &vectors{Encoding: "jase93"}
+
+	v.add("empty", []byte{})
+	v.add("one zero byte", []byte{0})
+	v.add("two zero bytes", []byte{0, 0})
+	v.add("one 0xff byte", []byte{0xff})
+	v.add("two 0xff bytes", []byte{0xff, 0xff})
+	v.add("extra bit taken", []byte{0x00, 0x20})
+	v.add("largest extra-bit word", []byte{0xc8, 0x21})
+	v.add("smallest 13-bit word", []byte{0xc9, 0x01})
+
+	rng := rand.New(rand.NewSource(93))
+	for n := 3; n <= 8; n++ {
+		data := make([]byte, n)
+		rng.Read(data)
+		v.add(fmt.Sprintf("random tail %d", n), data)
+	}
+
+	v.add("basE91 sample", []byte("Man is distinguished, not only by his reason, but by this singular passion from other animals, which is a lust of the mind, that by a perseverance of delight in the continued and indefatigable generation of knowledge, exceeds the short vehemence of any carnal pleasure."))
+
+	for c := 0; c < 256; c++ {
+		v.add(fmt.Sprintf("byte %#02x", c), []byte{byte(c)})
+	}
+
+	// Every length up to several words, where the final partial word varies
+	for n := 3; n <= 16; n++ {
+		v.add(fmt.Sprintf("%d zero bytes", n), make([]byte, n))
+		v.add(fmt.Sprintf("%d 0xff bytes", n), bytes.Repeat([]byte{0xff}, n))
+	}
+
+	// Words just below and at the extra-bit threshold, with the extra bit clear and set
+	for _, word := range []uint16{0, jase93.WordFull - 1, jase93.WordFull, 1<<jase93.WordBits - 1} {
+		for extra := uint16(0); extra < 2; extra++ {
+			w := word | extra<<jase93.WordBits
+			v.add(fmt.Sprintf("word %#04x", w), []byte{byte(w), byte(w >> 8)})
+		}
+	}
+
+	return v
+}
+
+// writeVectors writes the test vectors as indented JSON.
+func writeVectors(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "\t")
+	return enc.Encode(generate())
+}
+
+// writeGoVectors writes the test vectors as Go source declaring the variable testVectors in package pkg.
+func writeGoVectors(w io.Writer, pkg string) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by jase93-vectors; DO NOT EDIT.\n\npackage %s\n\n", pkg)
+	fmt.Fprintf(&buf, "// testVectors are the test vectors of testdata/vectors.json.\n")
+	fmt.Fprintf(&buf, "var testVectors = []struct{ name, hex, encoded string }{\n")
+	for _, v := range generate().Vectors {
+		fmt.Fprintf(&buf, "\t{%q, %q, %q},\n", v.Name, v.Hex, v.Encoded)
+	}
+	fmt.Fprintf(&buf, "}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(src)
+	return err
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+func TestTestdata(t *testing.T) {
+	want, err := ioutil.ReadFile("../../testdata/vectors.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := writeVectors(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Error("testdata/vectors.json is out of date; regenerate it with jase93-vectors -o testdata/vectors.json")
+	}
+}
+
+func TestGoVectors(t *testing.T) {
+	want, err := ioutil.ReadFile("../jase93/vectors.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := writeGoVectors(&buf, "main"); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Error("cmd/jase93/vectors.go is out of date; regenerate it with go generate ./cmd/jase93")
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+// errDiffer reports that the inputs of diff differ, which has been reported already.
+var errDiffer = errors.New("inputs differ")
+
+// runDiff decodes two inputs and reports the first difference between them.
+func runDiff(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("jase93 diff", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		return fmt.Errorf("diff requires two files")
+	}
+	if flags.Arg(0) == "-" && flags.Arg(1) == "-" {
+		return fmt.Errorf("only one file may be standard input")
+	}
+
+	a, aChars, aEnc, err := readDiffInput(flags.Arg(0), stdin)
+	if err != nil {
+		return err
+	}
+	b, bChars, bEnc, err := readDiffInput(flags.Arg(1), stdin)
+	if err != nil {
+		return err
+	}
+
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	switch {
+	case n < len(a) && n < len(b):
+		aFirst, aLast := encodedSpan(aEnc, aChars, n)
+		bFirst, bLast := encodedSpan(bEnc, bChars, n)
+		fmt.Fprintf(stdout, "%s %s differ: decoded offset %d, encoded offsets %d-%d and %d-%d\n",
+			flags.Arg(0), flags.Arg(1), n, aFirst, aLast, bFirst, bLast)
+	case len(a) != len(b):
+		short := flags.Arg(0)
+		if len(b) < len(a) {
+			short = flags.Arg(1)
+		}
+		fmt.Fprintf(stdout, "EOF on %s after decoded offset %d\n", short, n)
+	default:
+		return nil
+	}
+	return errDiffer
+}
+
+// readDiffInput reads and decodes the named input, which may be armored, returning the decoded data, the encoded
+// characters, and their Encoding.
+func readDiffInput(name string, stdin io.Reader) ([]byte, []inspectChar, *jase93.Encoding, error) {
+	in := stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		defer f.Close()
+		in = f
+	}
+	data, err := ioutil.ReadAll(in)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	chars, enc := inspectFraming(ioutil.Discard, data)
+	encoded := make([]byte, len(chars))
+	for i, c := range chars {
+		encoded[i] = c.c
+	}
+	decoded, err := enc.Decode(nil, encoded)
+	var cerr *jase93.CorruptInputError
+	if errors.As(err, &cerr) && cerr.Offset < int64(len(chars)) {
+		// Report the offset in the input rather than among the encoded characters
+		e := *cerr
+		e.Offset = chars[e.Offset].offset
+		err = &e
+	}
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return decoded, chars, enc, nil
+}
+
+// encodedSpan returns the offsets of the first and last encoded characters of the words that decode to the byte at
+// offset, which the characters must encode with enc.
+func encodedSpan(enc *jase93.Encoding, chars []inspectChar, offset int) (first, last int64) {
+	start, end := 8*offset, 8*offset+8
+	first = -1
+	for i, bit := 0, 0; i < len(chars) && bit < end; i += 2 {
+		n := 8
+		if i+1 < len(chars) {
+			word, _ := enc.DecodeWord(chars[i].c, chars[i+1].c)
+			n = enc.WordBitLen(word)
+		}
+		if bit+n > start {
+			if first < 0 {
+				first = chars[i].offset
+			}
+			last = chars[i].offset
+			if i+1 < len(chars) {
+				last = chars[i+1].offset
+			}
+		}
+		bit += n
+	}
+	return first, last
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+func TestRunDiff(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jase93")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	write := func(name string, data []byte) string {
+		name = filepath.Join(dir, name)
+		if err := ioutil.WriteFile(name, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return name
+	}
+	changed := []byte(testSrc)
+	changed[20] ^= 1
+	a := write("a", jase93.Encode(nil, []byte(testSrc)))
+	wrapped := write("wrapped", jase93.StdEncoding.WithLineLength(10).Encode(nil, []byte(testSrc)))
+	b := write("b", jase93.Encode(nil, changed))
+	short := write("short", jase93.Encode(nil, []byte(testSrc[:50])))
+
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{a, wrapped}, ""},
+		{[]string{a, b}, a + " " + b + " differ: decoded offset 20, encoded offsets 24-25 and 24-25\n"},
+		{[]string{wrapped, b}, wrapped + " " + b + " differ: decoded offset 20, encoded offsets 28-29 and 24-25\n"},
+		{[]string{short, a}, "EOF on " + short + " after decoded offset 50\n"},
+	} {
+		var out bytes.Buffer
+		err := run(append([]string{"diff"}, tc.args...), nil, &out)
+		if out.String() != tc.want || (err == errDiffer) != (tc.want != "") {
+			t.Errorf("diff %q = %q, %v, want %q", tc.args, out.String(), err, tc.want)
+		}
+	}
+
+	var out bytes.Buffer
+	err = run([]string{"diff", a, "-"}, strings.NewReader("ab\""), &out)
+	if err == nil || !strings.Contains(err.Error(), "-: jase93: invalid character 0x22 (double quote) at offset 2") {
+		t.Errorf("diff(invalid) = %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+// runGuess detects the format of the input, decodes it, and prints the format and the decoded data.
+func runGuess(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("jase93 guess", flag.ContinueOnError)
+	out := flags.String("o", "", "write the decoded data to `FILE` instead of printing it")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 {
+		return fmt.Errorf("too many arguments")
+	}
+
+	in := stdin
+	if name := flags.Arg(0); name != "" && name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	data, err := ioutil.ReadAll(in)
+	if err != nil {
+		return err
+	}
+
+	decoded, format, err := jase93.AutoDecode(data)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "format: %s\n", format)
+	if *out != "" {
+		if err := ioutil.WriteFile(*out, decoded, 0644); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintf(stdout, "wrote %d bytes to %s\n", len(decoded), *out)
+		return err
+	}
+
+	fmt.Fprintf(stdout, "decoded: %d bytes\n", len(decoded))
+	if isText(decoded) {
+		_, err = stdout.Write(decoded)
+		if err == nil && len(decoded) > 0 && decoded[len(decoded)-1] != '\n' {
+			_, err = io.WriteString(stdout, "\n")
+		}
+		return err
+	}
+	_, err = io.WriteString(stdout, hex.Dump(decoded))
+	return err
+}
+
+// isText reports whether data is valid UTF-8 without control characters other than tabs and line breaks.
+func isText(data []byte) bool {
+	if !utf8.Valid(data) {
+		return false
+	}
+	for _, r := range string(data) {
+		if unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r' {
+			return false
+		}
+	}
+	return true
+}
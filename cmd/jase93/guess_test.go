@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunGuess(t *testing.T) {
+	var out bytes.Buffer
+	in := base64.StdEncoding.EncodeToString([]byte("hello, world"))
+	if err := run([]string{"guess"}, strings.NewReader(in), &out); err != nil {
+		t.Fatal(err)
+	}
+	if want := "format: base64\ndecoded: 12 bytes\nhello, world\n"; out.String() != want {
+		t.Errorf("guess = %q, want %q", out.String(), want)
+	}
+
+	out.Reset()
+	if err := run([]string{"guess"}, strings.NewReader("0001feff0a0b0c0d"), &out); err != nil {
+		t.Fatal(err)
+	}
+	if want := "format: hex\ndecoded: 8 bytes\n" + hex.Dump([]byte{0, 1, 0xfe, 0xff, 10, 11, 12, 13}); out.String() != want {
+		t.Errorf("guess = %q, want %q", out.String(), want)
+	}
+
+	name := filepath.Join(t.TempDir(), "out")
+	out.Reset()
+	if err := run([]string{"guess", "-o", name}, strings.NewReader(in), &out); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := ioutil.ReadFile(name); err != nil || string(data) != "hello, world" {
+		t.Errorf("guess -o wrote %q, %v", data, err)
+	}
+}
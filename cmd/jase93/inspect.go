@@ -0,0 +1,256 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+// inspectChar is an encoded character and its offset in the input.
+type inspectChar struct {
+	offset int64
+	c      byte
+}
+
+// runInspect prints an annotated listing of the words of the input.
+func runInspect(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("jase93 inspect", flag.ContinueOnError)
+	header := flags.Bool("header", false, "treat the first two characters as a stream header")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 {
+		return fmt.Errorf("too many arguments")
+	}
+
+	in := stdin
+	if name := flags.Arg(0); name != "" && name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	data, err := ioutil.ReadAll(in)
+	if err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	chars, enc := inspectFraming(&b, data)
+	packing := jase93.AdaptivePacking
+	if enc == jase93.StdEncoding && len(chars) >= jase93.HeaderLen && chars[0].c == 'a' && (chars[1].c == 'a' || chars[1].c == 'b') {
+		if *header {
+			if chars[1].c == 'b' {
+				packing = jase93.SimplePacking
+			}
+			fmt.Fprintf(&b, "header:   %q at %08x, StdEncoding with %s packing\n", []byte{chars[0].c, chars[1].c}, chars[0].offset, packingName(packing))
+			chars = chars[jase93.HeaderLen:]
+		} else {
+			fmt.Fprintf(&b, "header:   none; %q may be a stream header, which -header shows\n", []byte{chars[0].c, chars[1].c})
+		}
+	} else if *header {
+		return fmt.Errorf("no StdEncoding stream header")
+	}
+
+	encoded := make([]byte, len(chars))
+	for i, c := range chars {
+		encoded[i] = c.c
+	}
+	enc = enc.WithPacking(packing)
+	decoded, err := enc.Decode(nil, encoded)
+	dataLen := len(decoded)
+	if err == nil {
+		if dec, err := enc.WithLengthTrailer().Decode(nil, encoded); err == nil {
+			dataLen = len(dec)
+			fmt.Fprintf(&b, "trailer:  length trailer from data offset %d\n", dataLen)
+		} else {
+			fmt.Fprintf(&b, "trailer:  none detected\n")
+		}
+	}
+	span := func(bit, n int) string {
+		if err != nil {
+			return inspectBits(bit, n, -1, -1)
+		}
+		return inspectBits(bit, n, dataLen, len(decoded))
+	}
+
+	fmt.Fprintf(&b, "\n  offset  chars   word  bits  data\n")
+	bit, words, extra := 0, 0, 0
+	for i := 0; i < len(chars); i += 2 {
+		c := chars[i]
+		if i+1 == len(chars) {
+			// A final character carries a byte
+			zero, _ := enc.EncodeWord(0)
+			word, ok := enc.DecodeWord(c.c, zero)
+			if !ok {
+				fmt.Fprintf(&b, "%08x  invalid %#02x\n", c.offset, c.c)
+				break
+			}
+			fmt.Fprintf(&b, "%08x  %-6q %5d  %-4d  %s\n", c.offset, []byte{c.c}, word, 8, span(bit, 8))
+			bit += 8
+			break
+		}
+
+		word, ok := enc.DecodeWord(c.c, chars[i+1].c)
+		if !ok {
+			bad := c
+			if _, ok := enc.DecodeWord(c.c, c.c); ok {
+				bad = chars[i+1]
+			}
+			fmt.Fprintf(&b, "%08x  invalid %#02x\n", bad.offset, bad.c)
+			break
+		}
+		n, bits := jase93.WordBits, fmt.Sprint(jase93.WordBits)
+		if enc.WordBitLen(word) > jase93.WordBits {
+			n, bits = jase93.WordBits+1, bits+"+1"
+			extra++
+		}
+		fmt.Fprintf(&b, "%08x  %-6q %5d  %-4s  %s\n", c.offset, []byte{c.c, chars[i+1].c}, word, bits, span(bit, n))
+		bit += n
+		words++
+	}
+
+	fmt.Fprintf(&b, "\nwords:    %d, %d with an extra bit\n", words, extra)
+	if err != nil {
+		fmt.Fprintf(&b, "error:    %v\n", err)
+	} else {
+		fmt.Fprintf(&b, "data:     %d bytes, %d padding bits\n", dataLen, bit-8*len(decoded))
+	}
+	_, werr := stdout.Write(b.Bytes())
+	if werr == nil && err != nil {
+		return err
+	}
+	return werr
+}
+
+// inspectFraming describes the framing of data to w and returns its encoded characters and their Encoding: the body of
+// the first armored block, encoded with SpaceFreeEncoding, if there is one, and otherwise all of data other than line
+// breaks, encoded with StdEncoding.
+func inspectFraming(w io.Writer, data []byte) ([]inspectChar, *jase93.Encoding) {
+	type line struct {
+		offset int64
+		text   string
+	}
+	var lines []line
+	crlf, maxLen := 0, 0
+	for offset := 0; offset < len(data); {
+		end := bytes.IndexByte(data[offset:], '\n')
+		next := offset + end + 1
+		if end < 0 {
+			end, next = len(data)-offset, len(data)
+		}
+		text := string(data[offset : offset+end])
+		if strings.HasSuffix(text, "\r") {
+			text = text[:len(text)-1]
+			crlf++
+		}
+		if len(text) > maxLen {
+			maxLen = len(text)
+		}
+		lines = append(lines, line{int64(offset), text})
+		offset = next
+	}
+
+	var chars []inspectChar
+	begin := -1
+	for i, l := range lines {
+		if strings.HasPrefix(l.text, "-----BEGIN ") && strings.HasSuffix(l.text, "-----") {
+			begin = i
+			break
+		}
+	}
+	if begin < 0 {
+		for _, l := range lines {
+			for i := 0; i < len(l.text); i++ {
+				chars = append(chars, inspectChar{l.offset + int64(i), l.text[i]})
+			}
+		}
+		endings := "LF"
+		if crlf > 0 {
+			endings = "CRLF"
+		}
+		switch {
+		case len(lines) > 1:
+			fmt.Fprintf(w, "framing:  %d lines of up to %d characters, %s line breaks\n", len(lines), maxLen, endings)
+		default:
+			fmt.Fprintf(w, "framing:  none\n")
+		}
+		return chars, jase93.StdEncoding
+	}
+
+	blockType := strings.TrimSuffix(strings.TrimPrefix(lines[begin].text, "-----BEGIN "), "-----")
+	end := "-----END " + blockType + "-----"
+	i, sums := begin+1, false
+	for ; i < len(lines) && lines[i].text != ""; i++ {
+		if lines[i].text == "Line-Checksum: CRC-8" {
+			sums = true
+		}
+	}
+	body := i + 1
+	if body > len(lines) {
+		body = len(lines)
+	}
+	for i = body; i < len(lines) && lines[i].text != end; i++ {
+	}
+
+	// The line before the END line holds the checksum of the block
+	desc := fmt.Sprintf("armored block %q at line %d", blockType, begin+1)
+	last := i
+	if i < len(lines) && i > body && strings.HasPrefix(lines[i-1].text, "=") {
+		desc += fmt.Sprintf(", CRC-24 checksum at line %d", i)
+		last = i - 1
+	} else {
+		desc += ", no checksum"
+	}
+	if i == len(lines) {
+		desc += ", no END line"
+	}
+	if sums {
+		desc += ", line checksums"
+	}
+	fmt.Fprintf(w, "framing:  %s\n", desc)
+
+	for _, l := range lines[body:last] {
+		text := l.text
+		if sums && len(text) >= 2 {
+			text = text[:len(text)-2]
+		}
+		for i := 0; i < len(text); i++ {
+			chars = append(chars, inspectChar{l.offset + int64(i), text[i]})
+		}
+	}
+	return chars, jase93.SpaceFreeEncoding
+}
+
+// inspectBits describes the n bits from bit offset bit of the decoded bytes, as a byte and bit offset, noting whether
+// they belong to a trailer, from dataLen bytes to total bytes, or to the padding that follows. Negative lengths are
+// unknown.
+func inspectBits(bit, n, dataLen, total int) string {
+	s := fmt.Sprintf("%d.%d", bit/8, bit%8)
+	if total < 0 {
+		return s
+	}
+	if end := bit + n; end > 8*dataLen && bit < 8*total && dataLen < total {
+		s += " trailer"
+	}
+	if bit+n > 8*total {
+		s += " padding"
+	}
+	return s
+}
+
+// packingName returns the name of packing.
+func packingName(packing jase93.Packing) string {
+	if packing == jase93.SimplePacking {
+		return "simple"
+	}
+	return "adaptive"
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+func TestRunInspect(t *testing.T) {
+	src := []byte("Man is distinguished")
+	for _, tc := range []struct {
+		name    string
+		args    []string
+		encoded []byte
+		want    []string
+	}{
+		{
+			"plain", nil, jase93.Encode(nil, src),
+			[]string{
+				"framing:  none\n",
+				"trailer:  none detected\n",
+				"00000000  \"`}\"    8525  13+1  0.0\n",
+				"00000004  \"-`\"    5778  13    3.4\n",
+				"00000018  \"!\"        1  8     19.6 padding\n",
+				"words:    12, 2 with an extra bit\n",
+				"data:     20 bytes, 6 padding bits\n",
+			},
+		},
+		{
+			"header and trailer", []string{"-header"}, jase93.StdEncoding.WithLengthTrailer().WithHeader().Encode(nil, src),
+			[]string{
+				"header:   \"aa\" at 00000000, StdEncoding with adaptive packing\n",
+				"trailer:  length trailer from data offset 20\n",
+				"00000002  \"`}\"    8525  13+1  0.0\n",
+				"19.6 trailer padding\n",
+			},
+		},
+		{
+			"wrapped", nil, jase93.StdEncoding.WithLineLength(10).Encode(nil, src),
+			[]string{
+				"framing:  3 lines of up to 10 characters, CRLF line breaks\n",
+				"0000000e  \"#;\"    2420  13    10.0\n",
+			},
+		},
+		{
+			"invalid", nil, append(jase93.Encode(nil, src)[:6], '"', 'x'),
+			[]string{
+				"00000006  invalid 0x22\n",
+				"error:    jase93: invalid character 0x22 (double quote) at offset 6\n",
+			},
+		},
+	} {
+		var out bytes.Buffer
+		err := run(append([]string{"inspect"}, tc.args...), bytes.NewReader(tc.encoded), &out)
+		if (err != nil) != (tc.name == "invalid") {
+			t.Errorf("%s: inspect = %v", tc.name, err)
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(out.String(), want) {
+				t.Errorf("%s: output %q does not contain %q", tc.name, out.String(), want)
+			}
+		}
+	}
+}
+
+func TestRunInspectArmor(t *testing.T) {
+	var armored bytes.Buffer
+	w, _ := jase93.ArmorWithLineChecksums(&armored, "DATA", map[string]string{"Comment": "test"})
+	w.Write([]byte(testSrc))
+	w.Close()
+
+	var out bytes.Buffer
+	if err := run([]string{"inspect"}, &armored, &out); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"framing:  armored block \"DATA\" at line 1, CRC-24 checksum at line 7, line checksums\n",
+		fmt.Sprintf("data:     %d bytes", len(testSrc)),
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output %q does not contain %q", out.String(), want)
+		}
+	}
+}
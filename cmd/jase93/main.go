@@ -0,0 +1,180 @@
+// Command jase93 encodes or decodes jase93 data.
+//
+// Usage:
+//
+//	jase93 [-e | -d [-f]] [FILE]
+//	jase93 -e FILE...
+//	jase93 -d -split-dir DIR [FILE]
+//	jase93 verify [-trailer] [FILE]
+//	jase93 stats [-base64] [FILE]
+//	jase93 normalize [-wrap N] [FILE]
+//	jase93 inspect [-header] [FILE]
+//	jase93 diff FILE1 FILE2
+//	jase93 selftest [-n N] [-seed SEED]
+//	jase93 guess [-o OUT] [FILE]
+//
+// With no FILE, or when FILE is -, jase93 reads standard input. Output is written to standard output.
+// When standard output is a terminal, encoded output is wrapped into lines of 76 characters, and decoded output that is
+// not text is refused, to avoid filling the terminal with garbage, unless -f is given. Line breaks in input to -d are
+// skipped.
+// Regular files are memory-mapped where supported and processed in a single pass.
+//
+// Given several files, -e encodes each as a record terminated by a newline, bundling them into one text string.
+// -d -split-dir reverses this, decoding each record of the input into a file in DIR named by its index: 0, 1, and so
+// on.
+//
+// The verify command checks that FILE decodes successfully without writing the decoded data, exiting with a non-zero
+// status and the offset of the first invalid character if it does not. Armored blocks are detected automatically and
+// their checksums verified; -trailer verifies a length trailer.
+//
+// The stats command encodes FILE without writing the encoded data and reports the raw and encoded sizes, the expansion
+// ratio, and the throughput; -base64 also reports the size of the base64 encoding for comparison.
+//
+// The normalize command re-encodes encoded FILE in canonical form, so equal data always has identical encodings. Line
+// breaks and Unicode lookalikes of alphabet characters are removed, and non-canonical final characters are corrected.
+// With -wrap, the output is wrapped into lines of N characters.
+//
+// The inspect command prints an annotated listing of encoded FILE for debugging interoperability with other
+// implementations: the framing detected, such as line wrapping or an armored block, and any length trailer, then each
+// word with its offset, characters, value, the number of bits it carries, including any extra bit, and the byte and bit
+// offset of the decoded data they start at. Words carrying trailer or padding bits are marked. With -header, the first
+// two characters are treated as a stream header.
+//
+// The diff command decodes encoded FILE1 and FILE2, either of which may be armored, and reports the offset of the first
+// byte at which their decoded data differs, and the offsets of the characters encoding it in each, exiting with status
+// 1 if they differ, as cmp does.
+//
+// The selftest command checks the encoding of the test vectors, and N random round trips, 1000 by default, through each
+// registered Codec, so a deployed binary, especially a cross-compiled one, can be validated on its host before it is
+// trusted with data. It reports each failure and exits with a non-zero status if any fail.
+//
+// The guess command detects whether FILE is hex, base64, base64url, jase93 in any registered alphabet, or plain text,
+// for triaging data of unknown origin. It prints the format it chose and the decoded data: as text if it is text, or
+// as a hex dump. With -o, the decoded data is written to OUT instead.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err == errDiffer {
+		os.Exit(1)
+	} else if err != nil {
+		fmt.Fprintln(os.Stderr, "jase93:", err)
+		os.Exit(1)
+	}
+}
+
+// commands are the subcommands, which take the arguments following their names.
+var commands = map[string]func(args []string, stdin io.Reader, stdout io.Writer) error{
+	"diff":      runDiff,
+	"guess":     runGuess,
+	"inspect":   runInspect,
+	"normalize": runNormalize,
+	"selftest":  runSelftest,
+	"stats":     runStats,
+	"verify":    runVerify,
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) > 0 {
+		if cmd, ok := commands[args[0]]; ok {
+			return cmd(args[1:], stdin, stdout)
+		}
+	}
+
+	flags := flag.NewFlagSet("jase93", flag.ContinueOnError)
+	flags.Bool("e", true, "encode data (the default)")
+	decode := flags.Bool("d", false, "decode data")
+	splitDir := flags.String("split-dir", "", "decode newline-terminated records into files in `DIR`")
+	force := flags.Bool("f", false, "write decoded binary data even to a terminal")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *splitDir != "" && !*decode {
+		return fmt.Errorf("-split-dir requires -d")
+	}
+
+	// Skip line breaks, so wrapped output decodes
+	enc := jase93.StdEncoding
+	if *decode {
+		enc = enc.IgnoreLineBreaks()
+	}
+	if isTerminal(stdout) {
+		if *decode && !*force {
+			stdout = &binaryGuard{w: stdout}
+		} else if !*decode {
+			enc = enc.WithLineLength(jase93.MIMELineLen)
+		}
+	}
+	if flags.NArg() > 1 {
+		if *decode {
+			return fmt.Errorf("too many arguments")
+		}
+		return encodeRecords(stdout, flags.Args())
+	}
+
+	in := stdin
+	if name := flags.Arg(0); name != "" && name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		if *splitDir != "" {
+			return decodeRecords(*splitDir, f)
+		}
+		if data, unmap, ok := mapFile(f); ok {
+			defer unmap()
+			return process(stdout, enc, data, *decode)
+		}
+		in = f
+	}
+	if *splitDir != "" {
+		return decodeRecords(*splitDir, in)
+	}
+
+	w := bufio.NewWriter(stdout)
+	if err := stream(w, enc, in, *decode); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// process encodes or decodes data with enc in a single pass.
+func process(w io.Writer, enc *jase93.Encoding, data []byte, decode bool) error {
+	var out []byte
+	if decode {
+		var err error
+		if out, err = enc.Decode(make([]byte, 0, len(data)), data); err != nil {
+			return err
+		}
+	} else {
+		out = enc.Encode(make([]byte, 0, enc.MaxEncodedLen(len(data))), data)
+	}
+
+	_, err := w.Write(out)
+	return err
+}
+
+// stream encodes or decodes r to w with enc.
+func stream(w io.Writer, enc *jase93.Encoding, r io.Reader, decode bool) error {
+	if decode {
+		_, err := io.Copy(w, enc.NewDecoder(r))
+		return err
+	}
+
+	e := enc.NewEncoder(w)
+	if _, err := io.Copy(e, r); err != nil {
+		return err
+	}
+	return e.Close()
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSrc = `"Man is distinguished, not only by his reason, but by this singular passion from other animals."`
+
+func TestRunStream(t *testing.T) {
+	var enc bytes.Buffer
+	if err := run(nil, strings.NewReader(testSrc), &enc); err != nil {
+		t.Fatal(err)
+	}
+
+	var dec bytes.Buffer
+	if err := run([]string{"-d"}, &enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if dec.String() != testSrc {
+		t.Errorf("decoded %q", dec.String())
+	}
+}
+
+func TestRunFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jase93")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	raw := filepath.Join(dir, "raw")
+	if err := ioutil.WriteFile(raw, []byte(testSrc), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var enc bytes.Buffer
+	if err := run([]string{raw}, nil, &enc); err != nil {
+		t.Fatal(err)
+	}
+
+	encoded := filepath.Join(dir, "encoded")
+	if err := ioutil.WriteFile(encoded, enc.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var dec bytes.Buffer
+	if err := run([]string{"-d", encoded}, nil, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if dec.String() != testSrc {
+		t.Errorf("decoded %q", dec.String())
+	}
+
+	if err := run([]string{"-d", raw}, nil, &dec); err == nil {
+		t.Error("decoding raw data succeeded")
+	}
+}
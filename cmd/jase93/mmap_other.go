@@ -0,0 +1,10 @@
+//go:build !unix
+
+package main
+
+import "os"
+
+// mapFile reports that memory-mapping is unsupported.
+func mapFile(f *os.File) (data []byte, unmap func() error, ok bool) {
+	return nil, nil, false
+}
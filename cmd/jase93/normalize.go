@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+// runNormalize re-encodes the input in canonical form.
+func runNormalize(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("jase93 normalize", flag.ContinueOnError)
+	wrap := flags.Int("wrap", 0, "wrap output into lines of `N` characters")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 {
+		return fmt.Errorf("too many arguments")
+	}
+
+	in := stdin
+	if name := flags.Arg(0); name != "" && name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	dec := jase93.StdEncoding.Lenient().IgnoreLineBreaks().NewDecoder(in)
+
+	w := bufio.NewWriter(stdout)
+	enc := jase93.StdEncoding.WithLineLength(*wrap).NewEncoder(w)
+	if _, err := io.Copy(enc, dec); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+func TestRunNormalize(t *testing.T) {
+	canonical := string(jase93.Encode(nil, []byte(testSrc)))
+
+	for _, in := range []string{
+		canonical,
+		canonical + "\n",
+		canonical[:20] + "\r\n" + canonical[20:40] + "\n" + canonical[40:],
+		strings.Replace(canonical, "-", "–", -1),
+	} {
+		var out bytes.Buffer
+		if err := run([]string{"normalize"}, strings.NewReader(in), &out); err != nil || out.String() != canonical {
+			t.Errorf("normalize(%q) = %q, %v", in, out.String(), err)
+		}
+	}
+
+	// A final byte less than 93 is encoded as one character, but a full word with a high digit of zero decodes the same
+	canonical = string(jase93.Encode(nil, []byte{5}))
+	var out bytes.Buffer
+	if err := run([]string{"normalize"}, strings.NewReader(canonical+" "), &out); err != nil || out.String() != canonical {
+		t.Errorf("normalize(non-canonical tail) = %q, %v, want %q", out.String(), err, canonical)
+	}
+}
+
+func TestRunNormalizeWrap(t *testing.T) {
+	canonical := string(jase93.Encode(nil, []byte(testSrc)))
+
+	var out bytes.Buffer
+	if err := run([]string{"normalize", "-wrap", "40"}, strings.NewReader(canonical), &out); err != nil {
+		t.Fatal(err)
+	}
+	if want := canonical[:40] + "\r\n" + canonical[40:80] + "\r\n" + canonical[80:] + "\r\n"; out.String() != want {
+		t.Errorf("normalize -wrap 40 = %q, want %q", out.String(), want)
+	}
+
+	if err := run([]string{"normalize"}, strings.NewReader(`a"b`), &out); err == nil {
+		t.Error("normalize(invalid) succeeded")
+	}
+}
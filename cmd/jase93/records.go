@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+// recordTerminator terminates each record of a bundle. It is not in the alphabet.
+const recordTerminator = '\n'
+
+// encodeRecords encodes each named file as a terminated record.
+func encodeRecords(stdout io.Writer, names []string) error {
+	w := bufio.NewWriter(stdout)
+	for _, name := range names {
+		if err := encodeRecord(w, name); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+func encodeRecord(w *bufio.Writer, name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := stream(w, jase93.StdEncoding, f, false); err != nil {
+		return err
+	}
+	return w.WriteByte(recordTerminator)
+}
+
+// decodeRecords decodes each terminated record of r into a file in dir.
+func decodeRecords(dir string, r io.Reader) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	br := bufio.NewReader(r)
+	for i := 0; ; i++ {
+		if _, err := br.Peek(1); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if err := decodeRecord(filepath.Join(dir, strconv.Itoa(i)), br); err != nil {
+			return fmt.Errorf("record %d: %v", i, err)
+		}
+	}
+}
+
+func decodeRecord(name string, br *bufio.Reader) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(f, jase93.NewDecoderUntil(br, recordTerminator, true))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
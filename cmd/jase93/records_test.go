@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+func TestRunRecords(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jase93")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	contents := []string{testSrc, "", "\x00\x01\x02"}
+	var names []string
+	for i, c := range contents {
+		name := filepath.Join(dir, string(rune('a'+i))+".bin")
+		if err := ioutil.WriteFile(name, []byte(c), 0644); err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, name)
+	}
+
+	var bundle bytes.Buffer
+	if err := run(append([]string{"-e"}, names...), nil, &bundle); err != nil {
+		t.Fatal(err)
+	}
+	want := string(jase93.Encode(nil, []byte(contents[0]))) + "\n\n" + string(jase93.Encode(nil, []byte(contents[2]))) + "\n"
+	if bundle.String() != want {
+		t.Errorf("bundle = %q, want %q", bundle.String(), want)
+	}
+
+	out := filepath.Join(dir, "out")
+	if err := run([]string{"-d", "-split-dir", out}, bytes.NewReader(bundle.Bytes()), nil); err != nil {
+		t.Fatal(err)
+	}
+	for i, c := range contents {
+		data, err := ioutil.ReadFile(filepath.Join(out, string(rune('0'+i))))
+		if err != nil || string(data) != c {
+			t.Errorf("record %d = %q, %v", i, data, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(out, "3")); !os.IsNotExist(err) {
+		t.Errorf("extra record: %v", err)
+	}
+}
+
+func TestRunRecordsErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jase93")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := run([]string{"-split-dir", dir}, strings.NewReader(""), nil); err == nil {
+		t.Error("-split-dir without -d succeeded")
+	}
+	if err := run([]string{"-d", "-split-dir", dir}, strings.NewReader("abc\nde"), nil); err == nil || !strings.Contains(err.Error(), "record 1") {
+		t.Errorf("unterminated record = %v", err)
+	}
+}
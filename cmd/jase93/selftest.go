@@ -0,0 +1,128 @@
+package main
+
+//go:generate go run ../jase93-vectors -go main -o vectors.go
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"testing/iotest"
+	"time"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+// errSelftest reports that the self-test failed, after the failures have been reported.
+var errSelftest = errors.New("self-test failed")
+
+// runSelftest checks the encoding against the test vectors and random round trips.
+func runSelftest(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("jase93 selftest", flag.ContinueOnError)
+	n := flags.Int("n", 1000, "number of random round trips")
+	seed := flags.Int64("seed", 0, "seed of the random round trips, or 0 for the current time")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("too many arguments")
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+
+	failed := 0
+	for _, v := range testVectors {
+		if err := checkVector(v.hex, v.encoded); err != nil {
+			fmt.Fprintf(stdout, "FAIL vector %q: %v\n", v.name, err)
+			failed++
+		}
+	}
+	fmt.Fprintf(stdout, "vectors: %d of %d passed\n", len(testVectors)-failed, len(testVectors))
+
+	roundTripFailed := 0
+	rng := rand.New(rand.NewSource(*seed))
+	names := jase93.Codecs()
+	for i := 0; i < *n; i++ {
+		name := names[rng.Intn(len(names))]
+		c, err := jase93.Lookup(name)
+		if err != nil {
+			return err
+		}
+
+		// Favor short inputs, where the final word varies, but exercise the bulk paths too
+		src := make([]byte, rng.Intn(64))
+		if rng.Intn(4) == 0 {
+			src = make([]byte, rng.Intn(1<<16))
+		}
+		rng.Read(src)
+		if err := checkRoundTrip(c, src, rng); err != nil {
+			fmt.Fprintf(stdout, "FAIL round trip %d with %s of %d bytes: %v\n", i, name, len(src), err)
+			roundTripFailed++
+		}
+	}
+	fmt.Fprintf(stdout, "round trips: %d of %d passed (seed %d)\n", *n-roundTripFailed, *n, *seed)
+
+	if failed+roundTripFailed > 0 {
+		return errSelftest
+	}
+	_, err := fmt.Fprintln(stdout, "OK")
+	return err
+}
+
+// checkVector checks that the hex-encoded data has the given encoding with StdEncoding, in one call and streamed a
+// byte at a time, and that it decodes back.
+func checkVector(hexData, encoded string) error {
+	raw, err := hex.DecodeString(hexData)
+	if err != nil {
+		return err
+	}
+
+	if got := jase93.Encode(nil, raw); string(got) != encoded {
+		return fmt.Errorf("Encode = %q, want %q", got, encoded)
+	}
+	var buf bytes.Buffer
+	e := jase93.NewEncoder(&buf)
+	for i := range raw {
+		e.Write(raw[i : i+1])
+	}
+	if err := e.Close(); err != nil || buf.String() != encoded {
+		return fmt.Errorf("Encoder = %q, %v, want %q", buf.Bytes(), err, encoded)
+	}
+
+	if got, err := jase93.Decode(nil, []byte(encoded)); err != nil || !bytes.Equal(got, raw) {
+		return fmt.Errorf("Decode = %x, %v, want %s", got, err, hexData)
+	}
+	if got, err := ioutil.ReadAll(jase93.NewDecoder(iotest.OneByteReader(bytes.NewReader([]byte(encoded))))); err != nil || !bytes.Equal(got, raw) {
+		return fmt.Errorf("Decoder = %x, %v, want %s", got, err, hexData)
+	}
+	return nil
+}
+
+// checkRoundTrip checks that src round-trips through c, both in one call and streamed in random chunks.
+func checkRoundTrip(c jase93.Codec, src []byte, rng *rand.Rand) error {
+	encoded := c.Encode(nil, src)
+	if got, err := c.Decode(nil, encoded); err != nil || !bytes.Equal(got, src) {
+		return fmt.Errorf("Decode(Encode) = %d bytes, %v", len(got), err)
+	}
+
+	var buf bytes.Buffer
+	w := c.NewWriter(&buf)
+	for rest := src; len(rest) > 0; {
+		k := 1 + rng.Intn(len(rest))
+		w.Write(rest[:k])
+		rest = rest[k:]
+	}
+	if err := w.Close(); err != nil || !bytes.Equal(buf.Bytes(), encoded) {
+		return fmt.Errorf("streamed encoding of %d bytes, %v, differs from Encode", buf.Len(), err)
+	}
+
+	if got, err := ioutil.ReadAll(c.NewReader(iotest.OneByteReader(&buf))); err != nil || !bytes.Equal(got, src) {
+		return fmt.Errorf("streamed decoding = %d bytes, %v", len(got), err)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRunSelftest(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"selftest", "-n", "50", "-seed", "1"}, nil, &out); err != nil {
+		t.Fatalf("selftest = %v\n%s", err, out.Bytes())
+	}
+	want := fmt.Sprintf("vectors: %d of %d passed\nround trips: 50 of 50 passed (seed 1)\nOK\n", len(testVectors), len(testVectors))
+	if out.String() != want {
+		t.Errorf("selftest output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestCheckVector(t *testing.T) {
+	if err := checkVector("ff", "g#"); err != nil {
+		t.Errorf("checkVector(ff) = %v", err)
+	}
+	if err := checkVector("ff", "h#"); err == nil || !strings.Contains(err.Error(), "Encode") {
+		t.Errorf("checkVector(ff, wrong encoding) = %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+// runStats encodes the input without writing the encoded data and reports its statistics.
+func runStats(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("jase93 stats", flag.ContinueOnError)
+	compare := flags.Bool("base64", false, "compare with base64")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 {
+		return fmt.Errorf("too many arguments")
+	}
+
+	in := stdin
+	if name := flags.Arg(0); name != "" && name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	enc := jase93.NewEncoder(ioutil.Discard)
+	if _, err := io.Copy(enc, in); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+
+	s := enc.Stats()
+	fmt.Fprintf(stdout, "raw bytes:     %d\n", s.RawBytes)
+	fmt.Fprintf(stdout, "encoded bytes: %d\n", s.EncodedBytes)
+	fmt.Fprintf(stdout, "ratio:         %.4f\n", s.Ratio())
+	fmt.Fprintf(stdout, "throughput:    %.1f MB/s\n", s.Throughput()/1e6)
+	if *compare {
+		n := int64(base64.StdEncoding.EncodedLen(int(s.RawBytes)))
+		fmt.Fprintf(stdout, "base64 bytes:  %d\n", n)
+		if n > 0 {
+			fmt.Fprintf(stdout, "savings:       %.1f%%\n", 100*float64(n-s.EncodedBytes)/float64(n))
+		}
+	}
+	return nil
+}
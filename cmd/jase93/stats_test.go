@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+func TestRunStats(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"stats", "-base64"}, strings.NewReader(testSrc), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	encoded := len(jase93.Encode(nil, []byte(testSrc)))
+	for _, want := range []string{
+		fmt.Sprintf("raw bytes:     %d\n", len(testSrc)),
+		fmt.Sprintf("encoded bytes: %d\n", encoded),
+		fmt.Sprintf("ratio:         %.4f\n", float64(encoded)/float64(len(testSrc))),
+		"throughput:",
+		"base64 bytes:  128\n",
+		"savings:",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("stats output %q does not contain %q", out.String(), want)
+		}
+	}
+
+	out.Reset()
+	if err := run([]string{"stats"}, strings.NewReader(""), &out); err != nil || strings.Contains(out.String(), "base64") {
+		t.Errorf("stats = %q, %v", out.String(), err)
+	}
+}
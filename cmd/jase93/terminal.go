@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+	"unicode"
+	"unicode/utf8"
+)
+
+// errBinaryTerminal reports that decoded output was withheld from a terminal.
+var errBinaryTerminal = errors.New("refusing to write binary data to a terminal; use -f to force")
+
+// isTerminal reports whether w is a terminal. It is a variable so tests can replace it.
+var isTerminal = func(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// binaryGuard writes text to w, failing with errBinaryTerminal at the first write that is not text: valid UTF-8
+// without control characters other than tabs and line breaks. Writes are checked before any of them is written.
+type binaryGuard struct {
+	w       io.Writer
+	partial []byte // an incomplete character at the end of the previous write
+}
+
+func (g *binaryGuard) Write(data []byte) (int, error) {
+	buf := append(g.partial, data...)
+	for len(buf) > 0 {
+		r, n := utf8.DecodeRune(buf)
+		if r == utf8.RuneError && n <= 1 {
+			if !utf8.FullRune(buf) {
+				break
+			}
+			return 0, errBinaryTerminal
+		}
+		if unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r' {
+			return 0, errBinaryTerminal
+		}
+		buf = buf[n:]
+	}
+	g.partial = append(g.partial[:0], buf...)
+	return g.w.Write(data)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+func TestRunTerminal(t *testing.T) {
+	defer func(f func(io.Writer) bool) { isTerminal = f }(isTerminal)
+	isTerminal = func(io.Writer) bool { return true }
+
+	// Encoded output is wrapped
+	src := strings.Repeat(testSrc, 3)
+	var out bytes.Buffer
+	if err := run(nil, strings.NewReader(src), &out); err != nil {
+		t.Fatal(err)
+	}
+	if want := jase93.StdEncoding.WithLineLength(jase93.MIMELineLen).Encode(nil, []byte(src)); !bytes.Equal(out.Bytes(), want) {
+		t.Errorf("encoded %q, want %q", out.Bytes(), want)
+	}
+
+	// Decoded text is written, but not binary data unless forced
+	binary := jase93.Encode(nil, []byte("caf\xc3\xa9\x00\x1b[2J"))
+	for _, tc := range []struct {
+		args    []string
+		encoded []byte
+		err     error
+	}{
+		{[]string{"-d"}, out.Bytes(), nil},
+		{[]string{"-d"}, jase93.Encode(nil, []byte("caf\xc3\xa9\t\n")), nil},
+		{[]string{"-d"}, binary, errBinaryTerminal},
+		{[]string{"-d"}, jase93.Encode(nil, []byte("\xff")), errBinaryTerminal},
+		{[]string{"-d", "-f"}, binary, nil},
+	} {
+		var dec bytes.Buffer
+		if err := run(tc.args, bytes.NewReader(tc.encoded), &dec); err != tc.err {
+			t.Errorf("%q: %v, want %v", tc.encoded, err, tc.err)
+		}
+		if tc.err != nil && dec.Len() > 0 {
+			t.Errorf("%q: wrote %q", tc.encoded, dec.Bytes())
+		}
+	}
+}
+
+func TestBinaryGuard(t *testing.T) {
+	var out bytes.Buffer
+	g := &binaryGuard{w: &out}
+
+	// Characters may be split between writes
+	for _, s := range []string{"caf\xc3", "\xa9 \xe2\x82", "\xac"} {
+		if _, err := g.Write([]byte(s)); err != nil {
+			t.Fatalf("Write(%q) = %v", s, err)
+		}
+	}
+	if out.String() != "café €" {
+		t.Errorf("wrote %q", out.String())
+	}
+	if _, err := g.Write([]byte("\xc3\x28")); err != errBinaryTerminal {
+		t.Errorf("Write(invalid) = %v", err)
+	}
+}
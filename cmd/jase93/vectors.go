@@ -0,0 +1,314 @@
+// Code generated by jase93-vectors; DO NOT EDIT.
+
+package main
+
+// testVectors are the test vectors of testdata/vectors.json.
+var testVectors = []struct{ name, hex, encoded string }{
+	{"empty", "", ""},
+	{"one zero byte", "00", " "},
+	{"two zero bytes", "0000", "   "},
+	{"one 0xff byte", "ff", "g#"},
+	{"two 0xff bytes", "ffff", "(z("},
+	{"extra bit taken", "0020", ")z "},
+	{"largest extra-bit word", "c821", "~~ "},
+	{"smallest 13-bit word", "c901", "w% "},
+	{"random tail 3", "dc2e17", "vI~!"},
+	{"random tail 4", "a6fb5938", "+nf(/"},
+	{"random tail 5", "773c8cd940", "Bp(C[M "},
+	{"random tail 6", "10f44abf772e", "6XPjFt~ "},
+	{"random tail 7", "61bda5d11870a4", "sr:E(2I9+"},
+	{"random tail 8", "86265adf48ae6da2", "{2nk.S>my<"},
+	{"basE91 sample", "4d616e2069732064697374696e677569736865642c206e6f74206f6e6c792062792068697320726561736f6e2c2062757420627920746869732073696e67756c61722070617373696f6e2066726f6d206f7468657220616e696d616c732c2077686963682069732061206c757374206f6620746865206d696e642c20746861742062792061207065727365766572616e6365206f662064656c6967687420696e2074686520636f6e74696e75656420616e6420696e6465666174696761626c652067656e65726174696f6e206f66206b6e6f776c656467652c2065786365656473207468652073686f727420766568656d656e6365206f6620616e79206361726e616c20706c6561737572652e", "`}g%-`_M>0dH#;Umkrj3!`)Sv!~`0jLp~F}goORufM1`_M{]PBRKO*.7]>$5|O5&{Hu:x*6q@qo_2_4;0,%~~F;EfHoOep{kZR0jS]BH2}h0^F(Cx*.7YO1`WXF-dH2}INFI<YqfNhd7!`WMc0~F+Cq|yD*YoORu3'V&RTzFvC$0r@{m_>ZRuUINtCy:u)+r7S~3giS].,BKO*Vju>K2WM7hTC,:O*jgc('b7W4;<F1{N]}F]SD)VjLqZRuUHC~FPbA@)gg>](*T4GtC*-x*wqi>ZR`Xm;'Iv^e)kgs>$aKTLp7D2}h01,v}}hQB8{rQuCAV.Wmg~ "},
+	{"byte 0x00", "00", " "},
+	{"byte 0x01", "01", "!"},
+	{"byte 0x02", "02", "#"},
+	{"byte 0x03", "03", "$"},
+	{"byte 0x04", "04", "%"},
+	{"byte 0x05", "05", "&"},
+	{"byte 0x06", "06", "'"},
+	{"byte 0x07", "07", "("},
+	{"byte 0x08", "08", ")"},
+	{"byte 0x09", "09", "*"},
+	{"byte 0x0a", "0a", "+"},
+	{"byte 0x0b", "0b", ","},
+	{"byte 0x0c", "0c", "-"},
+	{"byte 0x0d", "0d", "."},
+	{"byte 0x0e", "0e", "/"},
+	{"byte 0x0f", "0f", "0"},
+	{"byte 0x10", "10", "1"},
+	{"byte 0x11", "11", "2"},
+	{"byte 0x12", "12", "3"},
+	{"byte 0x13", "13", "4"},
+	{"byte 0x14", "14", "5"},
+	{"byte 0x15", "15", "6"},
+	{"byte 0x16", "16", "7"},
+	{"byte 0x17", "17", "8"},
+	{"byte 0x18", "18", "9"},
+	{"byte 0x19", "19", ":"},
+	{"byte 0x1a", "1a", ";"},
+	{"byte 0x1b", "1b", "<"},
+	{"byte 0x1c", "1c", "="},
+	{"byte 0x1d", "1d", ">"},
+	{"byte 0x1e", "1e", "?"},
+	{"byte 0x1f", "1f", "@"},
+	{"byte 0x20", "20", "A"},
+	{"byte 0x21", "21", "B"},
+	{"byte 0x22", "22", "C"},
+	{"byte 0x23", "23", "D"},
+	{"byte 0x24", "24", "E"},
+	{"byte 0x25", "25", "F"},
+	{"byte 0x26", "26", "G"},
+	{"byte 0x27", "27", "H"},
+	{"byte 0x28", "28", "I"},
+	{"byte 0x29", "29", "J"},
+	{"byte 0x2a", "2a", "K"},
+	{"byte 0x2b", "2b", "L"},
+	{"byte 0x2c", "2c", "M"},
+	{"byte 0x2d", "2d", "N"},
+	{"byte 0x2e", "2e", "O"},
+	{"byte 0x2f", "2f", "P"},
+	{"byte 0x30", "30", "Q"},
+	{"byte 0x31", "31", "R"},
+	{"byte 0x32", "32", "S"},
+	{"byte 0x33", "33", "T"},
+	{"byte 0x34", "34", "U"},
+	{"byte 0x35", "35", "V"},
+	{"byte 0x36", "36", "W"},
+	{"byte 0x37", "37", "X"},
+	{"byte 0x38", "38", "Y"},
+	{"byte 0x39", "39", "Z"},
+	{"byte 0x3a", "3a", "["},
+	{"byte 0x3b", "3b", "]"},
+	{"byte 0x3c", "3c", "^"},
+	{"byte 0x3d", "3d", "_"},
+	{"byte 0x3e", "3e", "`"},
+	{"byte 0x3f", "3f", "a"},
+	{"byte 0x40", "40", "b"},
+	{"byte 0x41", "41", "c"},
+	{"byte 0x42", "42", "d"},
+	{"byte 0x43", "43", "e"},
+	{"byte 0x44", "44", "f"},
+	{"byte 0x45", "45", "g"},
+	{"byte 0x46", "46", "h"},
+	{"byte 0x47", "47", "i"},
+	{"byte 0x48", "48", "j"},
+	{"byte 0x49", "49", "k"},
+	{"byte 0x4a", "4a", "l"},
+	{"byte 0x4b", "4b", "m"},
+	{"byte 0x4c", "4c", "n"},
+	{"byte 0x4d", "4d", "o"},
+	{"byte 0x4e", "4e", "p"},
+	{"byte 0x4f", "4f", "q"},
+	{"byte 0x50", "50", "r"},
+	{"byte 0x51", "51", "s"},
+	{"byte 0x52", "52", "t"},
+	{"byte 0x53", "53", "u"},
+	{"byte 0x54", "54", "v"},
+	{"byte 0x55", "55", "w"},
+	{"byte 0x56", "56", "x"},
+	{"byte 0x57", "57", "y"},
+	{"byte 0x58", "58", "z"},
+	{"byte 0x59", "59", "{"},
+	{"byte 0x5a", "5a", "|"},
+	{"byte 0x5b", "5b", "}"},
+	{"byte 0x5c", "5c", "~"},
+	{"byte 0x5d", "5d", " !"},
+	{"byte 0x5e", "5e", "!!"},
+	{"byte 0x5f", "5f", "#!"},
+	{"byte 0x60", "60", "$!"},
+	{"byte 0x61", "61", "%!"},
+	{"byte 0x62", "62", "&!"},
+	{"byte 0x63", "63", "'!"},
+	{"byte 0x64", "64", "(!"},
+	{"byte 0x65", "65", ")!"},
+	{"byte 0x66", "66", "*!"},
+	{"byte 0x67", "67", "+!"},
+	{"byte 0x68", "68", ",!"},
+	{"byte 0x69", "69", "-!"},
+	{"byte 0x6a", "6a", ".!"},
+	{"byte 0x6b", "6b", "/!"},
+	{"byte 0x6c", "6c", "0!"},
+	{"byte 0x6d", "6d", "1!"},
+	{"byte 0x6e", "6e", "2!"},
+	{"byte 0x6f", "6f", "3!"},
+	{"byte 0x70", "70", "4!"},
+	{"byte 0x71", "71", "5!"},
+	{"byte 0x72", "72", "6!"},
+	{"byte 0x73", "73", "7!"},
+	{"byte 0x74", "74", "8!"},
+	{"byte 0x75", "75", "9!"},
+	{"byte 0x76", "76", ":!"},
+	{"byte 0x77", "77", ";!"},
+	{"byte 0x78", "78", "<!"},
+	{"byte 0x79", "79", "=!"},
+	{"byte 0x7a", "7a", ">!"},
+	{"byte 0x7b", "7b", "?!"},
+	{"byte 0x7c", "7c", "@!"},
+	{"byte 0x7d", "7d", "A!"},
+	{"byte 0x7e", "7e", "B!"},
+	{"byte 0x7f", "7f", "C!"},
+	{"byte 0x80", "80", "D!"},
+	{"byte 0x81", "81", "E!"},
+	{"byte 0x82", "82", "F!"},
+	{"byte 0x83", "83", "G!"},
+	{"byte 0x84", "84", "H!"},
+	{"byte 0x85", "85", "I!"},
+	{"byte 0x86", "86", "J!"},
+	{"byte 0x87", "87", "K!"},
+	{"byte 0x88", "88", "L!"},
+	{"byte 0x89", "89", "M!"},
+	{"byte 0x8a", "8a", "N!"},
+	{"byte 0x8b", "8b", "O!"},
+	{"byte 0x8c", "8c", "P!"},
+	{"byte 0x8d", "8d", "Q!"},
+	{"byte 0x8e", "8e", "R!"},
+	{"byte 0x8f", "8f", "S!"},
+	{"byte 0x90", "90", "T!"},
+	{"byte 0x91", "91", "U!"},
+	{"byte 0x92", "92", "V!"},
+	{"byte 0x93", "93", "W!"},
+	{"byte 0x94", "94", "X!"},
+	{"byte 0x95", "95", "Y!"},
+	{"byte 0x96", "96", "Z!"},
+	{"byte 0x97", "97", "[!"},
+	{"byte 0x98", "98", "]!"},
+	{"byte 0x99", "99", "^!"},
+	{"byte 0x9a", "9a", "_!"},
+	{"byte 0x9b", "9b", "`!"},
+	{"byte 0x9c", "9c", "a!"},
+	{"byte 0x9d", "9d", "b!"},
+	{"byte 0x9e", "9e", "c!"},
+	{"byte 0x9f", "9f", "d!"},
+	{"byte 0xa0", "a0", "e!"},
+	{"byte 0xa1", "a1", "f!"},
+	{"byte 0xa2", "a2", "g!"},
+	{"byte 0xa3", "a3", "h!"},
+	{"byte 0xa4", "a4", "i!"},
+	{"byte 0xa5", "a5", "j!"},
+	{"byte 0xa6", "a6", "k!"},
+	{"byte 0xa7", "a7", "l!"},
+	{"byte 0xa8", "a8", "m!"},
+	{"byte 0xa9", "a9", "n!"},
+	{"byte 0xaa", "aa", "o!"},
+	{"byte 0xab", "ab", "p!"},
+	{"byte 0xac", "ac", "q!"},
+	{"byte 0xad", "ad", "r!"},
+	{"byte 0xae", "ae", "s!"},
+	{"byte 0xaf", "af", "t!"},
+	{"byte 0xb0", "b0", "u!"},
+	{"byte 0xb1", "b1", "v!"},
+	{"byte 0xb2", "b2", "w!"},
+	{"byte 0xb3", "b3", "x!"},
+	{"byte 0xb4", "b4", "y!"},
+	{"byte 0xb5", "b5", "z!"},
+	{"byte 0xb6", "b6", "{!"},
+	{"byte 0xb7", "b7", "|!"},
+	{"byte 0xb8", "b8", "}!"},
+	{"byte 0xb9", "b9", "~!"},
+	{"byte 0xba", "ba", " #"},
+	{"byte 0xbb", "bb", "!#"},
+	{"byte 0xbc", "bc", "##"},
+	{"byte 0xbd", "bd", "$#"},
+	{"byte 0xbe", "be", "%#"},
+	{"byte 0xbf", "bf", "&#"},
+	{"byte 0xc0", "c0", "'#"},
+	{"byte 0xc1", "c1", "(#"},
+	{"byte 0xc2", "c2", ")#"},
+	{"byte 0xc3", "c3", "*#"},
+	{"byte 0xc4", "c4", "+#"},
+	{"byte 0xc5", "c5", ",#"},
+	{"byte 0xc6", "c6", "-#"},
+	{"byte 0xc7", "c7", ".#"},
+	{"byte 0xc8", "c8", "/#"},
+	{"byte 0xc9", "c9", "0#"},
+	{"byte 0xca", "ca", "1#"},
+	{"byte 0xcb", "cb", "2#"},
+	{"byte 0xcc", "cc", "3#"},
+	{"byte 0xcd", "cd", "4#"},
+	{"byte 0xce", "ce", "5#"},
+	{"byte 0xcf", "cf", "6#"},
+	{"byte 0xd0", "d0", "7#"},
+	{"byte 0xd1", "d1", "8#"},
+	{"byte 0xd2", "d2", "9#"},
+	{"byte 0xd3", "d3", ":#"},
+	{"byte 0xd4", "d4", ";#"},
+	{"byte 0xd5", "d5", "<#"},
+	{"byte 0xd6", "d6", "=#"},
+	{"byte 0xd7", "d7", ">#"},
+	{"byte 0xd8", "d8", "?#"},
+	{"byte 0xd9", "d9", "@#"},
+	{"byte 0xda", "da", "A#"},
+	{"byte 0xdb", "db", "B#"},
+	{"byte 0xdc", "dc", "C#"},
+	{"byte 0xdd", "dd", "D#"},
+	{"byte 0xde", "de", "E#"},
+	{"byte 0xdf", "df", "F#"},
+	{"byte 0xe0", "e0", "G#"},
+	{"byte 0xe1", "e1", "H#"},
+	{"byte 0xe2", "e2", "I#"},
+	{"byte 0xe3", "e3", "J#"},
+	{"byte 0xe4", "e4", "K#"},
+	{"byte 0xe5", "e5", "L#"},
+	{"byte 0xe6", "e6", "M#"},
+	{"byte 0xe7", "e7", "N#"},
+	{"byte 0xe8", "e8", "O#"},
+	{"byte 0xe9", "e9", "P#"},
+	{"byte 0xea", "ea", "Q#"},
+	{"byte 0xeb", "eb", "R#"},
+	{"byte 0xec", "ec", "S#"},
+	{"byte 0xed", "ed", "T#"},
+	{"byte 0xee", "ee", "U#"},
+	{"byte 0xef", "ef", "V#"},
+	{"byte 0xf0", "f0", "W#"},
+	{"byte 0xf1", "f1", "X#"},
+	{"byte 0xf2", "f2", "Y#"},
+	{"byte 0xf3", "f3", "Z#"},
+	{"byte 0xf4", "f4", "[#"},
+	{"byte 0xf5", "f5", "]#"},
+	{"byte 0xf6", "f6", "^#"},
+	{"byte 0xf7", "f7", "_#"},
+	{"byte 0xf8", "f8", "`#"},
+	{"byte 0xf9", "f9", "a#"},
+	{"byte 0xfa", "fa", "b#"},
+	{"byte 0xfb", "fb", "c#"},
+	{"byte 0xfc", "fc", "d#"},
+	{"byte 0xfd", "fd", "e#"},
+	{"byte 0xfe", "fe", "f#"},
+	{"byte 0xff", "ff", "g#"},
+	{"3 zero bytes", "000000", "    "},
+	{"3 0xff bytes", "ffffff", "(z!7"},
+	{"4 zero bytes", "00000000", "     "},
+	{"4 0xff bytes", "ffffffff", "(z(za"},
+	{"5 zero bytes", "0000000000", "      "},
+	{"5 0xff bytes", "ffffffffff", "(z(z(z!"},
+	{"6 zero bytes", "000000000000", "       "},
+	{"6 0xff bytes", "ffffffffffff", "(z(z(zO&"},
+	{"7 zero bytes", "00000000000000", "        "},
+	{"7 0xff bytes", "ffffffffffffff", "(z(z(z(z0"},
+	{"8 zero bytes", "0000000000000000", "         "},
+	{"8 0xff bytes", "ffffffffffffffff", "(z(z(z(z$M"},
+	{"9 zero bytes", "000000000000000000", "           "},
+	{"9 0xff bytes", "ffffffffffffffffff", "(z(z(z(z(zC!"},
+	{"10 zero bytes", "00000000000000000000", "            "},
+	{"10 0xff bytes", "ffffffffffffffffffff", "(z(z(z(z(z(z$"},
+	{"11 zero bytes", "0000000000000000000000", "             "},
+	{"11 0xff bytes", "ffffffffffffffffffffff", "(z(z(z(z(z(z ,"},
+	{"12 zero bytes", "000000000000000000000000", "              "},
+	{"12 0xff bytes", "ffffffffffffffffffffffff", "(z(z(z(z(z(z(z@"},
+	{"13 zero bytes", "00000000000000000000000000", "               "},
+	{"13 0xff bytes", "ffffffffffffffffffffffffff", "(z(z(z(z(z(z(z(z"},
+	{"14 zero bytes", "0000000000000000000000000000", "                "},
+	{"14 0xff bytes", "ffffffffffffffffffffffffffff", "(z(z(z(z(z(z(z(zg#"},
+	{"15 zero bytes", "000000000000000000000000000000", "                 "},
+	{"15 0xff bytes", "ffffffffffffffffffffffffffffff", "(z(z(z(z(z(z(z(z(z("},
+	{"16 zero bytes", "00000000000000000000000000000000", "                   "},
+	{"16 0xff bytes", "ffffffffffffffffffffffffffffffff", "(z(z(z(z(z(z(z(z(z!7"},
+	{"word 0x0000", "0000", "   "},
+	{"word 0x2000", "0020", ")z "},
+	{"word 0x01c8", "c801", "v% "},
+	{"word 0x21c8", "c821", "~~ "},
+	{"word 0x01c9", "c901", "w% "},
+	{"word 0x21c9", "c921", "w%!"},
+	{"word 0x1fff", "ff1f", "(z "},
+	{"word 0x3fff", "ff3f", "(z!"},
+}
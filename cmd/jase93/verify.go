@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+// runVerify validates the input without writing the decoded data.
+func runVerify(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("jase93 verify", flag.ContinueOnError)
+	trailer := flags.Bool("trailer", false, "verify a length trailer")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 {
+		return fmt.Errorf("too many arguments")
+	}
+
+	in, name := stdin, "-"
+	if flags.NArg() == 1 && flags.Arg(0) != "-" {
+		name = flags.Arg(0)
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	// Skip line breaks, as -d does, so wrapped output verifies
+	enc := jase93.StdEncoding.IgnoreLineBreaks()
+	if *trailer {
+		enc = enc.WithLengthTrailer()
+	}
+
+	br := bufio.NewReader(in)
+	var r io.Reader
+	if prefix, _ := br.Peek(len("-----BEGIN ")); bytes.Equal(prefix, []byte("-----BEGIN ")) {
+		block, err := jase93.Dearmor(br)
+		if err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+		r = block.Body
+	} else {
+		r = enc.NewValidatingReader(br)
+	}
+
+	if _, err := io.Copy(ioutil.Discard, r); err != nil {
+		return fmt.Errorf("%s: %v", name, err)
+	}
+	_, err := fmt.Fprintf(stdout, "%s: OK\n", name)
+	return err
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+func TestRunVerify(t *testing.T) {
+	encoded := string(jase93.Encode(nil, []byte(testSrc)))
+
+	var out bytes.Buffer
+	if err := run([]string{"verify"}, strings.NewReader(encoded), &out); err != nil || out.String() != "-: OK\n" {
+		t.Errorf("verify = %q, %v", out.String(), err)
+	}
+
+	// Wrapped output, and output ending with a line break, verify as they decode
+	var wrapped bytes.Buffer
+	if err := run([]string{"normalize", "-wrap", "76"}, strings.NewReader(encoded), &wrapped); err != nil {
+		t.Fatal(err)
+	}
+	for _, in := range []string{wrapped.String(), encoded + "\n"} {
+		out.Reset()
+		if err := run([]string{"verify"}, strings.NewReader(in), &out); err != nil {
+			t.Errorf("verify(%q) = %v", in, err)
+		}
+	}
+
+	err := run([]string{"verify"}, strings.NewReader(encoded[:10]+`"`+encoded[11:]), &out)
+	if err == nil || !strings.Contains(err.Error(), "offset 10") {
+		t.Errorf("verify(corrupt) = %v", err)
+	}
+}
+
+func TestRunVerifyFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jase93")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var armored bytes.Buffer
+	w, _ := jase93.Armor(&armored, "DATA", nil)
+	w.Write([]byte(testSrc))
+	w.Close()
+	name := filepath.Join(dir, "armored")
+	if err := ioutil.WriteFile(name, armored.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := run([]string{"verify", name}, nil, &out); err != nil || out.String() != name+": OK\n" {
+		t.Errorf("verify(armored) = %q, %v", out.String(), err)
+	}
+
+	corrupt := bytes.Replace(armored.Bytes(), []byte("\n="), []byte("\n=!"), 1)
+	if err := run([]string{"verify"}, bytes.NewReader(corrupt), &out); err == nil {
+		t.Error("verify(bad checksum) succeeded")
+	}
+}
+
+func TestRunVerifyTrailer(t *testing.T) {
+	enc := jase93.StdEncoding.WithLengthTrailer()
+	encoded := enc.Encode(nil, []byte(testSrc))
+
+	var out bytes.Buffer
+	if err := run([]string{"verify", "-trailer"}, bytes.NewReader(encoded), &out); err != nil {
+		t.Errorf("verify -trailer = %v", err)
+	}
+	if err := run([]string{"verify", "-trailer"}, bytes.NewReader(encoded[:len(encoded)-4]), &out); err == nil {
+		t.Error("verify -trailer(truncated) succeeded")
+	}
+}
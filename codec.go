@@ -0,0 +1,94 @@
+package jase93
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Codec is a binary-to-text encoding, such as an Encoding, so that applications can select one by name with Lookup.
+type Codec interface {
+	// Encode encodes src and appends it to dst.
+	Encode(dst, src []byte) []byte
+	// Decode decodes src and appends it to dst.
+	Decode(dst, src []byte) ([]byte, error)
+	Transcoder
+}
+
+// NewWriter returns an Encoder that encodes to w, as NewEncoder does, so that Encoding implements Codec.
+func (enc *Encoding) NewWriter(w io.Writer) io.WriteCloser {
+	return enc.NewEncoder(w)
+}
+
+// NewReader returns a Decoder that decodes from r, as NewDecoder does, so that Encoding implements Codec.
+func (enc *Encoding) NewReader(r io.Reader) io.Reader {
+	return enc.NewDecoder(r)
+}
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[string]Codec{
+		"jase93":             StdEncoding,
+		"jase93-human":       HumanEncoding,
+		"jase93-space-free":  SpaceFreeEncoding,
+		"jase93-http-header": HTTPHeaderEncoding,
+		"jase93-cookie":      CookieEncoding,
+		"jase93-url-path":    URLPathEncoding,
+		"jase93-url-query":   URLQueryEncoding,
+		"jase93-filename":    FilenameEncoding,
+		"base64":             NewBase64Codec(base64.StdEncoding),
+		"base64url":          NewBase64Codec(base64.URLEncoding),
+		"hex":                HexCodec,
+	}
+)
+
+// Register makes c available by name to Lookup, such as an Encoding configured by the application. The predefined
+// alphabet variants are registered as "jase93" for StdEncoding, and "jase93-human", "jase93-space-free",
+// "jase93-http-header", "jase93-cookie", "jase93-url-path", "jase93-url-query", and "jase93-filename" for the others,
+// and padded standard and URL-safe base64 and lowercase hex as "base64", "base64url", and "hex". Register panics if c
+// is nil or name is already registered.
+func Register(name string, c Codec) {
+	if c == nil {
+		panic("jase93: Register codec is nil")
+	}
+
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	if _, ok := codecs[name]; ok {
+		panic("jase93: Register called twice for codec " + name)
+	}
+	codecs[name] = c
+}
+
+// UnknownCodecError reports a name that Lookup did not find.
+type UnknownCodecError string
+
+func (e UnknownCodecError) Error() string {
+	return fmt.Sprintf("jase93: unknown codec %q", string(e))
+}
+
+// Lookup returns the Codec registered by name, or an UnknownCodecError, so that applications can select an encoding
+// with a configuration string.
+func Lookup(name string) (Codec, error) {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	c, ok := codecs[name]
+	if !ok {
+		return nil, UnknownCodecError(name)
+	}
+	return c, nil
+}
+
+// Codecs returns the sorted names of the registered Codecs.
+func Codecs() []string {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	names := make([]string, 0, len(codecs))
+	for name := range codecs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
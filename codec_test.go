@@ -0,0 +1,53 @@
+package jase93
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+	"math/rand"
+	"testing"
+)
+
+func TestCodecs(t *testing.T) {
+	if _, err := Lookup("test-base64"); err != nil {
+		Register("test-base64", NewBase64Codec(base64.StdEncoding))
+	}
+
+	src := make([]byte, 1000)
+	rand.New(rand.NewSource(0)).Read(src)
+	for _, name := range Codecs() {
+		c, err := Lookup(name)
+		if err != nil {
+			t.Fatalf("Lookup(%q) = %v", name, err)
+		}
+
+		encoded := c.Encode(nil, src)
+		if dec, err := c.Decode(nil, encoded); err != nil || !bytes.Equal(dec, src) {
+			t.Errorf("%s: Decode = %v", name, err)
+		}
+
+		var buf bytes.Buffer
+		w := c.NewWriter(&buf)
+		w.Write(src)
+		if err := w.Close(); err != nil || !bytes.Equal(buf.Bytes(), encoded) {
+			t.Errorf("%s: NewWriter wrote %d bytes, %v, want %d", name, buf.Len(), err, len(encoded))
+		}
+		if dec, err := ioutil.ReadAll(c.NewReader(&buf)); err != nil || !bytes.Equal(dec, src) {
+			t.Errorf("%s: NewReader = %v", name, err)
+		}
+	}
+
+	if c, _ := Lookup("jase93-url-path"); c != URLPathEncoding {
+		t.Errorf("Lookup(jase93-url-path) = %v", c)
+	}
+	if _, err := Lookup("jase93-nonesuch"); err != UnknownCodecError("jase93-nonesuch") {
+		t.Errorf("Lookup(unknown) = %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a name twice did not panic")
+		}
+	}()
+	Register("jase93", NewBase64Codec(base64.StdEncoding))
+}
@@ -0,0 +1,103 @@
+//go:build conformance
+
+package jase93
+
+// The conformance tests compare this package with another implementation of jase93, to catch drift from the
+// specification that the test vectors miss. They are built with the conformance tag, and run the command in the
+// JASE93_REFERENCE environment variable, split into fields, so a reference written in JavaScript can be run with:
+//
+//	JASE93_REFERENCE="node jase93-cli.js" go test -tags conformance -run Conformance
+//
+// The command is run with an argument of "encode" to encode standard input to standard output, or "decode" to decode
+// it, and must exit with a non-zero status if the input is invalid.
+
+import (
+	"bytes"
+	"flag"
+	"math/rand"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+var (
+	conformanceN    = flag.Int("conformance.n", 200, "number of random inputs to compare with the reference")
+	conformanceSeed = flag.Int64("conformance.seed", 0, "seed of the random inputs, or 0 for the current time")
+)
+
+// reference runs the reference implementation with op on input.
+func reference(t *testing.T, op string, input []byte) ([]byte, bool) {
+	t.Helper()
+	args := strings.Fields(os.Getenv("JASE93_REFERENCE"))
+	if len(args) == 0 {
+		t.Skip("JASE93_REFERENCE is not set")
+	}
+
+	cmd := exec.Command(args[0], append(args[1:], op)...)
+	cmd.Stdin = bytes.NewReader(input)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if _, ok := err.(*exec.ExitError); ok {
+		return nil, false
+	}
+	if err != nil {
+		t.Fatalf("%s %s: %v: %s", strings.Join(args, " "), op, err, stderr.Bytes())
+	}
+	return out, true
+}
+
+func conformanceRand(t *testing.T) *rand.Rand {
+	seed := *conformanceSeed
+	if seed == 0 {
+		seed = rand.Int63()
+	}
+	t.Logf("seed %d", seed)
+	return rand.New(rand.NewSource(seed))
+}
+
+// conformanceLen returns a random input length, favoring short inputs, where the final word is most often exercised.
+func conformanceLen(rng *rand.Rand) int {
+	if rng.Intn(2) == 0 {
+		return rng.Intn(16)
+	}
+	return rng.Intn(4096)
+}
+
+func TestConformanceEncode(t *testing.T) {
+	rng := conformanceRand(t)
+	for i := 0; i < *conformanceN; i++ {
+		src := make([]byte, conformanceLen(rng))
+		rng.Read(src)
+
+		want := Encode(nil, src)
+		got, ok := reference(t, "encode", src)
+		if !ok || !bytes.Equal(got, want) {
+			t.Fatalf("reference encode(%x) = %q, %v, want %q", src, got, ok, want)
+		}
+
+		decoded, ok := reference(t, "decode", want)
+		if !ok || !bytes.Equal(decoded, src) {
+			t.Fatalf("reference decode(%q) = %x, %v, want %x", want, decoded, ok, src)
+		}
+	}
+}
+
+func TestConformanceDecode(t *testing.T) {
+	// Random strings of the alphabet include non-canonical final words and words out of range, which implementations
+	// must decode the same way, or reject
+	rng := conformanceRand(t)
+	for i := 0; i < *conformanceN; i++ {
+		src := make([]byte, conformanceLen(rng))
+		for j := range src {
+			src[j] = encodeStd[rng.Intn(len(encodeStd))]
+		}
+
+		want, err := Decode(nil, src)
+		got, ok := reference(t, "decode", src)
+		if ok != (err == nil) || ok && !bytes.Equal(got, want) {
+			t.Fatalf("reference decode(%q) = %x, %v, want %x, %v", src, got, ok, want, err)
+		}
+	}
+}
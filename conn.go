@@ -0,0 +1,121 @@
+package jase93
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"sync"
+)
+
+// MaxConnLineLen is the length of the longest line of encoded text, without its line break, read by a connection
+// returned by WrapConn.
+const MaxConnLineLen = 1 << 20
+
+// connWriteLen is the most data written as one line by a connection returned by WrapConn, whose encoding is shorter
+// than MaxConnLineLen.
+const connWriteLen = 3 << 18
+
+// WrapConn returns a net.Conn that encodes data written to c with SpaceFreeEncoding and decodes data read from it, so
+// binary protocols can traverse text-only transports such as serial consoles and line-based relays. No line ends in a
+// space, so relays that trim trailing whitespace do not corrupt it.
+//
+// Each Write is sent as one line of encoded text terminated by "\n", so the peer can decode it as soon as it arrives;
+// larger Writes are split into lines of at most MaxConnLineLen characters. Reads accept lines terminated by "\n" or
+// "\r\n" and skip empty lines. A Read that fails, such as on a deadline, can be retried without losing the part of a
+// line already read, but a line longer than MaxConnLineLen fails that and every later Read with a *LimitExceededError,
+// and a line that does not decode with a *CorruptInputError, returning none of its data. The peer must also be
+// wrapped.
+func WrapConn(c net.Conn) net.Conn {
+	return &conn{Conn: c, r: bufio.NewReader(c)}
+}
+
+type conn struct {
+	net.Conn
+
+	r    *bufio.Reader
+	line []byte // the part of the next line read so far
+	buf  []byte
+	err  error // sticky error from a line too long or corrupt
+
+	wmu  sync.Mutex
+	wbuf []byte
+}
+
+func (c *conn) Read(data []byte) (int, error) {
+	for len(c.buf) == 0 {
+		if err := c.readLine(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(data, c.buf)
+	c.buf = c.buf[n:]
+	return n, nil
+}
+
+// readLine reads and decodes the next line into buf, keeping the part read in line if reading fails.
+func (c *conn) readLine() error {
+	if c.err != nil {
+		return c.err
+	}
+	for {
+		part, err := c.r.ReadSlice('\n')
+		c.line = append(c.line, part...)
+		if len(c.line) > MaxConnLineLen+len("\r\n") {
+			c.line, c.err = nil, &LimitExceededError{Limit: MaxConnLineLen}
+			return c.err
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err == io.EOF && len(c.line) > 0 {
+			return io.ErrUnexpectedEOF
+		} else if err != nil {
+			return err
+		}
+		break
+	}
+
+	line := c.line[:len(c.line)-1]
+	if len(line) > 0 && line[len(line)-1] == '\r' {
+		line = line[:len(line)-1]
+	}
+	if len(line) > MaxConnLineLen {
+		c.line, c.err = nil, &LimitExceededError{Limit: MaxConnLineLen}
+		return c.err
+	}
+
+	var err error
+	c.buf, err = SpaceFreeEncoding.Decode(c.buf[:0], line)
+	c.line = c.line[:0]
+	if err != nil {
+		// Discard the data decoded before the corrupt character
+		c.buf, c.err = c.buf[:0], err
+	}
+	return err
+}
+
+func (c *conn) Write(data []byte) (int, error) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	written := 0
+	for len(data) > 0 {
+		n := len(data)
+		if n > connWriteLen {
+			n = connWriteLen
+		}
+		c.wbuf = SpaceFreeEncoding.Encode(c.wbuf[:0], data[:n])
+		c.wbuf = append(c.wbuf, '\n')
+		if _, err := c.Conn.Write(c.wbuf); err != nil {
+			return written, err
+		}
+		written += n
+		data = data[n:]
+	}
+	return written, nil
+}
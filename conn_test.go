@@ -0,0 +1,155 @@
+package jase93
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWrapConn(t *testing.T) {
+	a, b := net.Pipe()
+	ca, cb := WrapConn(a), WrapConn(b)
+	defer ca.Close()
+	defer cb.Close()
+
+	msg := []byte("\x00binary request\xff\r\n")
+	go func() {
+		ca.Write(msg)
+		ca.Write(msg[:3])
+	}()
+
+	buf := make([]byte, len(msg)+3)
+	if _, err := io.ReadFull(cb, buf); err != nil {
+		t.Fatal(err)
+	}
+	if want := append(append([]byte(nil), msg...), msg[:3]...); !bytes.Equal(buf, want) {
+		t.Errorf("Read = %q, want %q", buf, want)
+	}
+}
+
+func TestWrapConnWire(t *testing.T) {
+	a, b := net.Pipe()
+	ca := WrapConn(a)
+	defer ca.Close()
+	defer b.Close()
+
+	msg := make([]byte, 256)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+	go ca.Write(msg)
+
+	line, err := bufio.NewReader(b).ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := string(SpaceFreeEncoding.Encode(nil, msg)) + "\n"; line != want {
+		t.Errorf("wire = %q, want %q", line, want)
+	}
+	if strings.Contains(line, " ") {
+		t.Errorf("wire = %q contains a space", line)
+	}
+}
+
+func TestWrapConnReadLines(t *testing.T) {
+	a, b := net.Pipe()
+	cb := WrapConn(b)
+	defer cb.Close()
+
+	go func() {
+		a.Write([]byte("\n" + string(SpaceFreeEncoding.Encode(nil, []byte("one"))) + "\r\n"))
+		a.Write([]byte(string(SpaceFreeEncoding.Encode(nil, []byte("two")))))
+		a.Close()
+	}()
+
+	buf := make([]byte, 3)
+	if _, err := io.ReadFull(cb, buf); err != nil || string(buf) != "one" {
+		t.Errorf("Read = %q, %v", buf, err)
+	}
+	if _, err := cb.Read(buf); err != io.ErrUnexpectedEOF {
+		t.Errorf("Read(unterminated) = %v", err)
+	}
+}
+
+func TestWrapConnReadTimeout(t *testing.T) {
+	a, b := net.Pipe()
+	cb := WrapConn(b)
+	defer a.Close()
+	defer cb.Close()
+
+	encoded := string(SpaceFreeEncoding.Encode(nil, []byte("a message split by a timeout")))
+	go a.Write([]byte(encoded[:10]))
+
+	// A Read that times out partway through a line leaves the part read for the next
+	cb.SetReadDeadline(time.Now().Add(50 * time.Millisecond))
+	buf := make([]byte, 100)
+	if _, err := cb.Read(buf); err == nil {
+		t.Fatal("Read of partial line succeeded")
+	} else if ne, ok := err.(net.Error); !ok || !ne.Timeout() {
+		t.Fatalf("Read of partial line = %v, want timeout", err)
+	}
+
+	cb.SetReadDeadline(time.Time{})
+	go a.Write([]byte(encoded[10:] + "\n"))
+	n, err := cb.Read(buf)
+	if err != nil || string(buf[:n]) != "a message split by a timeout" {
+		t.Errorf("Read after timeout = %q, %v", buf[:n], err)
+	}
+}
+
+func TestWrapConnLongLines(t *testing.T) {
+	if SpaceFreeEncoding.MaxEncodedLen(connWriteLen) > MaxConnLineLen {
+		t.Fatalf("SpaceFreeEncoding.MaxEncodedLen(connWriteLen) = %d > MaxConnLineLen", SpaceFreeEncoding.MaxEncodedLen(connWriteLen))
+	}
+
+	// Large Writes are split into lines the peer accepts
+	a, b := net.Pipe()
+	ca, cb := WrapConn(a), WrapConn(b)
+	msg := bytes.Repeat([]byte{0xff, 0x00, 0x7f}, MaxConnLineLen)
+	go ca.Write(msg)
+	buf := make([]byte, len(msg))
+	if _, err := io.ReadFull(cb, buf); err != nil || !bytes.Equal(buf, msg) {
+		t.Errorf("Read of large Write = %v", err)
+	}
+	ca.Close()
+	cb.Close()
+
+	// Longer lines are rejected without being buffered whole
+	a, b = net.Pipe()
+	cb = WrapConn(b)
+	defer cb.Close()
+	go func() {
+		a.Write(bytes.Repeat([]byte("0"), MaxConnLineLen+100))
+		a.Close()
+	}()
+	var lerr *LimitExceededError
+	if _, err := cb.Read(buf); !errors.As(err, &lerr) || lerr.Limit != MaxConnLineLen || !errors.Is(err, ErrInvalidData) {
+		t.Errorf("Read of long line = %v", err)
+	}
+	if _, err := cb.Read(buf); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("Read after long line = %v", err)
+	}
+}
+
+func TestWrapConnCorruptLine(t *testing.T) {
+	a, b := net.Pipe()
+	cb := WrapConn(b)
+	defer a.Close()
+	defer cb.Close()
+
+	go a.Write([]byte(string(SpaceFreeEncoding.Encode(nil, []byte("valid prefix"))) + "\"\n"))
+
+	buf := make([]byte, 100)
+	var cerr *CorruptInputError
+	if n, err := cb.Read(buf); n != 0 || !errors.As(err, &cerr) {
+		t.Errorf("Read of corrupt line = %q, %v", buf[:n], err)
+	}
+	if n, err := cb.Read(buf); n != 0 || !errors.As(err, &cerr) {
+		t.Errorf("Read after corrupt line = %q, %v", buf[:n], err)
+	}
+}
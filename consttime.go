@@ -0,0 +1,86 @@
+package jase93
+
+// ConstantTime creates a new Encoding identical to enc except that decoding looks up each character arithmetically,
+// without indexing a table by it, so the pattern of cache accesses does not depend on the data. Use it to decode secret
+// material, such as keys, where other tenants of the machine may observe the cache.
+//
+// Only the alphabet lookups are hardened: the decoder still stops at the first invalid character, and with
+// AdaptivePacking the number of bits in each word, and so the decoded length, depends on the data, as it does for
+// every encoding. Decoding is slower, by a factor that grows with the number of runs of consecutive characters in the
+// alphabet: about two and a half times for StdEncoding. Encoding is unaffected.
+func (enc Encoding) ConstantTime() *Encoding {
+	enc.constantTime = true
+	return &enc
+}
+
+// decodeRange is a run of consecutive characters that decode to consecutive values.
+type decodeRange struct {
+	lo, hi int32 // the first and last characters
+	value  int32 // the value of lo
+}
+
+// decodeRanges returns the runs of the decode table of enc. For StdEncoding there are three.
+func (enc *Encoding) decodeRanges() []decodeRange {
+	var ranges []decodeRange
+	for c := 0; c < len(enc.decode); c++ {
+		v := enc.decode[c]
+		if v == -1 {
+			continue
+		}
+		if n := len(ranges); n > 0 && ranges[n-1].hi == int32(c-1) && ranges[n-1].value+int32(c)-ranges[n-1].lo == int32(v) {
+			ranges[n-1].hi++
+			continue
+		}
+		ranges = append(ranges, decodeRange{lo: int32(c), hi: int32(c), value: int32(v)})
+	}
+	return ranges
+}
+
+// lookupConstantTime returns the value of c, or -1 if it is not in ranges, examining every range without branching on
+// c.
+func lookupConstantTime(ranges []decodeRange, c byte) int8 {
+	x := int32(c)
+	v := int32(-1)
+	for _, r := range ranges {
+		// All ones if lo <= x <= hi, since otherwise one of the differences is negative
+		in := ^((x - r.lo) | (r.hi - x)) >> 31
+		v = v&^in | (x-r.lo+r.value)&in
+	}
+	return int8(v)
+}
+
+// decodeConstantTime decodes normalized src with enc, whose header has been read, and appends it to dst, as decode
+// does.
+func (d *decoder) decodeConstantTime(dst, src []byte, enc *Encoding) ([]byte, error) {
+	if d.ranges == nil {
+		d.ranges = enc.decodeRanges()
+	}
+	start := len(dst)
+
+	for i, c := range src {
+		nibble := lookupConstantTime(d.ranges, c)
+		if nibble == -1 {
+			if d.encoding.skipped(c) {
+				continue
+			}
+			d.offset += int64(i)
+			return dst, newCorruptInputError(d.offset, src[i:])
+		}
+
+		if d.word == -1 {
+			d.word = int16(nibble)
+			continue
+		}
+
+		d.word += int16(nibble) * int16(enc.base)
+
+		d.extra += int64(d.bits.WriteWord(uint32(d.word), enc.wordBits, enc.wordFull) - enc.wordBits)
+		d.words++
+		dst = d.bits.AppendBytes(dst)
+
+		d.word = -1
+	}
+
+	d.offset += int64(len(src))
+	return d.holdTrailer(dst, start), nil
+}
@@ -0,0 +1,71 @@
+package jase93
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"math/rand"
+	"testing"
+)
+
+func TestLookupConstantTime(t *testing.T) {
+	for _, enc := range []*Encoding{StdEncoding, HumanEncoding, NewEncoding("zyxwvutsrqponmlkjihgfedcba")} {
+		ranges := enc.decodeRanges()
+		for c := 0; c < 256; c++ {
+			if got, want := lookupConstantTime(ranges, byte(c)), enc.decode[c]; got != want {
+				t.Errorf("%q: lookup of %q = %d, want %d", enc.encode, c, got, want)
+			}
+		}
+	}
+
+	if n := len(StdEncoding.decodeRanges()); n != 3 {
+		t.Errorf("StdEncoding has %d ranges, want 3", n)
+	}
+}
+
+func TestConstantTime(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	src := make([]byte, 1000)
+	rng.Read(src)
+
+	for _, enc := range []*Encoding{
+		StdEncoding,
+		HumanEncoding,
+		StdEncoding.WithPacking(SimplePacking),
+		StdEncoding.WithHeader(),
+		StdEncoding.WithLineLength(76),
+		StdEncoding.WithLengthTrailer(),
+	} {
+		encoded := enc.Encode(nil, src)
+		ct := enc.ConstantTime()
+
+		dec, err := ct.Decode(nil, encoded)
+		if err != nil || !bytes.Equal(dec, src) {
+			t.Errorf("%q: Decode = %v", enc.encode, err)
+		}
+		dec, err = ioutil.ReadAll(ct.NewDecoder(bytes.NewReader(encoded)))
+		if err != nil || !bytes.Equal(dec, src) {
+			t.Errorf("%q: Decoder = %v", enc.encode, err)
+		}
+	}
+
+	var cerr *CorruptInputError
+	if _, err := StdEncoding.ConstantTime().Decode(nil, []byte(`abc"def`)); !errors.As(err, &cerr) || cerr.Offset != 3 {
+		t.Errorf("Decode of invalid input = %v, want offset 3", err)
+	}
+}
+
+func BenchmarkDecodeConstantTime(b *testing.B) {
+	src := make([]byte, 1<<16)
+	rand.New(rand.NewSource(0)).Read(src)
+	encoded := Encode(nil, src)
+	enc := StdEncoding.ConstantTime()
+	dst := make([]byte, 0, len(src))
+
+	b.SetBytes(int64(len(src)))
+	for i := 0; i < b.N; i++ {
+		if _, err := enc.Decode(dst, encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
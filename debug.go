@@ -0,0 +1,68 @@
+package jase93
+
+import "fmt"
+
+// DebugError wraps a decoding error with the decoder state preceding it, when enabled by Decoder.SetDebugHistory.
+type DebugError struct {
+	Err     error
+	Recent  []byte // The most recent encoded characters, up to and including any that failed
+	Word    int    // The value of the first character of a partial word, or -1
+	Bits    uint64 // Decoded bits not yet forming a whole byte, in the low NBits bits
+	NBits   uint
+	Encoded int64 // The number of encoded characters consumed
+}
+
+func (e *DebugError) Error() string {
+	return fmt.Sprintf("%v (after %q; word %d, %d bits %#x)", e.Err, e.Recent, e.Word, e.NBits, e.Bits)
+}
+
+// Unwrap returns the underlying error.
+func (e *DebugError) Unwrap() error {
+	return e.Err
+}
+
+// SetDebugHistory sets the Decoder to record its n most recent encoded characters and wrap any decoding error in a
+// *DebugError describing its state. Errors from the wrapped io.Reader are not wrapped. If n is not positive,
+// recording is disabled, which is the default.
+func (d *Decoder) SetDebugHistory(n int) {
+	d.dec.history = nil
+	if n > 0 {
+		d.dec.history = &history{n: n}
+	}
+}
+
+// history records the most recent encoded characters.
+type history struct {
+	n   int
+	buf []byte
+}
+
+func (h *history) reset() {
+	h.buf = h.buf[:0]
+}
+
+func (h *history) record(src []byte) {
+	if len(src) > h.n {
+		src = src[len(src)-h.n:]
+	}
+	if keep := h.n - len(src); len(h.buf) > keep {
+		h.buf = h.buf[:copy(h.buf, h.buf[len(h.buf)-keep:])]
+	}
+	h.buf = append(h.buf, src...)
+}
+
+// debugError wraps err in a *DebugError if the decoder is recording its history.
+func (d *decoder) debugError(err error) error {
+	if err == nil || d.history == nil {
+		return err
+	}
+
+	return &DebugError{
+		Err:     err,
+		Recent:  append([]byte(nil), d.history.buf...),
+		Word:    int(d.word),
+		Bits:    d.bits.Bits(),
+		NBits:   d.bits.Len(),
+		Encoded: d.offset,
+	}
+}
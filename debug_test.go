@@ -0,0 +1,45 @@
+package jase93
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"testing/iotest"
+)
+
+func TestDebugHistory(t *testing.T) {
+	in := append(Encode(nil, []byte("Man is distinguished")), `g"(z(`...)
+
+	d := NewDecoder(iotest.OneByteReader(bytes.NewReader(in)))
+	d.SetDebugHistory(5)
+	_, err := ioutil.ReadAll(d)
+
+	var de *DebugError
+	if !errors.As(err, &de) {
+		t.Fatalf("ReadAll = %v", err)
+	}
+	if want := in[len(in)-8 : len(in)-3]; !bytes.Equal(de.Recent, want) {
+		t.Errorf("Recent = %q != %q", de.Recent, want)
+	}
+	// The invalid character either completes a word or begins one
+	word := -1
+	if (len(in)-4)%2 == 1 {
+		word = int(StdEncoding.decode['g'])
+	}
+	if de.Word != word {
+		t.Errorf("Word = %d", de.Word)
+	}
+	if !errors.Is(err, ErrInvalidData) {
+		t.Errorf("%v does not match ErrInvalidData", err)
+	}
+
+	var cie *CorruptInputError
+	if !errors.As(err, &cie) || cie.Offset != int64(len(in)-4) {
+		t.Errorf("ReadAll = %v", err)
+	}
+
+	if _, err := ioutil.ReadAll(NewDecoder(bytes.NewReader(in))); errors.As(err, &de) {
+		t.Errorf("ReadAll without history = %v", err)
+	}
+}
@@ -0,0 +1,196 @@
+package jase93
+
+import (
+	"errors"
+	"io"
+	"runtime"
+	"sync"
+)
+
+// decodeToMinShard is the smallest number of encoded characters DecodeTo gives each worker.
+const decodeToMinShard = 64 << 10
+
+// DecodeTo decodes src with up to workers goroutines, or GOMAXPROCS if workers is not positive, each writing the data
+// it decodes directly at its final offset in wa, such as an *os.File, so restoring a large archive to disk is not
+// limited by a single writer. It returns the number of bytes decoded. Calls to wa.WriteAt may be concurrent, and are
+// made in no particular order.
+//
+// Since a word carries 13 or 14 bits, the offset of the data of each shard of src depends on every word before it, so
+// src is read twice, in parallel: once to count the bits of each shard and validate it, and then to decode each shard,
+// starting at its first word that begins on a byte boundary. If src contains an invalid character, DecodeTo
+// returns a *CorruptInputError for the first one without writing anything.
+//
+// Encodings that skip line breaks or whitespace, or that have a length trailer, or are Lenient or ConstantTime, are
+// decoded by a single goroutine, writing to wa in order as the data is decoded.
+func (enc *Encoding) DecodeTo(wa io.WriterAt, src []byte, workers int) (int64, error) {
+	if enc.trailer || enc.lineLen > 0 || enc.ignoreBreaks || enc.ignoreSpace || enc.lenient || enc.constantTime {
+		return enc.decodeToSequential(wa, src)
+	}
+
+	core, offset := enc, 0
+	if enc.header {
+		if len(src) < HeaderLen {
+			return 0, &HeaderError{Header: string(src)}
+		}
+		var err error
+		if core, err = parseHeader(src[:HeaderLen]); err != nil {
+			return 0, err
+		}
+		src, offset = src[HeaderLen:], HeaderLen
+	}
+
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	words := len(src) / 2
+	shards := len(src)/decodeToMinShard + 1
+	if shards > workers {
+		shards = workers
+	}
+
+	// Count the bits of each shard of words, in parallel, validating them
+	bounds := make([]int, shards+1)
+	for i := range bounds {
+		bounds[i] = words * i / shards
+	}
+	bits := make([]int64, shards)
+	errs := make([]error, shards)
+	parallel(shards, func(i int) {
+		bits[i], errs[i] = core.countBits(src, bounds[i], bounds[i+1])
+	})
+	for _, err := range errs {
+		if err != nil {
+			return 0, adjustOffset(err, int64(offset))
+		}
+	}
+	if len(src)%2 == 1 && core.decode[src[len(src)-1]] == -1 {
+		return 0, newCorruptInputError(int64(offset+len(src)-1), src[len(src)-1:])
+	}
+
+	// Start each shard at its first word that begins on a byte boundary. Shards with none are merged into the previous
+	// one.
+	starts, bitOffsets := []int{0}, []int64{0}
+	var bit int64
+	for i := 1; i < shards; i++ {
+		bit += bits[i-1]
+		w, b := bounds[i], bit
+		for w < words && b%8 != 0 {
+			b += int64(core.wordBitLen(src[2*w], src[2*w+1]))
+			w++
+		}
+		if b%8 == 0 && w > starts[len(starts)-1] {
+			starts, bitOffsets = append(starts, w), append(bitOffsets, b)
+		}
+	}
+	starts = append(starts, words)
+
+	// Decode each shard at its offset, the last including any final character
+	n := make([]int64, len(starts)-1)
+	errs = errs[:len(starts)-1]
+	parallel(len(starts)-1, func(i int) {
+		end := 2 * starts[i+1]
+		last := i == len(starts)-2
+		if last {
+			end = len(src)
+		}
+
+		d := decoder{encoding: core}
+		d.reset()
+		out, err := d.decode(nil, src[2*starts[i]:end])
+		if err == nil && last {
+			out, err = d.flush(out)
+		}
+		if err != nil {
+			errs[i] = adjustOffset(err, int64(offset+2*starts[i]))
+			return
+		}
+		if len(out) > 0 {
+			_, errs[i] = wa.WriteAt(out, bitOffsets[i]/8)
+		}
+		n[i] = int64(len(out))
+	})
+
+	var total int64
+	for i := range n {
+		total += n[i]
+	}
+	for _, err := range errs {
+		if err != nil {
+			return total, err
+		}
+	}
+	recordDecode(int64(len(src)+offset), int(total), nil)
+	return total, nil
+}
+
+// DecodeTo decodes src with StdEncoding to wa with up to workers goroutines; see Encoding.DecodeTo.
+func DecodeTo(wa io.WriterAt, src []byte, workers int) (int64, error) {
+	return StdEncoding.DecodeTo(wa, src, workers)
+}
+
+// decodeToSequential decodes src with a single Decoder, writing the data to wa in order.
+func (enc *Encoding) decodeToSequential(wa io.WriterAt, src []byte) (int64, error) {
+	d := decoder{encoding: enc}
+	d.reset()
+	out, err := d.write(nil, src)
+	if err == nil {
+		out, err = d.flush(out)
+	}
+	recordDecode(int64(len(src)), len(out), err)
+	if len(out) > 0 {
+		if _, werr := wa.WriteAt(out, 0); err == nil {
+			err = werr
+		}
+	}
+	return int64(len(out)), err
+}
+
+// countBits returns the number of bits carried by the words of src from first up to end, or a *CorruptInputError for
+// the first invalid character.
+func (enc *Encoding) countBits(src []byte, first, end int) (int64, error) {
+	var n int64
+	for w := first; w < end; w++ {
+		a, b := src[2*w], src[2*w+1]
+		if enc.decode[a] == -1 {
+			return 0, newCorruptInputError(int64(2*w), src[2*w:])
+		}
+		if enc.decode[b] == -1 {
+			return 0, newCorruptInputError(int64(2*w+1), src[2*w+1:])
+		}
+		n += int64(enc.wordBitLen(a, b))
+	}
+	return n, nil
+}
+
+// wordBitLen returns the number of bits carried by the word of the valid characters a and b.
+func (enc *Encoding) wordBitLen(a, b byte) uint {
+	word := uint32(enc.decode[a]) + uint32(enc.decode[b])*enc.base
+	if word&enc.wordMask < enc.wordFull {
+		return enc.wordBits + 1
+	}
+	return enc.wordBits
+}
+
+// adjustOffset returns err with the offset of a *CorruptInputError advanced by offset.
+func adjustOffset(err error, offset int64) error {
+	var cerr *CorruptInputError
+	if errors.As(err, &cerr) {
+		e := *cerr
+		e.Offset += offset
+		return &e
+	}
+	return err
+}
+
+// parallel calls f(i) for i from 0 up to n, each in its own goroutine, and waits for them to return.
+func parallel(n int, f func(i int)) {
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			defer wg.Done()
+			f(i)
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,81 @@
+package jase93
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+// writerAt is an io.WriterAt backed by a growing buffer.
+type writerAt struct {
+	mu  sync.Mutex
+	buf []byte
+}
+
+func (w *writerAt) WriteAt(p []byte, off int64) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if end := int(off) + len(p); end > len(w.buf) {
+		w.buf = append(w.buf, make([]byte, end-len(w.buf))...)
+	}
+	return copy(w.buf[off:], p), nil
+}
+
+func TestDecodeTo(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	for _, size := range []int{0, 1, 2, 1000, 1 << 20} {
+		src := make([]byte, size)
+		rng.Read(src)
+		if size > 1000 {
+			copy(src[size/3:], make([]byte, size/3)) // zeros carry extra bits
+		}
+
+		for _, enc := range []*Encoding{StdEncoding, HumanEncoding.WithHeader(), StdEncoding.WithPacking(SimplePacking), StdEncoding.WithLengthTrailer()} {
+			for _, workers := range []int{1, 3, 16} {
+				var w writerAt
+				n, err := enc.DecodeTo(&w, enc.Encode(nil, src), workers)
+				if err != nil || n != int64(size) || !bytes.Equal(w.buf, src) {
+					t.Errorf("DecodeTo(%d bytes, %d workers) = %d, %v", size, workers, n, err)
+				}
+			}
+		}
+	}
+}
+
+func TestDecodeToErrors(t *testing.T) {
+	src := make([]byte, 1<<20)
+	rand.New(rand.NewSource(0)).Read(src)
+	encoded := Encode(nil, src)
+	encoded[len(encoded)/2] = '"'
+	encoded[len(encoded)-10] = '\\'
+
+	var w writerAt
+	_, err := DecodeTo(&w, encoded, 8)
+	var cerr *CorruptInputError
+	if !errors.As(err, &cerr) || cerr.Offset != int64(len(encoded)/2) || len(w.buf) != 0 {
+		t.Errorf("DecodeTo(corrupt) = %v, wrote %d bytes", err, len(w.buf))
+	}
+
+	odd := Encode(nil, src[:1<<19])
+	if len(odd)%2 == 0 {
+		odd = odd[:len(odd)-1]
+	}
+	odd[len(odd)-1] = '"'
+	w.buf = nil
+	_, err = DecodeTo(&w, odd, 8)
+	if !errors.As(err, &cerr) || cerr.Offset != int64(len(odd)-1) || len(w.buf) != 0 {
+		t.Errorf("DecodeTo(corrupt final character) = %v, wrote %d bytes", err, len(w.buf))
+	}
+
+	headered := StdEncoding.WithHeader().Encode(nil, []byte("Man is distinguished"))
+	headered[5] = '"'
+	_, err = StdEncoding.WithHeader().DecodeTo(&w, headered, 8)
+	if !errors.As(err, &cerr) || cerr.Offset != 5 {
+		t.Errorf("DecodeTo(corrupt headered) = %v", err)
+	}
+	if _, err := StdEncoding.WithHeader().DecodeTo(&w, []byte("z"), 8); !errors.Is(err, ErrInvalidHeader) {
+		t.Errorf("DecodeTo(short header) = %v", err)
+	}
+}
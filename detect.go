@@ -0,0 +1,143 @@
+package jase93
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"math"
+)
+
+// DefaultDetectLen is the number of bytes sampled to detect the Encoding of a stream by SetDetectEncoding when n is not
+// positive.
+const DefaultDetectLen = 4096
+
+// detectMinBits is the evidence, in bits, that the most likely alphabet must have over the next before it is detected:
+// the log2 of the ratio of their likelihoods.
+const detectMinBits = 20
+
+// ErrUndetectedEncoding indicates that the Encoding of a sample could not be determined, because no registered
+// Encoding could have produced it, or because it was too short to tell them apart.
+var ErrUndetectedEncoding = errors.New("jase93: encoding not detected")
+
+// DetectEncoding returns the registered Encoding that produced sample, the start of an encoded stream, so archives of
+// streams encoded with different alphabet variants can be decoded without configuration. Codecs registered with
+// Register that are not Encodings are not considered.
+//
+// If sample begins with a stream header, and its alphabet is consistent with the remainder, DetectEncoding returns an
+// Encoding that reads the header. A stream without a header is mistaken for one if it happens to begin with the
+// characters of its own header, as about one StdEncoding stream in 4,000 does, so archives should not mix streams with
+// and without headers.
+//
+// Otherwise, DetectEncoding chooses the alphabet most likely to have produced the sample, among those that contain all
+// its characters, if that is much more likely than the next: streams in a larger alphabet are identified by their first
+// character outside the smaller ones, but streams in a smaller alphabet need enough characters to rule out the larger
+// ones: a few hundred, or about 1,300 to tell SpaceFreeEncoding from StdEncoding. Alphabets of the same size containing
+// the sample cannot be told apart. If sample contains line breaks, the Encoding returned skips them, and wraps lines at
+// the length of the first.
+func DetectEncoding(sample []byte) (*Encoding, error) {
+	lineLen := 0
+	chars := make([]byte, 0, len(sample))
+	for i, c := range sample {
+		if c == '\r' || c == '\n' {
+			if lineLen == 0 {
+				lineLen = i
+			}
+			continue
+		}
+		chars = append(chars, c)
+	}
+
+	enc := detectAlphabet(chars)
+	if len(chars) >= HeaderLen {
+		if h, err := parseHeader(chars[:HeaderLen]); err == nil && (enc == nil || enc.encode == h.encode) && h.accepts(chars[HeaderLen:]) {
+			enc = h.WithHeader()
+		}
+	}
+	if enc == nil {
+		return nil, ErrUndetectedEncoding
+	}
+	if lineLen > 0 {
+		enc = enc.WithLineLength(lineLen)
+	}
+	return enc, nil
+}
+
+// detectAlphabet returns the registered Encoding most likely to have produced chars, or nil if there is no clear
+// choice.
+func detectAlphabet(chars []byte) *Encoding {
+	// Find the smallest alphabets containing chars, ignoring registered Encodings that share an alphabet
+	var best, next *Encoding
+	seen := make(map[string]bool)
+	for _, name := range Codecs() {
+		c, _ := Lookup(name)
+		enc, ok := c.(*Encoding)
+		if !ok || seen[enc.encode] || !enc.accepts(chars) {
+			continue
+		}
+		seen[enc.encode] = true
+
+		if best == nil || enc.base < best.base {
+			best, next = enc, best
+		} else if next == nil || enc.base < next.base {
+			next = enc
+		}
+	}
+
+	if best == nil || next != nil && (next.base == best.base || float64(len(chars))*math.Log2(float64(next.base)/float64(best.base)) < detectMinBits) {
+		return nil
+	}
+	return best
+}
+
+// accepts reports whether every character of chars is in the alphabet of enc.
+func (enc *Encoding) accepts(chars []byte) bool {
+	for _, c := range chars {
+		if enc.decode[c] == -1 {
+			return false
+		}
+	}
+	return true
+}
+
+// SetDetectEncoding sets the Decoder to detect the Encoding of each stream with DetectEncoding, from a sample of its
+// first n bytes, or DefaultDetectLen bytes if n is not positive, rather than decoding with the Encoding it was created
+// with. The sample is read with a bufio.Reader of at least n bytes, which wraps the io.Reader unless it is one already.
+// The first Read returns any error from DetectEncoding. SetDetectEncoding must be called before the first Read, and
+// is retained by Reset.
+func (d *Decoder) SetDetectEncoding(n int) {
+	if n <= 0 {
+		n = DefaultDetectLen
+	}
+	d.detect = n
+}
+
+// detectEncoding sets the Encoding of the stream from a sample of its first d.detect bytes.
+func (d *Decoder) detectEncoding() error {
+	br, ok := d.r.(*bufio.Reader)
+	if !ok || br.Size() < d.detect {
+		if d.limit > 0 && d.detect > len(d.in) {
+			// Buffering the sample would exceed the memory limit
+			return &LimitExceededError{Limit: int64(len(d.in))}
+		}
+		br = bufio.NewReaderSize(d.r, d.detect)
+		d.r = br
+	}
+	sample, err := br.Peek(d.detect)
+	if err != nil && err != io.EOF {
+		return err
+	}
+	d.undetected = d.dec.encoding
+	if len(sample) == 0 {
+		// Any Encoding decodes an empty stream
+		return nil
+	}
+
+	enc, err := DetectEncoding(sample)
+	if err != nil {
+		d.undetected = nil
+		return err
+	}
+	d.dec.encoding = enc
+	d.dec.reset()
+	return nil
+}
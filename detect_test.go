@@ -0,0 +1,72 @@
+package jase93
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestDetectEncoding(t *testing.T) {
+	src := make([]byte, 2000)
+	rand.New(rand.NewSource(0)).Read(src)
+
+	for _, name := range []string{"jase93", "jase93-human", "jase93-space-free", "jase93-cookie", "jase93-filename"} {
+		c, _ := Lookup(name)
+		enc := c.(*Encoding)
+		for _, e := range []*Encoding{enc, enc.WithHeader(), enc.WithLineLength(MIMELineLen)} {
+			encoded := e.Encode(nil, src)
+			got, err := DetectEncoding(encoded)
+			if err != nil {
+				t.Errorf("%s: DetectEncoding = %v", name, err)
+				continue
+			}
+			if got.encode != enc.encode || got.header != e.header || got.lineLen != e.lineLen {
+				t.Errorf("%s: detected %q, header %v, line length %d", name, got.encode, got.header, got.lineLen)
+			}
+			if dec, err := got.Decode(nil, encoded); err != nil || !bytes.Equal(dec, src) {
+				t.Errorf("%s: Decode with detected encoding = %v", name, err)
+			}
+		}
+	}
+
+	// Alphabets of the same size, and too little evidence, are not told apart
+	for _, sample := range []string{
+		strings.Repeat("Mo", 200),
+		"Man",
+		"",
+	} {
+		if enc, err := DetectEncoding([]byte(sample)); err != ErrUndetectedEncoding {
+			t.Errorf("DetectEncoding(%.20q) = %v, %v", sample, enc, err)
+		}
+	}
+	if enc, err := DetectEncoding([]byte("a \"")); err != ErrUndetectedEncoding {
+		t.Errorf("DetectEncoding(invalid) = %v, %v", enc, err)
+	}
+	if enc, err := DetectEncoding([]byte("Man is")); err != nil || enc.encode != encodeStd {
+		t.Errorf("DetectEncoding(space) = %v, %v", enc, err)
+	}
+}
+
+func TestDecoderDetectEncoding(t *testing.T) {
+	src := []byte(strings.Repeat("Man is distinguished, not only by his reason. ", 20))
+
+	d := NewDecoder(nil)
+	d.SetDetectEncoding(0)
+	for _, enc := range []*Encoding{HumanEncoding, StdEncoding, CookieEncoding.WithHeader(), HumanEncoding} {
+		d.Reset(bytes.NewReader(enc.Encode(nil, src)))
+		if dec, err := ioutil.ReadAll(d); err != nil || !bytes.Equal(dec, src) {
+			t.Errorf("%q: ReadAll = %v", enc.encode, err)
+		}
+	}
+
+	d.Reset(strings.NewReader(""))
+	if dec, err := ioutil.ReadAll(d); err != nil || len(dec) != 0 {
+		t.Errorf("ReadAll(empty) = %q, %v", dec, err)
+	}
+	d.Reset(strings.NewReader("\"\""))
+	if _, err := ioutil.ReadAll(d); err != ErrUndetectedEncoding {
+		t.Errorf("ReadAll(invalid) = %v", err)
+	}
+}
@@ -0,0 +1,122 @@
+package jase93
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// doubleMinLen is the length of the shortest data reported by CheckDoubleEncoded.
+const doubleMinLen = 24
+
+// ErrDoubleEncoded indicates that decoded data appears to be encoded itself. It is matched by DoubleEncodedError.
+var ErrDoubleEncoded = errors.New("jase93: decoded data appears to be encoded")
+
+// DoubleEncodedError reports decoded data that appears to be encoded itself, usually because it was encoded twice. It
+// matches ErrDoubleEncoded, but not ErrInvalidData, since the data decoded successfully.
+type DoubleEncodedError struct {
+	Codec string // The name of the registered Codec, such as "jase93", or "base64"
+}
+
+func (e *DoubleEncodedError) Error() string {
+	return fmt.Sprintf("jase93: decoded data appears to be encoded with %s", e.Codec)
+}
+
+// Is reports whether target is ErrDoubleEncoded.
+func (e *DoubleEncodedError) Is(target error) bool {
+	return target == ErrDoubleEncoded
+}
+
+// CheckDoubleEncoded returns a *DoubleEncodedError if data, the result of decoding, appears to be encoded with
+// standard or URL-safe base64, or with a registered Encoding, such as StdEncoding, so that data encoded twice by mistake
+// can be diagnosed. It returns nil otherwise.
+//
+// The check is a heuristic, applied to the first DefaultDetectLen bytes. Data is only reported if it is at least 24
+// bytes long, consists of characters of the encoding, and is varied enough to be an encoding of binary or compressed
+// data: it has nearly as many distinct characters as random characters of the alphabet would, and, for base64, mixes
+// letters of both cases and digits and decodes successfully, or, for jase93, has at least 15% digits and punctuation
+// other than spaces. Text is rarely reported, but encodings of long runs of repetitive data are missed. The Encoding
+// reported is the one DetectEncoding would choose, or the largest alphabet containing the data, such as StdEncoding's,
+// if the data is too short to tell.
+func CheckDoubleEncoded(data []byte) error {
+	if len(data) < doubleMinLen {
+		return nil
+	}
+	sample := data
+	if len(sample) > DefaultDetectLen {
+		sample = sample[:DefaultDetectLen]
+	}
+
+	var seen [256]bool
+	var distinct, upper, lower, digit, punct int
+	for _, c := range sample {
+		if !seen[c] {
+			seen[c] = true
+			distinct++
+		}
+		switch {
+		case 'A' <= c && c <= 'Z':
+			upper++
+		case 'a' <= c && c <= 'z':
+			lower++
+		case '0' <= c && c <= '9':
+			digit++
+		case c != ' ':
+			punct++
+		}
+	}
+
+	if upper > 0 && lower > 0 && digit > 0 && varied(distinct, len(sample), 64) && isBase64(data) {
+		return &DoubleEncodedError{Codec: "base64"}
+	}
+
+	// Digits and punctuation other than spaces make up a third or more of random characters of each predefined
+	// alphabet, but little of text
+	if 20*(digit+punct) < 3*len(sample) {
+		return nil
+	}
+	// Report the alphabet DetectEncoding would choose, or the largest containing the sample if it is too short to tell
+	detected := detectAlphabet(sample)
+	var best *Encoding
+	var bestName string
+	for _, name := range Codecs() {
+		c, _ := Lookup(name)
+		enc, ok := c.(*Encoding)
+		if !ok || !enc.accepts(sample) {
+			continue
+		}
+		if enc == detected || detected == nil && (best == nil || enc.base > best.base) {
+			best, bestName = enc, name
+		}
+	}
+	if best == nil || !varied(distinct, len(sample), int(best.base)) {
+		return nil
+	}
+	return &DoubleEncodedError{Codec: bestName}
+}
+
+// varied reports whether n characters including distinct different characters are about as varied as n random
+// characters of an alphabet of size k, which include k(1 - (1 - 1/k)^n) different characters on average.
+func varied(distinct, n, k int) bool {
+	expected := float64(k) * (1 - math.Pow(1-1/float64(k), float64(n)))
+	return float64(distinct) >= 0.75*expected
+}
+
+// isBase64 reports whether data decodes as standard or URL-safe base64, padded or not.
+func isBase64(data []byte) bool {
+	s := string(data)
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if _, err := enc.Strict().DecodeString(s); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// WithDoubleEncodingCheck creates a new Encoding identical to enc except that Decode returns the decoded data with a
+// *DoubleEncodedError if CheckDoubleEncoded reports it. Decoders are unaffected.
+func (enc Encoding) WithDoubleEncodingCheck() *Encoding {
+	enc.checkDouble = true
+	return &enc
+}
@@ -0,0 +1,74 @@
+package jase93
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestCheckDoubleEncoded(t *testing.T) {
+	const text = "Man is distinguished, not only by his reason, but by this singular passion from other animals."
+	rng := rand.New(rand.NewSource(0))
+	random := func(n int) []byte {
+		b := make([]byte, n)
+		rng.Read(b)
+		return b
+	}
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write(bytes.Repeat([]byte(text), 10))
+	zw.Close()
+
+	for _, tc := range []struct {
+		name  string
+		data  []byte
+		codec string
+	}{
+		{"jase93", Encode(nil, random(100)), "jase93"},
+		{"jase93 of text", Encode(nil, []byte(text)), "jase93"},
+		{"jase93 of gzip", Encode(nil, gz.Bytes()), "jase93"},
+		{"short jase93", Encode(nil, random(20)), "jase93"},
+		{"human", HumanEncoding.Encode(nil, random(100)), "jase93-human"},
+		{"space-free", SpaceFreeEncoding.Encode(nil, random(2000)), "jase93-space-free"},
+		{"base64", []byte(base64.StdEncoding.EncodeToString(random(100))), "base64"},
+		{"raw URL base64", []byte(base64.RawURLEncoding.EncodeToString(random(50))), "base64"},
+		{"text", []byte(text), ""},
+		{"URL", []byte("https://example.com/path/to/resource?query=value&other=1"), ""},
+		{"identifier", []byte("MaximumRetryCountExceeded2024"), ""},
+		{"binary", random(100), ""},
+		{"too short", Encode(nil, random(10)), ""},
+		{"repetitive", Encode(nil, make([]byte, 100)), ""},
+	} {
+		err := CheckDoubleEncoded(tc.data)
+		var derr *DoubleEncodedError
+		if tc.codec == "" {
+			if err != nil {
+				t.Errorf("%s: CheckDoubleEncoded(%q) = %v, want nil", tc.name, tc.data, err)
+			}
+		} else if !errors.As(err, &derr) || derr.Codec != tc.codec || !errors.Is(err, ErrDoubleEncoded) {
+			t.Errorf("%s: CheckDoubleEncoded(%q) = %v, want %s", tc.name, tc.data, err, tc.codec)
+		}
+	}
+}
+
+func TestWithDoubleEncodingCheck(t *testing.T) {
+	src := make([]byte, 100)
+	rand.New(rand.NewSource(0)).Read(src)
+	once := Encode(nil, src)
+	twice := Encode(nil, once)
+
+	enc := StdEncoding.WithDoubleEncodingCheck()
+	dst, err := enc.Decode([]byte("prefix"), twice)
+	if !errors.Is(err, ErrDoubleEncoded) || errors.Is(err, ErrInvalidData) || !bytes.Equal(dst, append([]byte("prefix"), once...)) {
+		t.Errorf("Decode(twice) = %q, %v", dst, err)
+	}
+	if dst, err := enc.Decode(nil, once); err != nil || !bytes.Equal(dst, src) {
+		t.Errorf("Decode(once) = %x, %v", dst, err)
+	}
+	if _, err := StdEncoding.Decode(nil, twice); err != nil {
+		t.Errorf("Decode(twice) without check = %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package jase93
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// dumpLineLen is the number of encoded characters per line of a dump. They decode to at most 14 bytes.
+const dumpLineLen = 16
+
+// Dump returns a dump of the encoded data, showing the offset of each line of characters, the characters, and the
+// bytes they decode to in hexadecimal and as ASCII, side by side, for debugging corrupt payloads:
+//
+//	00000000  `}g%-`_M>0dH#;Um  4d 61 6e 20 69 73 20 64  69 73 74 69 6e        |Man is distin|
+//	00000010  krj3"`)Sv!~`0jLp  67 75 69 63 32 6c 7d 5b  18 5b ce              |guic2l}[.[.|  ! invalid 0x22 (double quote) at 00000014
+//
+// Characters and bytes that are not printable are shown as '.'. Invalid characters are noted at the end of their line
+// and skipped, so decoding continues past them, although what follows may not decode as intended. Any length trailer
+// is shown as data.
+func (enc *Encoding) Dump(data []byte) string {
+	var b strings.Builder
+	d := enc.Dumper(&b)
+	d.Write(data)
+	d.Close()
+	return b.String()
+}
+
+// Dump returns a dump of data encoded with StdEncoding; see Encoding.Dump.
+func Dump(data []byte) string {
+	return StdEncoding.Dump(data)
+}
+
+// Dumper returns an io.WriteCloser that writes a dump of the encoded data written to it to w, as Dump does. Each line
+// is written once complete; Close writes the final line. Close does not close w.
+func (enc *Encoding) Dumper(w io.Writer) io.WriteCloser {
+	// Show any length trailer as data, rather than holding it back to verify
+	plain := *enc
+	plain.trailer = false
+
+	d := &dumper{w: w}
+	d.dec.encoding = &plain
+	d.dec.reset()
+	return d
+}
+
+// Dumper returns an io.WriteCloser that dumps data encoded with StdEncoding to w; see Encoding.Dumper.
+func Dumper(w io.Writer) io.WriteCloser {
+	return StdEncoding.Dumper(w)
+}
+
+type dumper struct {
+	w      io.Writer
+	dec    decoder
+	offset int64    // the offset of the current line
+	chars  []byte   // the characters of the current line
+	out    []byte   // the data decoded from the current line
+	notes  []string // problems found in the current line
+	failed bool     // decoding cannot continue
+	line   []byte
+	closed bool
+	err    error
+}
+
+func (d *dumper) Write(data []byte) (int, error) {
+	if d.err != nil {
+		return 0, d.err
+	}
+	if d.closed {
+		return 0, ErrWriteAfterClose
+	}
+
+	for i, c := range data {
+		d.chars = append(d.chars, c)
+		if !d.failed {
+			var err error
+			d.out, err = d.dec.write(d.out, data[i:i+1])
+			d.note(err, d.offset+int64(len(d.chars)-1))
+		}
+
+		if len(d.chars) == dumpLineLen {
+			if d.err = d.writeLine(); d.err != nil {
+				return i, d.err
+			}
+		}
+	}
+	return len(data), nil
+}
+
+// Close decodes any remaining data and writes the final line.
+func (d *dumper) Close() error {
+	if d.closed || d.err != nil {
+		return d.err
+	}
+	d.closed = true
+
+	if !d.failed {
+		var err error
+		d.out, err = d.dec.flush(d.out)
+		d.note(err, d.offset+int64(len(d.chars)))
+	}
+	if len(d.chars) > 0 || len(d.out) > 0 || len(d.notes) > 0 {
+		d.err = d.writeLine()
+	}
+	return d.err
+}
+
+// note notes a decoding error at offset in the current line.
+func (d *dumper) note(err error, offset int64) {
+	if err == nil {
+		return
+	}
+	if e, ok := err.(*CorruptInputError); ok {
+		// Skip the character, leaving the decoder ready for the next
+		d.notes = append(d.notes, fmt.Sprintf("invalid %#02x (%s) at %08x", e.Char, e.Description(), offset))
+		return
+	}
+	d.notes = append(d.notes, err.Error())
+	d.failed = true
+}
+
+// writeLine writes and starts a new line.
+func (d *dumper) writeLine() error {
+	b := append(d.line[:0], fmt.Sprintf("%08x  ", d.offset)...)
+	for _, c := range d.chars {
+		b = append(b, dumpChar(c))
+	}
+	b = append(b, strings.Repeat(" ", dumpLineLen-len(d.chars)+2)...)
+
+	// The decoded data never exceeds 15 bytes: 14 from the line, and one more from a final character
+	const maxOut = 15
+	for i := 0; i < maxOut; i++ {
+		if i == 8 {
+			b = append(b, ' ')
+		}
+		if i < len(d.out) {
+			b = append(b, fmt.Sprintf("%02x ", d.out[i])...)
+		} else {
+			b = append(b, "   "...)
+		}
+	}
+	b = append(b, " |"...)
+	for _, c := range d.out {
+		b = append(b, dumpChar(c))
+	}
+	b = append(b, '|')
+	for _, note := range d.notes {
+		b = append(b, "  ! "+note...)
+	}
+	b = append(b, '\n')
+	d.line = b
+
+	d.offset += int64(len(d.chars))
+	d.chars, d.out, d.notes = d.chars[:0], d.out[:0], d.notes[:0]
+	_, err := d.w.Write(b)
+	return err
+}
+
+// dumpChar returns c if it is printable ASCII, and '.' otherwise.
+func dumpChar(c byte) byte {
+	if c < ' ' || c > '~' {
+		return '.'
+	}
+	return c
+}
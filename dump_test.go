@@ -0,0 +1,48 @@
+package jase93
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDump(t *testing.T) {
+	src := []byte("Man is distinguished, not only by his reason, but by this singular passion")
+	encoded := Encode(nil, src)
+
+	want := "" +
+		"00000000  `}g%-`_M>0dH#;Um  4d 61 6e 20 69 73 20 64  69 73 74 69 6e        |Man is distin|\n" +
+		"00000010  krj3!`)Sv!~`0jLp  67 75 69 73 68 65 64 2c  20 6e 6f 74 20        |guished, not |\n" +
+		"00000020  ~F}goORufM1`_M{]  6f 6e 6c 79 20 62 79 20  68 69 73 20 72        |only by his r|\n" +
+		"00000030  PBRKO*.7]>$5|O5&  65 61 73 6f 6e 2c 20 62  75 74 20 62 79        |eason, but by|\n" +
+		"00000040  {Hu:x*6q@qo_2_4;  20 74 68 69 73 20 73 69  6e 67 75 6c 61        | this singula|\n" +
+		"00000050  0,%~~F;EfH$       72 20 70 61 73 73 69 6f  6e                    |r passion|\n"
+	if got := Dump(encoded); got != want {
+		t.Errorf("Dump =\n%s\nwant\n%s", got, want)
+	}
+
+	// The streaming form writes the same dump however the data is split
+	var buf bytes.Buffer
+	d := Dumper(&buf)
+	for _, c := range encoded {
+		d.Write([]byte{c})
+	}
+	if err := d.Close(); err != nil || buf.String() != want {
+		t.Errorf("Dumper =\n%s\n%v", buf.String(), err)
+	}
+
+	// Invalid characters are noted and skipped
+	corrupt := append([]byte(nil), encoded...)
+	corrupt[20] = '"'
+	lines := strings.Split(Dump(corrupt), "\n")
+	if !strings.HasSuffix(lines[1], "  ! invalid 0x22 (double quote) at 00000014") || !strings.HasPrefix(lines[1], "00000010  krj3\"`)Sv") {
+		t.Errorf("Dump of invalid character = %q", lines[1])
+	}
+	if got, want := Dump([]byte("\n")), "00000000  ."+strings.Repeat(" ", 15+2+15*3+1)+" ||  ! invalid 0x0a (control character) at 00000000\n"; got != want {
+		t.Errorf("Dump of control character = %q", got)
+	}
+
+	if got := Dump(nil); got != "" {
+		t.Errorf("Dump(nil) = %q", got)
+	}
+}
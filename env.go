@@ -0,0 +1,48 @@
+package jase93
+
+import (
+	"errors"
+	"os"
+)
+
+// ErrEnvNotSet indicates that an environment variable was not set.
+var ErrEnvNotSet = errors.New("not set")
+
+// EnvError reports an environment variable that could not be decoded.
+type EnvError struct {
+	Name string // The name of the environment variable
+	Err  error  // ErrEnvNotSet, or the decoding error
+}
+
+func (e *EnvError) Error() string {
+	return "jase93: environment variable " + e.Name + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *EnvError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeEnv decodes the value of the environment variable name with StdEncoding. If the variable is not set, or is
+// invalid, it returns an *EnvError naming it. The error does not include the value beyond any invalid character.
+func DecodeEnv(name string) ([]byte, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return nil, &EnvError{Name: name, Err: ErrEnvNotSet}
+	}
+
+	data, err := Decode(nil, []byte(value))
+	if err != nil {
+		return nil, &EnvError{Name: name, Err: err}
+	}
+	return data, nil
+}
+
+// MustDecodeEnv is like DecodeEnv but panics if the variable is not set or is invalid.
+func MustDecodeEnv(name string) []byte {
+	data, err := DecodeEnv(name)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
@@ -0,0 +1,48 @@
+package jase93
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDecodeEnv(t *testing.T) {
+	const name = "JASE93_TEST_SECRET"
+	secret := []byte("\x00secret\xff")
+	os.Setenv(name, string(Encode(nil, secret)))
+	defer os.Unsetenv(name)
+
+	if data, err := DecodeEnv(name); err != nil || string(data) != string(secret) {
+		t.Errorf("DecodeEnv = %q, %v", data, err)
+	}
+	if data := MustDecodeEnv(name); string(data) != string(secret) {
+		t.Errorf("MustDecodeEnv = %q", data)
+	}
+
+	os.Setenv(name, `secret"`)
+	_, err := DecodeEnv(name)
+	var ee *EnvError
+	if !errors.As(err, &ee) || ee.Name != name || !errors.Is(err, ErrInvalidData) {
+		t.Errorf("DecodeEnv(invalid) = %v", err)
+	}
+	if strings.Contains(err.Error(), "secret") {
+		t.Errorf("error %q includes the value", err)
+	}
+}
+
+func TestDecodeEnvNotSet(t *testing.T) {
+	const name = "JASE93_TEST_UNSET"
+	os.Unsetenv(name)
+
+	if _, err := DecodeEnv(name); !errors.Is(err, ErrEnvNotSet) || !strings.Contains(err.Error(), name) {
+		t.Errorf("DecodeEnv = %v", err)
+	}
+
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, ErrEnvNotSet) {
+			t.Errorf("MustDecodeEnv panicked with %v", err)
+		}
+	}()
+	MustDecodeEnv(name)
+}
@@ -0,0 +1,225 @@
+package jase93
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// CorruptInputError reports a character outside the alphabet of an Encoding. It matches ErrInvalidData.
+type CorruptInputError struct {
+	Offset int64 // The offset of the character in the encoded input
+	Char   byte  // The invalid character
+	Rune   rune  // The multi-byte UTF-8 character Char begins, if it could be decoded, or utf8.RuneError
+}
+
+// newCorruptInputError returns a CorruptInputError for the first character of src, at offset.
+func newCorruptInputError(offset int64, src []byte) *CorruptInputError {
+	e := &CorruptInputError{Offset: offset, Char: src[0], Rune: utf8.RuneError}
+	if r, size := utf8.DecodeRune(src); size > 1 {
+		e.Rune = r
+	}
+	return e
+}
+
+func (e *CorruptInputError) Error() string {
+	return fmt.Sprintf("jase93: invalid character %#02x (%s) at offset %d", e.Char, e.Description(), e.Offset)
+}
+
+// Description describes why Char is invalid, such as "control character" or "double quote".
+func (e *CorruptInputError) Description() string {
+	c := e.Char
+	switch {
+	case c == 0:
+		return "NUL, as in UTF-16 text"
+	case c < 0x20 || c == 0x7f:
+		return "control character"
+	case c == '"':
+		return "double quote"
+	case c == '\\':
+		return "backslash"
+	case c < utf8.RuneSelf:
+		return fmt.Sprintf("%q is not in the alphabet", c)
+	case c < 0xc0:
+		return "UTF-8 continuation byte"
+	case c < 0xf8:
+		if e.Rune == '\ufeff' {
+			return "UTF-8 byte order mark"
+		}
+		if e.Rune != utf8.RuneError {
+			return fmt.Sprintf("UTF-8 lead byte of %U %q", e.Rune, e.Rune)
+		}
+		return "UTF-8 lead byte"
+	case c >= 0xfe:
+		return "non-UTF-8 byte, as in UTF-16 text"
+	}
+	return "non-UTF-8 byte"
+}
+
+// Is reports whether target is ErrInvalidData.
+func (e *CorruptInputError) Is(target error) bool {
+	return target == ErrInvalidData
+}
+
+// HeaderError reports a stream header that was truncated or did not identify a known Encoding.
+// It matches ErrInvalidHeader and ErrInvalidData.
+type HeaderError struct {
+	Header string // The header characters that were read
+}
+
+func (e *HeaderError) Error() string {
+	return fmt.Sprintf("jase93: invalid header %q", e.Header)
+}
+
+// Is reports whether target is ErrInvalidHeader or ErrInvalidData.
+func (e *HeaderError) Is(target error) bool {
+	return target == ErrInvalidHeader || target == ErrInvalidData
+}
+
+// ChecksumError reports that decoded data did not match its checksum. It matches ErrChecksum and ErrInvalidData.
+type ChecksumError struct {
+	Want, Got uint32
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("jase93: checksum mismatch: want %#x, got %#x", e.Want, e.Got)
+}
+
+// Is reports whether target is ErrChecksum or ErrInvalidData.
+func (e *ChecksumError) Is(target error) bool {
+	return target == ErrChecksum || target == ErrInvalidData
+}
+
+// LengthError reports encoded input of the wrong length. It matches ErrInvalidData.
+type LengthError struct {
+	Want, Got int
+}
+
+func (e *LengthError) Error() string {
+	return fmt.Sprintf("jase93: invalid length %d, want %d", e.Got, e.Want)
+}
+
+// Is reports whether target is ErrInvalidData.
+func (e *LengthError) Is(target error) bool {
+	return target == ErrInvalidData
+}
+
+// ErrLimitExceeded indicates that encoded input exceeded a limit on its size. It is matched by LimitExceededError.
+var ErrLimitExceeded = errors.New("jase93: limit exceeded")
+
+// LimitExceededError reports encoded input exceeding a limit on its size, such as a line longer than MaxConnLineLen
+// read by a connection returned by WrapConn, or a sample for SetDetectEncoding larger than the memory limit of a Decoder
+// allows. It matches ErrLimitExceeded and ErrInvalidData.
+type LimitExceededError struct {
+	Limit int64 // The limit, in encoded characters
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("jase93: input exceeds limit of %d characters", e.Limit)
+}
+
+// Is reports whether target is ErrLimitExceeded or ErrInvalidData.
+func (e *LimitExceededError) Is(target error) bool {
+	return target == ErrLimitExceeded || target == ErrInvalidData
+}
+
+// ArmorError reports a malformed line of an armored block. It matches ErrInvalidArmor and ErrInvalidData.
+type ArmorError struct {
+	Line int // The 1-based line number, counting from the start of the input
+}
+
+func (e *ArmorError) Error() string {
+	return fmt.Sprintf("jase93: invalid armor at line %d", e.Line)
+}
+
+// Is reports whether target is ErrInvalidArmor or ErrInvalidData.
+func (e *ArmorError) Is(target error) bool {
+	return target == ErrInvalidArmor || target == ErrInvalidData
+}
+
+// LineChecksumError reports a line of an armored block whose checksum did not match, as written by
+// ArmorWithLineChecksums. It matches ErrChecksum and ErrInvalidData.
+type LineChecksumError struct {
+	Line int // The 1-based line number, counting from the start of the input
+}
+
+func (e *LineChecksumError) Error() string {
+	return fmt.Sprintf("jase93: checksum mismatch at line %d", e.Line)
+}
+
+// Is reports whether target is ErrChecksum or ErrInvalidData.
+func (e *LineChecksumError) Is(target error) bool {
+	return target == ErrChecksum || target == ErrInvalidData
+}
+
+// MACError reports a record of a stream written by a MACWriter that failed verification, because it was modified,
+// reordered, truncated, or authenticated with a different key. It matches ErrChecksum and ErrInvalidData.
+type MACError struct {
+	Record uint64 // The 0-based sequence number of the record
+}
+
+func (e *MACError) Error() string {
+	return fmt.Sprintf("jase93: authentication failed at record %d", e.Record)
+}
+
+// Is reports whether target is ErrChecksum or ErrInvalidData.
+func (e *MACError) Is(target error) bool {
+	return target == ErrChecksum || target == ErrInvalidData
+}
+
+// PaperError reports a paper backup that could not be read because lines were missing or mistyped, or because the
+// data did not match its digest. It matches ErrInvalidData, and ErrChecksum unless lines were only missing.
+type PaperError struct {
+	Missing []int // The numbers of missing lines
+	Bad     []int // The numbers of lines that did not match their checksums
+	Digest  bool  // Whether the data did not match its digest
+}
+
+func (e *PaperError) Error() string {
+	var problems []string
+	if len(e.Missing) > 0 {
+		problems = append(problems, "missing lines "+joinInts(e.Missing))
+	}
+	if len(e.Bad) > 0 {
+		problems = append(problems, "checksum mismatch on lines "+joinInts(e.Bad))
+	}
+	if e.Digest {
+		problems = append(problems, "digest mismatch")
+	}
+	return "jase93: invalid paper backup: " + strings.Join(problems, "; ")
+}
+
+// Is reports whether target is ErrInvalidData, or ErrChecksum if any checksum or the digest did not match.
+func (e *PaperError) Is(target error) bool {
+	return target == ErrInvalidData || target == ErrChecksum && (len(e.Bad) > 0 || e.Digest)
+}
+
+func joinInts(ns []int) string {
+	s := make([]string, len(ns))
+	for i, n := range ns {
+		s[i] = strconv.Itoa(n)
+	}
+	return strings.Join(s, ", ")
+}
+
+// ChunkError reports an invalid chunk of a chunked stream. It matches ErrInvalidData and Err.
+type ChunkError struct {
+	Seq int64 // The sequence number of the chunk
+	Err error // ErrChunkSequence, ErrChecksum, or the decoding error
+}
+
+func (e *ChunkError) Error() string {
+	return fmt.Sprintf("jase93: chunk %d: %v", e.Seq, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ChunkError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrInvalidData.
+func (e *ChunkError) Is(target error) bool {
+	return target == ErrInvalidData
+}
@@ -0,0 +1,56 @@
+package jase93
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestErrors(t *testing.T) {
+	_, err := Decode(nil, []byte("g#(z\"("))
+	var cie *CorruptInputError
+	if !errors.As(err, &cie) || cie.Offset != 4 || cie.Char != '"' {
+		t.Errorf("Decode = %v", err)
+	}
+
+	for _, tc := range []struct {
+		in, err string
+	}{
+		{"g#\n", `jase93: invalid character 0x0a (control character) at offset 2`},
+		{`g#"`, `jase93: invalid character 0x22 (double quote) at offset 2`},
+		{`g#\`, `jase93: invalid character 0x5c (backslash) at offset 2`},
+		{"g#\u201c", `jase93: invalid character 0xe2 (UTF-8 lead byte of U+201C '“') at offset 2`},
+		{"g#\xe2\x80", `jase93: invalid character 0xe2 (UTF-8 lead byte) at offset 2`},
+		{"g#\x80", `jase93: invalid character 0x80 (UTF-8 continuation byte) at offset 2`},
+		{"g#\xf8", `jase93: invalid character 0xf8 (non-UTF-8 byte) at offset 2`},
+		{"\ufeffg#", `jase93: invalid character 0xef (UTF-8 byte order mark) at offset 0`},
+		{"\xff\xfeg\x00", `jase93: invalid character 0xff (non-UTF-8 byte, as in UTF-16 text) at offset 0`},
+		{"\x00g\x00#", `jase93: invalid character 0x00 (NUL, as in UTF-16 text) at offset 0`},
+	} {
+		if _, err := Decode(nil, []byte(tc.in)); err == nil || err.Error() != tc.err {
+			t.Errorf("Decode(%q) = %v != %s", tc.in, err, tc.err)
+		}
+	}
+
+	_, err = StdEncoding.WithHeader().Decode(nil, []byte("z"))
+	var he *HeaderError
+	if !errors.As(err, &he) || he.Header != "z" || !errors.Is(err, ErrInvalidData) {
+		t.Errorf("Decode = %v", err)
+	}
+
+	for _, err := range []error{
+		&CorruptInputError{},
+		&HeaderError{},
+		&ChecksumError{},
+		&ArmorError{},
+		&MACError{},
+		&LimitExceededError{},
+	} {
+		if !errors.Is(err, ErrInvalidData) {
+			t.Errorf("%T does not match ErrInvalidData", err)
+		}
+	}
+
+	if !errors.Is(&ChecksumError{}, ErrChecksum) || !errors.Is(&ArmorError{}, ErrInvalidArmor) || !errors.Is(&LimitExceededError{}, ErrLimitExceeded) {
+		t.Error("errors do not match their sentinels")
+	}
+}
@@ -0,0 +1,50 @@
+// Package expvarmetrics publishes jase93 metrics with expvar.
+//
+// Importing this package registers the expvar HTTP handler at /debug/vars on http.DefaultServeMux, so it is kept
+// separate from package jase93.
+package expvarmetrics // import "github.com/jdknezek/jase93-go/expvarmetrics"
+
+import (
+	"expvar"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+// Metrics implements jase93.Metrics with an expvar.Map of counters:
+//
+//	encode_raw_bytes, encode_encoded_bytes
+//	decode_encoded_bytes, decode_raw_bytes
+//	errors_<kind>
+type Metrics struct {
+	m *expvar.Map
+}
+
+// Publish creates a Metrics published as the expvar variable name. Like expvar.Publish, it panics if name is already
+// registered.
+func Publish(name string) *Metrics {
+	return &Metrics{expvar.NewMap(name)}
+}
+
+// Map returns the underlying expvar.Map.
+func (m *Metrics) Map() *expvar.Map {
+	return m.m
+}
+
+// AddEncoded implements jase93.Metrics.
+func (m *Metrics) AddEncoded(raw, encoded int64) {
+	m.m.Add("encode_raw_bytes", raw)
+	m.m.Add("encode_encoded_bytes", encoded)
+}
+
+// AddDecoded implements jase93.Metrics.
+func (m *Metrics) AddDecoded(encoded, raw int64) {
+	m.m.Add("decode_encoded_bytes", encoded)
+	m.m.Add("decode_raw_bytes", raw)
+}
+
+// AddError implements jase93.Metrics.
+func (m *Metrics) AddError(kind string) {
+	m.m.Add("errors_"+kind, 1)
+}
+
+var _ jase93.Metrics = (*Metrics)(nil)
@@ -0,0 +1,31 @@
+package expvarmetrics
+
+import (
+	"testing"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+func TestMetrics(t *testing.T) {
+	m := Publish("jase93")
+	jase93.SetMetrics(m)
+	defer jase93.SetMetrics(nil)
+
+	enc := jase93.Encode(nil, []byte{0xff, 0xff})
+	if _, err := jase93.Decode(nil, enc); err != nil {
+		t.Fatal(err)
+	}
+	jase93.Decode(nil, []byte(`"`))
+
+	for name, want := range map[string]string{
+		"encode_raw_bytes":     "2",
+		"encode_encoded_bytes": "3",
+		"decode_encoded_bytes": "4",
+		"decode_raw_bytes":     "2",
+		"errors_corrupt_input": "1",
+	} {
+		if v := m.Map().Get(name); v == nil || v.String() != want {
+			t.Errorf("%s = %v != %s", name, v, want)
+		}
+	}
+}
@@ -0,0 +1,379 @@
+package jase93
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// Parity chunks protect groups of data chunks with a systematic Reed-Solomon erasure code over GF(2^8), using a
+// Cauchy matrix. Each chunk is treated as a shard: a flags byte, whose low bit is set for the final chunk, the 4-byte
+// big-endian length of its data, and the data, padded with zeros to the chunk size. Parity shard i of a group of n
+// data shards D_j is the sum over j of D_j / ((k + i) xor j), where k is the maximum group size, so any n of the
+// group's data and parity shards determine its data.
+
+// shardHeaderLen is the length of the header of a shard, preceding its data.
+const shardHeaderLen = 5
+
+// SetParity sets the ChunkWriter to follow each group of k data chunks, and the final group of up to k, with m parity
+// chunks, so the stream survives the loss or corruption of any m chunks of each group when relayed through transports
+// that truncate or drop lines. k+m must be at most 256. SetParity must be called before the first Write, and the
+// ChunkReader must be configured with the same k and m.
+//
+// Parity chunks follow the data chunks of their group in sequence, and are marked by their 1-based index within the
+// group and the number of data chunks it has:
+//
+//	{"seq":4,"crc":"1f3c47e2","data":"<encoded parity>","parity":1,"shards":4}
+//
+// Each adds about 1/k to the size of the stream.
+func (c *ChunkWriter) SetParity(k, m int) {
+	if k < 1 || m < 1 || k+m > 256 {
+		panic("jase93: parity group must have between 1 and 256 chunks")
+	}
+	c.k, c.m = k, m
+}
+
+// addShard adds a data chunk to the current parity group, writing the group's parity chunks if it is complete.
+func (c *ChunkWriter) addShard(data []byte, last bool) error {
+	c.shards = append(c.shards, appendShard(nil, data, last, c.size))
+	if len(c.shards) < c.k && !last {
+		return nil
+	}
+
+	n := len(c.shards)
+	parity := make([]byte, c.size+shardHeaderLen)
+	for i := 0; i < c.m; i++ {
+		for j := range parity {
+			parity[j] = 0
+		}
+		for j, shard := range c.shards {
+			gfMulAdd(parity, shard, gfInv(byte(c.k+i)^byte(j)))
+		}
+
+		err := c.enc.Encode(chunk{
+			Seq:    c.seq,
+			CRC:    fmt.Sprintf("%08x", crc32.ChecksumIEEE(parity)),
+			Data:   string(Encode(nil, parity)),
+			Parity: i + 1,
+			Shards: n,
+		})
+		c.seq++
+		if err != nil {
+			return err
+		}
+	}
+
+	c.shards = c.shards[:0]
+	return nil
+}
+
+// appendShard appends the shard holding the data of a chunk to dst, padded to size bytes of data.
+func appendShard(dst, data []byte, last bool, size int) []byte {
+	var flags byte
+	if last {
+		flags = 1
+	}
+	dst = append(dst, flags, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(dst[len(dst)-4:], uint32(len(data)))
+	dst = append(dst, data...)
+	for i := len(data); i < size; i++ {
+		dst = append(dst, 0)
+	}
+	return dst
+}
+
+// parseShard returns the data of a chunk held by shard.
+func parseShard(shard []byte) (data []byte, last bool, ok bool) {
+	if len(shard) < shardHeaderLen {
+		return nil, false, false
+	}
+	n := binary.BigEndian.Uint32(shard[1:])
+	if uint64(n) > uint64(len(shard)-shardHeaderLen) {
+		return nil, false, false
+	}
+	return shard[shardHeaderLen : shardHeaderLen+int(n)], shard[0]&1 != 0, true
+}
+
+// SetParity sets the ChunkReader to read streams written with ChunkWriter.SetParity(k, m), reconstructing any chunks
+// of a group that are missing or corrupt from its parity chunks. In this mode, input is read line by line, lines that
+// are not valid chunks are treated as lost, and chunks may arrive in any order within their group. Reading a group
+// fails with a *ChunkError naming its first unrecoverable chunk if more than m of its chunks are lost. SetParity must
+// be called before the first Read, and replaces any window set by SetWindow.
+func (c *ChunkReader) SetParity(k, m int) {
+	if k < 1 || m < 1 || k+m > 256 {
+		panic("jase93: parity group must have between 1 and 256 chunks")
+	}
+	c.k, c.m = k, m
+	c.lines = bufio.NewReader(c.r)
+	c.resetGroup()
+}
+
+// resetGroup starts the next parity group.
+func (c *ChunkReader) resetGroup() {
+	c.groupData = make([][]byte, c.k)
+	c.groupParity = make([][]byte, c.m)
+	c.groupLen = -1
+	c.groupNext = 0
+}
+
+// readParity returns the data of the next chunk in sequence, reconstructing it if necessary.
+func (c *ChunkReader) readParity() ([]byte, error) {
+	for {
+		base := c.group * int64(c.k+c.m)
+		if ch := c.pending; ch != nil && ch.Seq/int64(c.k+c.m) == c.group {
+			c.pending = nil
+			c.addToGroup(ch)
+		}
+
+		if j := c.groupNext; j < c.k && c.groupData[j] != nil {
+			data, last, ok := parseShard(c.groupData[j])
+			if !ok {
+				return nil, &ChunkError{Seq: base + int64(j), Err: ErrChecksum}
+			}
+
+			c.groupNext++
+			c.done = last
+			if last || c.groupNext == c.groupLen || c.groupNext == c.k {
+				c.group++
+				c.resetGroup()
+			}
+			return data, nil
+		}
+		if c.pending != nil {
+			// A later group has begun, so this one will get no more chunks
+			if err := c.reconstruct(true); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		ch, err := c.readLine()
+		if err == io.EOF {
+			if c.groupLen < 0 && c.groupNext == 0 && !c.hasShards() {
+				return nil, io.ErrUnexpectedEOF
+			}
+			if err := c.reconstruct(false); err != nil {
+				return nil, err
+			}
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if ch == nil {
+			// Lost
+			continue
+		}
+
+		if g := ch.Seq / int64(c.k+c.m); g > c.group {
+			c.pending = ch
+		} else if g == c.group {
+			c.addToGroup(ch)
+		}
+	}
+}
+
+// readLine reads and verifies the next line, returning a nil chunk if it is not a valid chunk.
+func (c *ChunkReader) readLine() (*chunk, error) {
+	line, err := c.lines.ReadBytes('\n')
+	if err == io.EOF && len(line) > 0 {
+		err = nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 {
+		return nil, nil
+	}
+	ch := new(chunk)
+	if json.Unmarshal(line, ch) != nil || ch.Seq < 0 || ch.Parity < 0 || ch.Parity > c.m {
+		return nil, nil
+	}
+	data, err := verifyChunk(ch)
+	if err != nil {
+		return nil, nil
+	}
+	ch.Data = string(data)
+	return ch, nil
+}
+
+// addToGroup adds a verified chunk of the current group, whose data has been decoded.
+func (c *ChunkReader) addToGroup(ch *chunk) {
+	pos := int(ch.Seq % int64(c.k+c.m))
+	if ch.Parity > 0 {
+		if c.groupParity[ch.Parity-1] == nil {
+			c.groupParity[ch.Parity-1] = []byte(ch.Data)
+			if ch.Shards > 0 && ch.Shards <= c.k {
+				c.groupLen = ch.Shards
+			}
+		}
+		return
+	}
+
+	if pos < c.k && c.groupData[pos] == nil {
+		c.groupData[pos] = appendShard(nil, []byte(ch.Data), ch.Last, 0)
+		if ch.Last {
+			c.groupLen = pos + 1
+		}
+	}
+}
+
+func (c *ChunkReader) hasShards() bool {
+	for _, s := range c.groupData {
+		if s != nil {
+			return true
+		}
+	}
+	for _, s := range c.groupParity {
+		if s != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// reconstruct reconstructs the missing data chunks of the current group. full reports whether the group is known to
+// be followed by another, and so to have k data chunks unless its parity chunks say otherwise.
+func (c *ChunkReader) reconstruct(full bool) error {
+	base := c.group * int64(c.k+c.m)
+	n := c.groupLen
+	if n < 0 {
+		if !full {
+			return &ChunkError{Seq: base + int64(c.groupNext), Err: ErrChunkSequence}
+		}
+		n = c.k
+	}
+
+	// Choose n shards: the data shards present, then parity shards in place of those missing
+	var missing []int
+	rows := make([][]byte, 0, n) // coefficients of each chosen shard in terms of the data shards
+	shards := make([][]byte, 0, n)
+	shardLen := 0
+	for _, p := range c.groupParity {
+		if p != nil {
+			shardLen = len(p)
+		}
+	}
+	for j := 0; j < n; j++ {
+		if c.groupData[j] == nil {
+			missing = append(missing, j)
+			continue
+		}
+		row := make([]byte, n)
+		row[j] = 1
+		rows = append(rows, row)
+		shards = append(shards, c.groupData[j])
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	for i, p := range c.groupParity {
+		if p == nil || len(rows) == n {
+			continue
+		}
+		row := make([]byte, n)
+		for j := range row {
+			row[j] = gfInv(byte(c.k+i) ^ byte(j))
+		}
+		rows = append(rows, row)
+		shards = append(shards, p)
+	}
+	if len(rows) < n {
+		return &ChunkError{Seq: base + int64(missing[0]), Err: ErrChunkSequence}
+	}
+
+	inv, ok := gfInvert(rows)
+	if !ok {
+		return &ChunkError{Seq: base + int64(missing[0]), Err: ErrChecksum}
+	}
+	for _, j := range missing {
+		shard := make([]byte, shardLen)
+		for r, s := range shards {
+			gfMulAdd(shard, s, inv[j][r])
+		}
+		c.groupData[j] = shard
+	}
+	c.groupLen = n
+	return nil
+}
+
+// GF(2^8) arithmetic with the polynomial x^8 + x^4 + x^3 + x^2 + 1.
+var gfExp, gfLog = func() (exp [510]byte, log [256]byte) {
+	x := 1
+	for i := 0; i < 255; i++ {
+		exp[i], exp[i+255] = byte(x), byte(x)
+		log[x] = byte(i)
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11d
+		}
+	}
+	return
+}()
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+func gfInv(a byte) byte {
+	return gfExp[255-int(gfLog[a])]
+}
+
+// gfMulAdd adds c times src to dst, treating src as zero-padded to the length of dst.
+func gfMulAdd(dst, src []byte, c byte) {
+	if c == 0 {
+		return
+	}
+	if len(src) > len(dst) {
+		src = src[:len(dst)]
+	}
+	for i, s := range src {
+		dst[i] ^= gfMul(s, c)
+	}
+}
+
+// gfInvert returns the inverse of the square matrix m, reporting false if it is singular. m is modified.
+func gfInvert(m [][]byte) ([][]byte, bool) {
+	n := len(m)
+	inv := make([][]byte, n)
+	for i := range inv {
+		inv[i] = make([]byte, n)
+		inv[i][i] = 1
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for pivot < n && m[pivot][col] == 0 {
+			pivot++
+		}
+		if pivot == n {
+			return nil, false
+		}
+		m[col], m[pivot] = m[pivot], m[col]
+		inv[col], inv[pivot] = inv[pivot], inv[col]
+
+		scale := gfInv(m[col][col])
+		for j := 0; j < n; j++ {
+			m[col][j] = gfMul(m[col][j], scale)
+			inv[col][j] = gfMul(inv[col][j], scale)
+		}
+		for row := 0; row < n; row++ {
+			if row == col || m[row][col] == 0 {
+				continue
+			}
+			f := m[row][col]
+			for j := 0; j < n; j++ {
+				m[row][j] ^= gfMul(f, m[col][j])
+				inv[row][j] ^= gfMul(f, inv[col][j])
+			}
+		}
+	}
+	return inv, true
+}
@@ -0,0 +1,142 @@
+package jase93
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func writeParityChunks(t *testing.T, src []byte, size, k, m int) []string {
+	var buf bytes.Buffer
+	w := NewChunkWriter(&buf, size)
+	w.SetParity(k, m)
+	if _, err := w.Write(src); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// Keep the final newline, so lines can be reordered
+	lines := strings.SplitAfter(buf.String(), "\n")
+	return lines[:len(lines)-1]
+}
+
+func readParityChunks(lines []string, k, m int) ([]byte, error) {
+	r := NewChunkReader(strings.NewReader(strings.Join(lines, "")))
+	r.SetParity(k, m)
+	return ioutil.ReadAll(r)
+}
+
+func TestParity(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	for _, n := range []int{0, 1, 99, 100, 101, 1000} {
+		src := make([]byte, n)
+		rng.Read(src)
+
+		for _, km := range [][2]int{{1, 1}, {4, 2}, {10, 3}} {
+			k, m := km[0], km[1]
+			lines := writeParityChunks(t, src, 100, k, m)
+			data := n/100 + 1
+			if want := data + (data+k-1)/k*m; len(lines) != want {
+				t.Errorf("%d bytes, %d+%d: %d chunks, want %d", n, k, m, len(lines), want)
+			}
+
+			for trial := 0; trial < 10; trial++ {
+				// Lose, mangle, and reorder up to m chunks of each group
+				var got []string
+				for g := 0; g < len(lines); g += k + m {
+					end := g + k + m
+					if end > len(lines) {
+						end = len(lines)
+					}
+					group := append([]string(nil), lines[g:end]...)
+					rng.Shuffle(len(group), func(i, j int) { group[i], group[j] = group[j], group[i] })
+					for i := 0; i < m && trial > 0; i++ {
+						switch rng.Intn(3) {
+						case 0:
+							group[i] = ""
+						case 1:
+							group[i] = group[i][:rng.Intn(len(group[i]))] + "\n"
+						case 2:
+							b := []byte(group[i])
+							data := strings.Index(group[i], `"data":"`) + len(`"data":"`)
+							if end := strings.IndexByte(group[i][data:], '"'); end > 0 {
+								b[data+rng.Intn(end)] ^= 1
+							}
+							group[i] = string(b)
+						}
+					}
+					got = append(got, group...)
+				}
+
+				dec, err := readParityChunks(got, k, m)
+				if err != nil {
+					t.Fatalf("%d bytes, %d+%d, trial %d: %v", n, k, m, trial, err)
+				}
+				if !bytes.Equal(dec, src) {
+					t.Errorf("%d bytes, %d+%d, trial %d: reassembled data differs", n, k, m, trial)
+				}
+			}
+		}
+	}
+}
+
+func TestParityErrors(t *testing.T) {
+	src := make([]byte, 1000)
+	rand.New(rand.NewSource(1)).Read(src)
+	lines := writeParityChunks(t, src, 100, 4, 2)
+
+	var lost []string
+	lost = append(lost, lines[:6]...)
+	lost = append(lost, lines[9:]...)
+	_, err := readParityChunks(lost, 4, 2)
+	var cerr *ChunkError
+	if !errors.As(err, &cerr) || cerr.Seq != 6 || !errors.Is(err, ErrChunkSequence) {
+		t.Errorf("3 chunks lost: got %v, want chunk 6 out of sequence", err)
+	}
+
+	// Truncated streams are detected however many chunks are lost
+	for _, n := range []int{1, 3, 12, 13} {
+		_, err := readParityChunks(lines[:n], 4, 2)
+		if !errors.Is(err, ErrChunkSequence) && err != io.ErrUnexpectedEOF {
+			t.Errorf("%d chunks: got %v, want truncation", n, err)
+		}
+	}
+	if _, err := readParityChunks(nil, 4, 2); err != io.ErrUnexpectedEOF {
+		t.Errorf("no chunks: got %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+
+	// Up to m chunks of the final group may be lost, including the last data chunk
+	last := append([]string(nil), lines[:len(lines)-4]...)
+	last = append(last, lines[len(lines)-2:]...)
+	if dec, err := readParityChunks(last, 4, 2); err != nil || !bytes.Equal(dec, src) {
+		t.Errorf("final chunks lost: got %v", err)
+	}
+}
+
+func TestGFInvert(t *testing.T) {
+	m := [][]byte{{1, 2, 3}, {4, 5, 6}, {7, 8, 10}}
+	inv, ok := gfInvert([][]byte{{1, 2, 3}, {4, 5, 6}, {7, 8, 10}})
+	if !ok {
+		t.Fatal("singular")
+	}
+	for i := range m {
+		for j := range m {
+			var sum byte
+			for k := range m {
+				sum ^= gfMul(m[i][k], inv[k][j])
+			}
+			if i == j && sum != 1 || i != j && sum != 0 {
+				t.Errorf("(M M^-1)[%d][%d] = %d", i, j, sum)
+			}
+		}
+	}
+
+	if _, ok := gfInvert([][]byte{{1, 2}, {2, 4}}); ok {
+		t.Error("singular matrix inverted")
+	}
+}
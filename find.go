@@ -0,0 +1,175 @@
+package jase93
+
+import "math"
+
+// DefaultMinRunLen is the minimum length of the runs FindEncodedRuns reports if no minimum is given.
+const DefaultMinRunLen = 24
+
+// Run locates a run of encoded characters within text.
+type Run struct {
+	Offset int // The offset of the run in the text
+	Len    int // The length of the run
+}
+
+// minRunEntropy is the minimum ratio of the entropy of a run to the maximum possible for its length. Encoded random
+// data scores 0.85 or more, while prose and hex score less than 0.8.
+const minRunEntropy = 0.83
+
+// shortToken is the length below which a space-separated token is word-like. Spaces occur in encoded random data
+// about once per 93 characters, so its tokens are rarely short.
+const shortToken = 8
+
+// FindEncodedRuns returns the runs of text that are likely to be data encoded with StdEncoding, such as blobs in log
+// files or HTML, in order of their offsets. Runs shorter than minLen, or DefaultMinRunLen if minLen is not positive,
+// are ignored.
+//
+// The search is heuristic. Candidate runs are bounded by characters outside the alphabet, then split where they contain
+// consecutive short space-separated tokens, as in prose, and trimmed of short tokens at either end and any leading key,
+// as in "key=value". Candidates are reported if the entropy of their characters is close to the maximum for their
+// length. Encoded data that is not random, such as encoded text, may not be found.
+func FindEncodedRuns(text []byte, minLen int) []Run {
+	if minLen <= 0 {
+		minLen = DefaultMinRunLen
+	}
+
+	var runs []Run
+	for i := 0; i < len(text); {
+		if StdEncoding.decode[text[i]] == -1 {
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(text) && StdEncoding.decode[text[j]] != -1 {
+			j++
+		}
+		runs = appendSegments(runs, text, i, j, minLen)
+		i = j
+	}
+	return runs
+}
+
+// appendSegments appends the likely encoded segments of the candidate run text[start:end] to runs.
+func appendSegments(runs []Run, text []byte, start, end, minLen int) []Run {
+	// Tokens are separated by single spaces, so a run of spaces yields empty, short tokens
+	segStart, tokStart := start, start
+	prevShort := false
+	for i := start; i <= end; i++ {
+		if i < end && text[i] != ' ' {
+			continue
+		}
+
+		short := i-tokStart < shortToken
+		if short && prevShort {
+			runs = appendRun(runs, text, segStart, tokStart-1, minLen)
+			segStart = tokStart
+		}
+		prevShort = short
+		tokStart = i + 1
+	}
+	return appendRun(runs, text, segStart, end, minLen)
+}
+
+// appendRun trims short tokens from either end of text[start:end] and appends it to runs if it is likely encoded.
+func appendRun(runs []Run, text []byte, start, end, minLen int) []Run {
+	for start < end {
+		i := start
+		for i < end && text[i] != ' ' {
+			i++
+		}
+		if i-start >= shortToken {
+			break
+		}
+		start = i + 1
+	}
+	if start < end {
+		start += keyPrefixLen(text[start:end])
+	}
+	for start < end {
+		i := end
+		for i > start && text[i-1] != ' ' {
+			i--
+		}
+		if end-i >= shortToken {
+			break
+		}
+		end = i - 1
+	}
+
+	if end-start < minLen || !highEntropy(text[start:end]) {
+		return runs
+	}
+	return append(runs, Run{Offset: start, Len: end - start})
+}
+
+// keyPrefixLen returns the length of a leading key ending in '=' or ':' in s, as in "key=value", or 0 if there is none.
+func keyPrefixLen(s []byte) int {
+	for i, c := range s {
+		switch {
+		case c == '=' || c == ':':
+			if i == 0 || i > 32 {
+				return 0
+			}
+			return i + 1
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_', c == '-', c == '.':
+		default:
+			return 0
+		}
+	}
+	return 0
+}
+
+// highEntropy reports whether the entropy of the characters of s is close to the maximum for its length.
+func highEntropy(s []byte) bool {
+	var counts [256]int
+	for _, c := range s {
+		counts[c]++
+	}
+
+	n := float64(len(s))
+	h := 0.0
+	for _, c := range counts {
+		if c > 0 {
+			p := float64(c) / n
+			h -= p * math.Log2(p)
+		}
+	}
+	return h >= minRunEntropy*math.Log2(math.Min(n, Base))
+}
+
+// IsLikelyJase93 reports whether s is plausibly data encoded with StdEncoding, rather than base64, hex, or plain text,
+// so fields of unknown encoding can be routed to the right decoder. Like FindEncodedRuns, it is heuristic, and
+// intended for encoded random or compressed data such as keys, hashes, and ciphertext.
+//
+// It requires that s is at least 8 characters in the alphabet, is not entirely hex or base64 characters, has few
+// spaces, and has characters of high entropy.
+func IsLikelyJase93(s string) bool {
+	if len(s) < 8 {
+		return false
+	}
+
+	hex, base64, spaces := true, true, 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if StdEncoding.decode[c] == -1 {
+			return false
+		}
+
+		switch {
+		case c >= '0' && c <= '9', c >= 'a' && c <= 'f', c >= 'A' && c <= 'F':
+		case c >= 'g' && c <= 'z', c >= 'G' && c <= 'Z', c == '+', c == '/', c == '-', c == '_', c == '=':
+			hex = false
+		case c == ' ':
+			spaces++
+			hex, base64 = false, false
+		default:
+			hex, base64 = false, false
+		}
+	}
+
+	// Encoded random data has a space about once per 93 characters
+	if hex || base64 || spaces > 1+len(s)/20 {
+		return false
+	}
+	return highEntropy([]byte(s))
+}
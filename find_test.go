@@ -0,0 +1,87 @@
+package jase93
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestFindEncodedRuns(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var blobs []string
+	for _, n := range []int{20, 100, 1000} {
+		raw := make([]byte, n)
+		rng.Read(raw)
+		blobs = append(blobs, string(Encode(nil, raw)))
+	}
+
+	text := "2024-01-01 INFO request id=42 body=" + blobs[0] + "\n" +
+		`<div data-blob="` + blobs[1] + `">Man is distinguished, not only by his reason</div>` + "\n" +
+		`{"key":"` + blobs[2] + `","hex":"4d616e2069732064697374696e677569736865642c206e6f74"}` + "\n"
+
+	runs := FindEncodedRuns([]byte(text), 0)
+	if len(runs) != len(blobs) {
+		t.Fatalf("FindEncodedRuns = %v, want %d runs", runs, len(blobs))
+	}
+	for i, run := range runs {
+		got := text[run.Offset : run.Offset+run.Len]
+		// Leading or trailing short tokens of a blob may be trimmed
+		if !strings.Contains(blobs[i], got) || len(got) < len(blobs[i])*3/4 {
+			t.Errorf("run %d = %q, want %q", i, got, blobs[i])
+		}
+	}
+}
+
+func TestFindEncodedRunsMinLen(t *testing.T) {
+	raw := make([]byte, 100)
+	rand.New(rand.NewSource(2)).Read(raw)
+	blob := strings.Replace(string(Encode(nil, raw)), " ", "!", -1)
+
+	if runs := FindEncodedRuns([]byte(`"`+blob+`"`), len(blob)+1); len(runs) != 0 {
+		t.Errorf("FindEncodedRuns(minLen %d) = %v", len(blob)+1, runs)
+	}
+	if runs := FindEncodedRuns([]byte(`"`+blob+`"`), len(blob)); len(runs) != 1 || runs[0] != (Run{1, len(blob)}) {
+		t.Errorf("FindEncodedRuns(minLen %d) = %v", len(blob), runs)
+	}
+}
+
+func TestFindEncodedRunsProse(t *testing.T) {
+	prose := "Man is distinguished, not only by his reason, but by this singular passion from other animals, which is a " +
+		"lust of the mind, that by a perseverance of delight in the continued and indefatigable generation of " +
+		"knowledge, exceeds the short vehemence of any carnal pleasure. https://example.com/a/path/to/a/resource"
+	if runs := FindEncodedRuns([]byte(prose), 0); len(runs) != 0 {
+		for _, r := range runs {
+			t.Errorf("FindEncodedRuns(prose) found %q", prose[r.Offset:r.Offset+r.Len])
+		}
+	}
+}
+
+func TestIsLikelyJase93(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	misses := 0
+	for i := 0; i < 100; i++ {
+		raw := make([]byte, 16+rng.Intn(256))
+		rng.Read(raw)
+		if s := string(Encode(nil, raw)); !IsLikelyJase93(s) {
+			misses++
+		}
+	}
+	if misses > 2 {
+		t.Errorf("IsLikelyJase93 rejected %d of 100 encoded strings", misses)
+	}
+
+	for _, s := range []string{
+		"",
+		"short",
+		"4d616e2069732064697374696e677569736865642c206e6f74",
+		"TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24=",
+		"TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24",
+		"Man is distinguished, not only by his reason, but by this singular passion",
+		"https://example.com/a/path/to/a/resource?query=value&other=1",
+		`contains "quotes"`,
+	} {
+		if IsLikelyJase93(s) {
+			t.Errorf("IsLikelyJase93(%q) = true", s)
+		}
+	}
+}
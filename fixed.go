@@ -0,0 +1,52 @@
+package jase93
+
+// Encoded lengths of the fixed-length helpers.
+const (
+	Encoded16Len = 20 // The length of Encode16 output
+	Encoded32Len = 40 // The length of Encode32 output
+)
+
+// fixedEncoding always packs 13 bits per word, so the encoded length depends only on the raw length.
+var fixedEncoding = StdEncoding.WithPacking(SimplePacking)
+
+// Encode16 encodes 16 bytes, such as a UUID or MD5 digest, into exactly Encoded16Len characters.
+// The encoding uses SimplePacking, so it differs from that of Encode.
+func Encode16(src [16]byte) string {
+	return string(fixedEncoding.Encode(make([]byte, 0, Encoded16Len), src[:]))
+}
+
+// Decode16 decodes the output of Encode16. It rejects any other encoding of the same bytes, so the encoded form of a
+// value is unique.
+func Decode16(s string) (dst [16]byte, err error) {
+	err = decodeFixed(dst[:], s, Encoded16Len)
+	return
+}
+
+// Encode32 encodes 32 bytes, such as a SHA-256 digest, into exactly Encoded32Len characters.
+// The encoding uses SimplePacking, so it differs from that of Encode.
+func Encode32(src [32]byte) string {
+	return string(fixedEncoding.Encode(make([]byte, 0, Encoded32Len), src[:]))
+}
+
+// Decode32 decodes the output of Encode32. It rejects any other encoding of the same bytes, so the encoded form of a
+// value is unique.
+func Decode32(s string) (dst [32]byte, err error) {
+	err = decodeFixed(dst[:], s, Encoded32Len)
+	return
+}
+
+// decodeFixed decodes s, which must be the canonical encoding of len(dst) bytes in n characters, into dst.
+func decodeFixed(dst []byte, s string, n int) error {
+	if len(s) != n {
+		return &LengthError{Want: n, Got: len(s)}
+	}
+
+	out, err := fixedEncoding.Decode(dst[:0], []byte(s))
+	if err != nil {
+		return err
+	}
+	if len(out) != len(dst) || string(fixedEncoding.Encode(make([]byte, 0, n), out)) != s {
+		return ErrInvalidData
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package jase93
+
+import (
+	"errors"
+	"testing"
+	"testing/quick"
+)
+
+func TestFixed16(t *testing.T) {
+	var zero, ones [16]byte
+	for i := range ones {
+		ones[i] = 0xff
+	}
+
+	if err := quick.Check(func(src [16]byte) bool {
+		for _, src := range [][16]byte{src, zero, ones} {
+			s := Encode16(src)
+			if len(s) != Encoded16Len {
+				t.Errorf("Encode16(%x) = %q", src, s)
+				return false
+			}
+			dec, err := Decode16(s)
+			if err != nil || dec != src {
+				t.Errorf("Decode16(%q) = %x, %v", s, dec, err)
+				return false
+			}
+		}
+		return true
+	}, nil); err != nil {
+		t.Error(err)
+	}
+
+	s := Encode16(zero)
+	for _, in := range []string{s[1:], s + " ", s[:19] + "~", s[:19] + `"`} {
+		if _, err := Decode16(in); !errors.Is(err, ErrInvalidData) {
+			t.Errorf("Decode16(%q) = %v", in, err)
+		}
+	}
+}
+
+func TestFixed32(t *testing.T) {
+	if err := quick.Check(func(src [32]byte) bool {
+		s := Encode32(src)
+		if len(s) != Encoded32Len {
+			t.Errorf("Encode32(%x) = %q", src, s)
+			return false
+		}
+		dec, err := Decode32(s)
+		if err != nil || dec != src {
+			t.Errorf("Decode32(%q) = %x, %v", s, dec, err)
+			return false
+		}
+		return true
+	}, nil); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := Decode32(Encode16([16]byte{})); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("Decode32(Encode16) = %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package jase93
+
+import "flag"
+
+// BytesFlag is a flag.Value, and a pflag.Value of github.com/spf13/pflag, that parses a jase93 string with
+// StdEncoding into a []byte. Invalid arguments are rejected when the flags are parsed.
+type BytesFlag struct {
+	p *[]byte
+}
+
+// NewBytesFlag returns a BytesFlag that sets *p, after setting it to value.
+func NewBytesFlag(p *[]byte, value []byte) *BytesFlag {
+	*p = value
+	return &BytesFlag{p}
+}
+
+// BytesVar defines a flag of fs with the specified name, default value, and usage that parses a jase93 string into
+// *p.
+func BytesVar(fs *flag.FlagSet, p *[]byte, name string, value []byte, usage string) {
+	fs.Var(NewBytesFlag(p, value), name, usage)
+}
+
+// String returns the flag's value encoded with StdEncoding.
+func (f *BytesFlag) String() string {
+	if f == nil || f.p == nil {
+		return ""
+	}
+	return string(Encode(nil, *f.p))
+}
+
+// Set decodes s with StdEncoding and sets the flag's value.
+func (f *BytesFlag) Set(s string) error {
+	b, err := Decode(nil, []byte(s))
+	if err != nil {
+		return err
+	}
+	*f.p = b
+	return nil
+}
+
+// Type implements pflag.Value, returning "jase93".
+func (f *BytesFlag) Type() string {
+	return "jase93"
+}
+
+// Get implements flag.Getter, returning the flag's []byte value.
+func (f *BytesFlag) Get() interface{} {
+	return *f.p
+}
@@ -0,0 +1,50 @@
+package jase93
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io/ioutil"
+	"testing"
+)
+
+func TestBytesVar(t *testing.T) {
+	key := []byte("\x00secret key\xff")
+	var got []byte
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	BytesVar(fs, &got, "key", []byte("default"), "the key")
+
+	if string(got) != "default" || fs.Lookup("key").DefValue != string(Encode(nil, []byte("default"))) {
+		t.Errorf("default = %q, DefValue = %q", got, fs.Lookup("key").DefValue)
+	}
+
+	if err := fs.Parse([]string{"-key", string(Encode(nil, key))}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, key) {
+		t.Errorf("-key = %q, want %q", got, key)
+	}
+	if v := fs.Lookup("key").Value.(flag.Getter).Get(); !bytes.Equal(v.([]byte), key) {
+		t.Errorf("Get = %q", v)
+	}
+
+	if err := fs.Parse([]string{"-key", `"`}); err == nil {
+		t.Error("Parse(invalid) succeeded")
+	}
+}
+
+func TestBytesFlag(t *testing.T) {
+	var p []byte
+	f := NewBytesFlag(&p, nil)
+	if f.Type() != "jase93" || f.String() != "" || (*BytesFlag)(nil).String() != "" {
+		t.Errorf("Type = %q, String = %q", f.Type(), f.String())
+	}
+	if err := f.Set("/#"); err != nil || f.String() != "/#" {
+		t.Errorf("Set = %v, String = %q", err, f.String())
+	}
+	if err := f.Set(`\`); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("Set(backslash) = %v", err)
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/jdknezek/jase93-go
 
-go 1.12
+go 1.23
@@ -0,0 +1,37 @@
+package jase93
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrNotGraphQLString indicates that a GraphQL input value for Bytes was not a string.
+var ErrNotGraphQLString = errors.New("jase93: GraphQL value is not a string")
+
+// MarshalGQL implements graphql.Marshaler of github.com/99designs/gqlgen, writing b as a quoted jase93 string. Map a
+// schema scalar to Bytes to expose binary fields more densely than Base64:
+//
+//	scalar Jase93Bytes
+//
+//	# gqlgen.yml
+//	models:
+//	  Jase93Bytes:
+//	    model: github.com/jdknezek/jase93-go.Bytes
+//
+// The encoding never needs escaping in a JSON string, so the result is written without further escaping.
+func (b Bytes) MarshalGQL(w io.Writer) {
+	buf := make([]byte, 0, MaxEncodedLen(len(b))+2)
+	buf = append(buf, '"')
+	buf = Encode(buf, b)
+	buf = append(buf, '"')
+	w.Write(buf)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler of github.com/99designs/gqlgen, decoding the string v.
+func (b *Bytes) UnmarshalGQL(v interface{}) error {
+	s, ok := v.(string)
+	if !ok {
+		return ErrNotGraphQLString
+	}
+	return b.UnmarshalText([]byte(s))
+}
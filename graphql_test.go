@@ -0,0 +1,35 @@
+package jase93
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestBytesGraphQL(t *testing.T) {
+	in := Bytes("\x00\x01<binary>&\xfe\xff")
+
+	var buf bytes.Buffer
+	in.MarshalGQL(&buf)
+
+	var s string
+	if err := json.Unmarshal(buf.Bytes(), &s); err != nil {
+		t.Fatalf("MarshalGQL wrote invalid JSON %s: %v", buf.Bytes(), err)
+	}
+
+	var out Bytes
+	if err := out.UnmarshalGQL(s); err != nil || !bytes.Equal(out, in) {
+		t.Errorf("UnmarshalGQL(%q) = %q, %v", s, out, err)
+	}
+}
+
+func TestBytesUnmarshalGQLInvalid(t *testing.T) {
+	var out Bytes
+	if err := out.UnmarshalGQL(1); err != ErrNotGraphQLString {
+		t.Errorf("UnmarshalGQL(1) = %v", err)
+	}
+	if err := out.UnmarshalGQL(`"`); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("UnmarshalGQL(quote) = %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package jase93
+
+import "errors"
+
+// HeaderLen is the number of characters in a stream header.
+const HeaderLen = 2
+
+// ErrInvalidHeader indicates that a stream header was missing or did not identify a known Encoding.
+var ErrInvalidHeader = errors.New("jase93: invalid header")
+
+// headerPackings maps each Packing to its header character.
+const headerPackings = "ab"
+
+// headerEncodings are the alphabet variants that can be identified by a stream header, each with its header character.
+var headerEncodings = []*Encoding{
+	StdEncoding.withID('a'),
+	HumanEncoding,
+	SpaceFreeEncoding,
+	HTTPHeaderEncoding,
+	CookieEncoding,
+	URLPathEncoding,
+	URLQueryEncoding,
+	FilenameEncoding,
+}
+
+func (enc Encoding) withID(id byte) *Encoding {
+	enc.id = id
+	return &enc
+}
+
+// WithHeader creates a new Encoding identical to enc except that encoded streams begin with a HeaderLen-character
+// header identifying the alphabet variant and packing mode. When decoding, the header is consumed and selects the
+// Encoding for the remainder of the stream, so any Encoding with a header decodes any stream with a header.
+//
+// WithHeader panics if enc is not a predefined alphabet variant.
+func (enc Encoding) WithHeader() *Encoding {
+	if enc.id == 0 {
+		panic("jase93: header requires a predefined encoding")
+	}
+	enc.header = true
+	return &enc
+}
+
+// appendHeader appends the header identifying enc to dst.
+func (enc *Encoding) appendHeader(dst []byte) []byte {
+	return append(dst, enc.id, headerPackings[enc.packing])
+}
+
+// parseHeader returns the Encoding identified by header.
+func parseHeader(header []byte) (*Encoding, error) {
+	for _, enc := range headerEncodings {
+		if enc.id != header[0] {
+			continue
+		}
+
+		for packing := 0; packing < len(headerPackings); packing++ {
+			if headerPackings[packing] == header[1] {
+				return enc.WithPacking(Packing(packing)), nil
+			}
+		}
+	}
+
+	return nil, &HeaderError{Header: string(header)}
+}
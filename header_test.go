@@ -0,0 +1,66 @@
+package jase93
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"testing"
+)
+
+func TestHeader(t *testing.T) {
+	src := []byte("Man is distinguished")
+
+	for _, tc := range []struct {
+		enc    *Encoding
+		header string
+	}{
+		{StdEncoding, "aa"},
+		{StdEncoding.WithPacking(SimplePacking), "ab"},
+	} {
+		enc := tc.enc.WithHeader()
+
+		out := enc.Encode(nil, src)
+		if !bytes.HasPrefix(out, []byte(tc.header)) || !bytes.Equal(out[HeaderLen:], tc.enc.Encode(nil, src)) {
+			t.Errorf("Encode(%q) = %q", src, out)
+		}
+
+		var buf bytes.Buffer
+		e := enc.NewEncoder(&buf)
+		if _, err := e.Write(src); err != nil {
+			t.Fatal(err)
+		}
+		if err := e.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), out) {
+			t.Errorf("Encoder(%q) = %q != %q", src, buf.Bytes(), out)
+		}
+
+		// Any Encoding with a header decodes any stream with a header
+		dec, err := StdEncoding.WithHeader().Decode(nil, out)
+		if err != nil || !bytes.Equal(dec, src) {
+			t.Errorf("Decode(%q) = %q, %v", out, dec, err)
+		}
+
+		dec, err = ioutil.ReadAll(StdEncoding.WithHeader().NewDecoder(&buf))
+		if err != nil || !bytes.Equal(dec, src) {
+			t.Errorf("Decoder(%q) = %q, %v", out, dec, err)
+		}
+	}
+
+	if out := StdEncoding.WithHeader().Encode(nil, nil); string(out) != "aa" {
+		t.Errorf("Encode(nil) = %q", out)
+	}
+}
+
+func TestInvalidHeader(t *testing.T) {
+	enc := StdEncoding.WithHeader()
+	for _, in := range []string{"", "a", "za", "az"} {
+		if _, err := enc.Decode(nil, []byte(in)); !errors.Is(err, ErrInvalidHeader) {
+			t.Errorf("Decode(%q) = %v != %v", in, err, ErrInvalidHeader)
+		}
+		if _, err := ioutil.ReadAll(enc.NewDecoder(bytes.NewBufferString(in))); !errors.Is(err, ErrInvalidHeader) {
+			t.Errorf("Decoder(%q) = %v != %v", in, err, ErrInvalidHeader)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package jase93
+
+// encodeHuman is the alphabet of HumanEncoding: the digits 2-9, the letters other than I, O, and Q and the lowercase
+// letters easily mistaken for those or for their uppercase forms, and a few distinct symbols.
+const encodeHuman = "#$%&*+23456789=?@ABCDEFGHJKLMNPRSTUVWXYZabdefghjmnrty"
+
+// HumanEncoding is an encoding for codes read, written, or typed by people, such as recovery codes and paper backups.
+// Its 53-character alphabet excludes characters that are easily confused with one another: 0, O, o, and Q; 1, I, l,
+// i, |, and !; quotes and small punctuation marks, such as ` and ,; and space, '-', '_', and '~', which are hard to
+// count or read aloud. Each word of two characters carries 11 or 12 bits, rather than the 13 or 14 of StdEncoding.
+//
+// Lowercase c, k, p, s, u, v, w, x, and z, which are written like their uppercase forms, are decoded as those forms.
+var HumanEncoding = newHumanEncoding().withID('b')
+
+func newHumanEncoding() *Encoding {
+	enc := NewEncoding(encodeHuman)
+	for _, c := range []byte("ckpsuvwxz") {
+		enc.decode[c] = enc.decode[c-'a'+'A']
+	}
+	return enc
+}
@@ -0,0 +1,44 @@
+package jase93
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+func TestHumanEncoding(t *testing.T) {
+	if strings.ContainsAny(encodeHuman, "0Oo1Il|!'`\",.:; -_~Qqi") {
+		t.Errorf("alphabet %q contains ambiguous characters", encodeHuman)
+	}
+
+	if err := quick.Check(func(in []byte) bool {
+		enc := HumanEncoding.Encode(nil, in)
+		dec, err := HumanEncoding.Decode(nil, enc)
+		if err != nil {
+			t.Error(err)
+			return false
+		}
+		return bytes.Equal(in, dec)
+	}, nil); err != nil {
+		t.Error(err)
+	}
+
+	// Lowercase forms of letters written like their uppercase forms decode as those forms
+	src := []byte("\x00paper backup\xff")
+	enc := HumanEncoding.Encode(nil, src)
+	if dec, err := HumanEncoding.Decode(nil, bytes.Map(func(r rune) rune {
+		if strings.ContainsRune("CKPSUVWXZ", r) {
+			return r - 'A' + 'a'
+		}
+		return r
+	}, enc)); err != nil || !bytes.Equal(dec, src) {
+		t.Errorf("Decode(lowercase) = %q, %v", dec, err)
+	}
+
+	// Streams with headers identify the encoding
+	headered := HumanEncoding.WithHeader()
+	if dec, err := StdEncoding.WithHeader().Decode(nil, headered.Encode(nil, src)); err != nil || !bytes.Equal(dec, src) {
+		t.Errorf("Decode(header) = %q, %v", dec, err)
+	}
+}
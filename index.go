@@ -0,0 +1,255 @@
+package jase93
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"sort"
+)
+
+// ErrInvalidIndex indicates that an index was malformed or does not match the encoded data.
+var ErrInvalidIndex = errors.New("jase93: invalid index")
+
+// indexMagic begins an index, followed by a version byte.
+const indexMagic = "J93I\x01"
+
+// DefaultIndexInterval is the approximate number of decoded bytes between checkpoints of an index built by BuildIndex
+// if no interval is given.
+const DefaultIndexInterval = 64 << 10
+
+// checkpoint is a point at a word boundary from which decoding can resume.
+type checkpoint struct {
+	raw     int64  // The offset in the decoded data of the byte being decoded
+	encoded int64  // The offset in the encoded data
+	bits    uint64 // The bits of the byte at raw that were already decoded
+	nbits   uint   // The number of bits in bits, which is less than 8
+}
+
+// BuildIndex reads encoded data from r and returns an index of it for NewReaderAt, with checkpoints approximately
+// every interval decoded bytes, or DefaultIndexInterval if interval is not positive.
+//
+// The index is a compact binary format: the magic string "J93I" and a version byte of 1, followed by uvarints of the
+// decoded length, the encoded length, and the number of checkpoints, and then for each checkpoint, uvarints of the
+// increase in its decoded and encoded offsets since the previous checkpoint and a byte holding the decoded bits of
+// the partial byte at the checkpoint, with a 1 bit above them. Each checkpoint takes about 7 bytes at the default
+// interval.
+//
+// Encodings that skip characters, with a line length, IgnoreLineBreaks, or IgnoreWhitespace, or that are Lenient or
+// have a length trailer are not supported, since the offsets of the index would not match the encoded data.
+func (enc *Encoding) BuildIndex(r io.Reader, interval int) ([]byte, error) {
+	if enc.lineLen > 0 || enc.ignoreBreaks || enc.ignoreSpace || enc.lenient || enc.trailer {
+		return nil, errors.New("jase93: index does not support skipped characters, lenient decoding, or length trailers")
+	}
+	if interval <= 0 {
+		interval = DefaultIndexInterval
+	}
+
+	// Checkpoints are taken after each chunk, which must have an even length to end at a word boundary
+	step := (interval*16/int(enc.wordBits) + 1) &^ 1
+	chunk := make([]byte, step)
+
+	d := decoder{encoding: enc}
+	d.reset()
+	cps := []checkpoint{{}}
+	var raw, encoded int64
+	var buf []byte
+	for {
+		n, rerr := io.ReadFull(r, chunk)
+		if n > 0 {
+			var err error
+			if buf, err = d.decode(buf[:0], chunk[:n]); err != nil {
+				return nil, err
+			}
+			raw += int64(len(buf))
+			encoded += int64(n)
+			if rerr == nil && d.enc != nil && d.word == -1 {
+				cps = append(cps, checkpoint{raw: raw, encoded: encoded, bits: d.bits.Bits(), nbits: d.bits.Len()})
+			}
+		}
+		if rerr == io.EOF || rerr == io.ErrUnexpectedEOF {
+			break
+		} else if rerr != nil {
+			return nil, rerr
+		}
+	}
+
+	buf, err := d.flush(buf[:0])
+	if err != nil {
+		return nil, err
+	}
+	raw += int64(len(buf))
+
+	index := append([]byte(nil), indexMagic...)
+	index = appendUvarint(index, uint64(raw))
+	index = appendUvarint(index, uint64(encoded))
+	index = appendUvarint(index, uint64(len(cps)))
+	prev := checkpoint{}
+	for _, cp := range cps {
+		index = appendUvarint(index, uint64(cp.raw-prev.raw))
+		index = appendUvarint(index, uint64(cp.encoded-prev.encoded))
+		index = append(index, byte(cp.bits|1<<cp.nbits))
+		prev = cp
+	}
+	return index, nil
+}
+
+// BuildIndex reads data encoded with StdEncoding from r and returns an index of it. See Encoding.BuildIndex.
+func BuildIndex(r io.Reader, interval int) ([]byte, error) {
+	return StdEncoding.BuildIndex(r, interval)
+}
+
+func appendUvarint(dst []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	return append(dst, buf[:binary.PutUvarint(buf[:], v)]...)
+}
+
+// parseIndex parses an index built by BuildIndex.
+func parseIndex(index []byte) (raw, encoded int64, cps []checkpoint, err error) {
+	if len(index) < len(indexMagic) || string(index[:len(indexMagic)]) != indexMagic {
+		return 0, 0, nil, ErrInvalidIndex
+	}
+	index = index[len(indexMagic):]
+
+	next := func() int64 {
+		v, n := binary.Uvarint(index)
+		if n <= 0 || v > 1<<62 {
+			err = ErrInvalidIndex
+			return 0
+		}
+		index = index[n:]
+		return int64(v)
+	}
+
+	raw, encoded = next(), next()
+	count := next()
+	if err != nil || count < 1 || count > int64(len(index)) {
+		return 0, 0, nil, ErrInvalidIndex
+	}
+
+	cps = make([]checkpoint, count)
+	var cp checkpoint
+	for i := range cps {
+		cp.raw += next()
+		cp.encoded += next()
+		if err != nil || len(index) == 0 || index[0] == 0 {
+			return 0, 0, nil, ErrInvalidIndex
+		}
+		state := index[0]
+		index = index[1:]
+		cp.nbits = 0
+		for state>>(cp.nbits+1) != 0 {
+			cp.nbits++
+		}
+		cp.bits = uint64(state) &^ (1 << cp.nbits)
+
+		if cp.raw > raw || cp.encoded > encoded || (i == 0 && (cp.raw != 0 || cp.encoded != 0)) {
+			return 0, 0, nil, ErrInvalidIndex
+		}
+		cps[i] = cp
+	}
+	if len(index) != 0 {
+		return 0, 0, nil, ErrInvalidIndex
+	}
+	return raw, encoded, cps, nil
+}
+
+// NewReaderAt returns an io.ReaderAt that reads the data decoded from ra, which holds the encoded data described by
+// index, as returned by BuildIndex. Each ReadAt decodes from the checkpoint preceding its offset, so random reads of
+// large encoded archives read little more encoded data than they return. If index is invalid, ReadAt returns
+// ErrInvalidIndex.
+func (enc *Encoding) NewReaderAt(ra io.ReaderAt, index []byte) io.ReaderAt {
+	r := &readerAt{ra: ra, encoding: enc}
+	r.raw, r.encoded, r.cps, r.err = parseIndex(index)
+	if r.err == nil && enc.header && r.encoded >= HeaderLen {
+		// Resuming past the header requires the Encoding it selects
+		header := make([]byte, HeaderLen)
+		if _, r.err = ra.ReadAt(header, 0); r.err == nil {
+			r.streamEnc, r.err = parseHeader(header)
+		}
+	}
+	return r
+}
+
+// NewReaderAt returns an io.ReaderAt that reads the data decoded with StdEncoding from ra, using index. See
+// Encoding.NewReaderAt.
+func NewReaderAt(ra io.ReaderAt, index []byte) io.ReaderAt {
+	return StdEncoding.NewReaderAt(ra, index)
+}
+
+type readerAt struct {
+	ra        io.ReaderAt
+	encoding  *Encoding
+	streamEnc *Encoding // The Encoding selected by the stream header, if any
+	raw       int64
+	encoded   int64
+	cps       []checkpoint
+	err       error
+}
+
+func (r *readerAt) ReadAt(data []byte, off int64) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	if off < 0 {
+		return 0, errors.New("jase93: negative offset")
+	}
+	if off >= r.raw {
+		return 0, io.EOF
+	}
+
+	i := sort.Search(len(r.cps), func(i int) bool { return r.cps[i].raw > off }) - 1
+	cp := r.cps[i]
+
+	d := decoder{encoding: r.encoding}
+	d.reset()
+	if cp.encoded > 0 {
+		if r.streamEnc != nil {
+			d.enc = r.streamEnc
+		}
+		d.offset = cp.encoded
+		d.bits.WriteBits(uint32(cp.bits), cp.nbits)
+	}
+
+	// Decode enough to reach the end of data, plus a margin for the partial words at either end
+	want := off + int64(len(data))
+	if want > r.raw {
+		want = r.raw
+	}
+	in := make([]byte, (want-cp.raw)*16/int64(d.encoding.wordBits)+8)
+
+	pos, n := cp.raw, 0
+	var buf []byte
+	for encoded := cp.encoded; n < len(data) && encoded < r.encoded; {
+		chunk := in
+		if rest := r.encoded - encoded; int64(len(chunk)) > rest {
+			chunk = chunk[:rest]
+		}
+		rn, err := r.ra.ReadAt(chunk, encoded)
+		if rn < len(chunk) {
+			if err == nil || err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return n, err
+		}
+		encoded += int64(rn)
+
+		if buf, err = d.decode(buf[:0], chunk); err != nil {
+			return n, err
+		}
+		if encoded == r.encoded {
+			if buf, err = d.flush(buf); err != nil {
+				return n, err
+			}
+		}
+
+		if skip := off + int64(n) - pos; skip < int64(len(buf)) {
+			n += copy(data[n:], buf[skip:])
+		}
+		pos += int64(len(buf))
+	}
+
+	if n < len(data) {
+		return n, io.EOF
+	}
+	return n, nil
+}
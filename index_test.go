@@ -0,0 +1,89 @@
+package jase93
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+)
+
+func TestReaderAt(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	src := make([]byte, 100000)
+	rng.Read(src)
+
+	for _, enc := range []*Encoding{StdEncoding, StdEncoding.WithPacking(SimplePacking), StdEncoding.WithHeader()} {
+		encoded := enc.Encode(nil, src)
+		index, err := enc.BuildIndex(bytes.NewReader(encoded), 1000)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(index) > 1000 {
+			t.Errorf("len(index) = %d", len(index))
+		}
+
+		ra := enc.NewReaderAt(bytes.NewReader(encoded), index)
+		for i := 0; i < 200; i++ {
+			off := rng.Intn(len(src))
+			data := make([]byte, rng.Intn(3000))
+			n, err := ra.ReadAt(data, int64(off))
+
+			want := src[off:]
+			if len(want) > len(data) {
+				want = want[:len(data)]
+			}
+			if n != len(want) || !bytes.Equal(data[:n], want) {
+				t.Fatalf("ReadAt(%d bytes, %d) = %d bytes, %v", len(data), off, n, err)
+			}
+			if (n < len(data)) != (err == io.EOF) {
+				t.Fatalf("ReadAt(%d bytes, %d) = %d, %v", len(data), off, n, err)
+			}
+		}
+
+		if n, err := ra.ReadAt(make([]byte, 1), int64(len(src))); n != 0 || err != io.EOF {
+			t.Errorf("ReadAt(end) = %d, %v", n, err)
+		}
+	}
+}
+
+func TestReaderAtSmall(t *testing.T) {
+	for _, s := range []string{"", "a", "ab", "abc"} {
+		encoded := Encode(nil, []byte(s))
+		index, err := BuildIndex(bytes.NewReader(encoded), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ra := NewReaderAt(bytes.NewReader(encoded), index)
+		data := make([]byte, 4)
+		if n, err := ra.ReadAt(data, 0); string(data[:n]) != s || (s != "" && err != io.EOF) {
+			t.Errorf("ReadAt(%q) = %q, %v", s, data[:n], err)
+		}
+	}
+}
+
+func TestReaderAtInvalidIndex(t *testing.T) {
+	encoded := Encode(nil, []byte("data"))
+	index, _ := BuildIndex(bytes.NewReader(encoded), 0)
+
+	for _, bad := range [][]byte{nil, []byte("J93I\x02"), index[:len(index)-1], append(index, 0)} {
+		if _, err := NewReaderAt(bytes.NewReader(encoded), bad).ReadAt(make([]byte, 1), 0); err != ErrInvalidIndex {
+			t.Errorf("ReadAt(index %q) = %v", bad, err)
+		}
+	}
+
+	if _, err := BuildIndex(bytes.NewReader([]byte(`ab"`)), 0); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("BuildIndex(invalid) = %v", err)
+	}
+	for i, enc := range []*Encoding{
+		StdEncoding.WithLengthTrailer(),
+		StdEncoding.WithLineLength(MIMELineLen),
+		StdEncoding.IgnoreLineBreaks(),
+		SpaceFreeEncoding.IgnoreWhitespace(),
+		StdEncoding.Lenient(),
+	} {
+		if _, err := enc.BuildIndex(bytes.NewReader(encoded), 0); err == nil {
+			t.Errorf("BuildIndex with Encoding %d succeeded", i)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+//go:build go1.23
+
+package jase93
+
+import (
+	"iter"
+	"slices"
+)
+
+// EncodeSeq returns a sequence of the encodings of the chunks of data in seq, encoded as one stream with enc, so the
+// encoded chunks concatenate to the encoding of the concatenated data. Chunks that complete no word yield nothing; the
+// final chunk holds the end of the stream. Each encoded chunk is only valid until the next iteration.
+func (enc *Encoding) EncodeSeq(seq iter.Seq[[]byte]) iter.Seq[[]byte] {
+	return func(yield func([]byte) bool) {
+		e := encoder{encoding: enc}
+		var buf []byte
+		for data := range seq {
+			if buf = e.write(buf[:0], data); len(buf) > 0 && !yield(buf) {
+				return
+			}
+		}
+		if buf = e.flush(buf[:0]); len(buf) > 0 {
+			yield(buf)
+		}
+	}
+}
+
+// EncodeSeq returns a sequence of the encodings of the chunks of data in seq with StdEncoding; see Encoding.EncodeSeq.
+func EncodeSeq(seq iter.Seq[[]byte]) iter.Seq[[]byte] {
+	return StdEncoding.EncodeSeq(seq)
+}
+
+// EncodeChunks returns a sequence of the encodings of src, n bytes at a time, as EncodeSeq does, so a payload can be
+// processed chunk by chunk with a range loop:
+//
+//	for chunk := range jase93.EncodeChunks(payload, 64<<10) {
+//		send(chunk)
+//	}
+//
+// EncodeChunks panics if n is not positive.
+func (enc *Encoding) EncodeChunks(src []byte, n int) iter.Seq[[]byte] {
+	return enc.EncodeSeq(slices.Chunk(src, n))
+}
+
+// EncodeChunks returns a sequence of the encodings of src, n bytes at a time, with StdEncoding; see
+// Encoding.EncodeChunks.
+func EncodeChunks(src []byte, n int) iter.Seq[[]byte] {
+	return StdEncoding.EncodeChunks(src, n)
+}
+
+// DecodeSeq returns a sequence of the data decoded from the chunks of an encoded stream in seq, paired with any error.
+// Chunks that complete no byte yield nothing. Decoding stops at the first error, which is yielded with any data decoded
+// before it; the error of a stream that ends incorrectly is yielded with the final chunk. Each decoded chunk is only
+// valid until the next iteration.
+func (enc *Encoding) DecodeSeq(seq iter.Seq[[]byte]) iter.Seq2[[]byte, error] {
+	return func(yield func([]byte, error) bool) {
+		d := decoder{encoding: enc}
+		d.reset()
+		var buf []byte
+		var err error
+		for encoded := range seq {
+			buf, err = d.write(buf[:0], encoded)
+			if err != nil {
+				yield(buf, err)
+				return
+			}
+			if len(buf) > 0 && !yield(buf, nil) {
+				return
+			}
+		}
+		if buf, err = d.flush(buf[:0]); len(buf) > 0 || err != nil {
+			yield(buf, err)
+		}
+	}
+}
+
+// DecodeSeq returns a sequence of the data decoded from the chunks of a stream encoded with StdEncoding; see
+// Encoding.DecodeSeq.
+func DecodeSeq(seq iter.Seq[[]byte]) iter.Seq2[[]byte, error] {
+	return StdEncoding.DecodeSeq(seq)
+}
+
+// DecodeChunks returns a sequence of the data decoded from src, n characters at a time, as DecodeSeq does:
+//
+//	for data, err := range jase93.DecodeChunks(encoded, 64<<10) {
+//		if err != nil {
+//			return err
+//		}
+//		process(data)
+//	}
+//
+// DecodeChunks panics if n is not positive.
+func (enc *Encoding) DecodeChunks(src []byte, n int) iter.Seq2[[]byte, error] {
+	return enc.DecodeSeq(slices.Chunk(src, n))
+}
+
+// DecodeChunks returns a sequence of the data decoded from src, n characters at a time, with StdEncoding; see
+// Encoding.DecodeChunks.
+func DecodeChunks(src []byte, n int) iter.Seq2[[]byte, error] {
+	return StdEncoding.DecodeChunks(src, n)
+}
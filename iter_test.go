@@ -0,0 +1,86 @@
+//go:build go1.23
+
+package jase93
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestEncodeChunks(t *testing.T) {
+	src := make([]byte, 1000)
+	rand.New(rand.NewSource(0)).Read(src)
+
+	for _, enc := range []*Encoding{StdEncoding, StdEncoding.WithHeader(), StdEncoding.WithLengthTrailer(), StdEncoding.WithLineLength(76)} {
+		want := enc.Encode(nil, src)
+		for _, n := range []int{1, 7, 100, 1000, 4096} {
+			var encoded []byte
+			for chunk := range enc.EncodeChunks(src, n) {
+				encoded = append(encoded, chunk...)
+			}
+			if !bytes.Equal(encoded, want) {
+				t.Errorf("%d-byte chunks: encoding differs from Encode", n)
+			}
+
+			var decoded []byte
+			for data, err := range enc.DecodeChunks(encoded, n) {
+				if err != nil {
+					t.Fatalf("%d-character chunks: %v", n, err)
+				}
+				decoded = append(decoded, data...)
+			}
+			if !bytes.Equal(decoded, src) {
+				t.Errorf("%d-character chunks: decoded data differs", n)
+			}
+		}
+	}
+}
+
+func TestDecodeSeq(t *testing.T) {
+	src := []byte("Man is distinguished, not only by his reason")
+
+	// Pipelines compose
+	var decoded []byte
+	for data, err := range DecodeSeq(EncodeSeq(func(yield func([]byte) bool) {
+		for i := range src {
+			if !yield(src[i : i+1]) {
+				return
+			}
+		}
+	})) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded = append(decoded, data...)
+	}
+	if !bytes.Equal(decoded, src) {
+		t.Errorf("decoded %q", decoded)
+	}
+
+	// Decoding stops at the first error
+	encoded := Encode(nil, src)
+	encoded[20] = '"'
+	var errs int
+	for _, err := range DecodeChunks(encoded, 8) {
+		if err != nil {
+			errs++
+			var cerr *CorruptInputError
+			if !errors.As(err, &cerr) || cerr.Offset != 20 {
+				t.Errorf("got %v, want invalid character at offset 20", err)
+			}
+		}
+	}
+	if errs != 1 {
+		t.Errorf("%d errors, want 1", errs)
+	}
+
+	// Stopping early is allowed
+	for range EncodeChunks(src, 1) {
+		break
+	}
+	for range DecodeChunks(encoded, 1) {
+		break
+	}
+}
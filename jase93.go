@@ -2,154 +2,568 @@
 package jase93 // import "github.com/jdknezek/jase93-go"
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
 	"errors"
+	"hash"
 	"io"
 	"math"
+	"math/bits"
+	"strings"
+	"time"
+
+	"github.com/jdknezek/jase93-go/bitio"
 )
 
-func invertAlphabet(alphabet string) []int8 {
-	decode := make([]int8, 256)
-	for i := range decode {
-		decode[i] = -1
+const encodeStd = " !#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[]^_`abcdefghijklmnopqrstuvwxyz{|}~"
+
+// Packing selects how bits are packed into each word of two characters.
+//
+// Words are always two characters: longer words would not be denser, since each character carries at most log2(93)
+// bits however characters are grouped, and adaptive packing of two-character words already comes closer to that than
+// three-character words can. See the Packing section of the README.
+type Packing uint8
+
+const (
+	// AdaptivePacking packs WordBits bits into each word, plus one extra bit whenever it fits.
+	AdaptivePacking Packing = iota
+	// SimplePacking packs exactly WordBits bits into each word. It is slightly less dense, but trivial to port.
+	SimplePacking
+)
+
+// An Encoding is a jase93 alphabet and packing mode.
+type Encoding struct {
+	encode   string
+	decode   [256]int8
+	base     uint32
+	divMul   uint64 // reciprocal of base for division by multiplication
+	wordMax  uint32
+	wordBits uint
+	wordMask uint32
+	wordFull uint32
+	packing  Packing
+	id       byte
+	header   bool
+	lenient  bool
+	trailer  bool
+	lineLen  int
+
+	ignoreBreaks bool // see IgnoreLineBreaks
+	ignoreSpace  bool // see IgnoreWhitespace
+	constantTime bool // see ConstantTime
+	checkDouble  bool // see WithDoubleEncodingCheck
+	printable    bool // the alphabet allows SWAR validation; see isPrintable
+}
+
+// NewEncoding returns a new adaptively packed Encoding defined by alphabet, which must consist of between 16 and 128
+// distinct characters, not including '\r' or '\n'.
+func NewEncoding(alphabet string) *Encoding {
+	if len(alphabet) < 16 || len(alphabet) > 128 {
+		panic("jase93: encoding alphabet must have between 16 and 128 characters")
 	}
 
-	for i, c := range encode {
-		decode[c] = int8(i)
+	e := &Encoding{encode: alphabet}
+	for i := range e.decode {
+		e.decode[i] = -1
 	}
 
-	return decode
+	for i := 0; i < len(alphabet); i++ {
+		c := alphabet[i]
+		if c == '\n' || c == '\r' {
+			panic("jase93: encoding alphabet contains newline character")
+		}
+		if e.decode[c] != -1 {
+			panic("jase93: encoding alphabet includes duplicate symbols")
+		}
+		e.decode[c] = int8(i)
+	}
+
+	e.printable = isPrintable(alphabet)
+	e.base = uint32(len(alphabet))
+	e.divMul = 1<<32/uint64(e.base) + 1
+	e.wordMax = (e.base * e.base) - 1
+	e.wordBits = uint(bits.Len32(e.wordMax) - 1)
+	e.wordMask = (1 << e.wordBits) - 1
+	return e.WithPacking(AdaptivePacking)
 }
 
-var (
-	encode   = " !#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[]^_`abcdefghijklmnopqrstuvwxyz{|}~"
-	decode   = invertAlphabet(encode)
-	base     = uint32(len(encode))                  // 93
-	wordMax  = (base * base) - 1                    // 8648
-	wordBits = uint8(math.Log2(float64(wordMax)))   // 13
-	wordMask = uint32((1 << wordBits) - 1)          // 0x1fff
-	wordFull = uint32(wordMax - (wordMask + 1) + 1) // 457
+// WithPacking creates a new Encoding identical to enc except with the specified packing mode.
+func (enc Encoding) WithPacking(packing Packing) *Encoding {
+	enc.packing = packing
+	enc.wordFull = 0
+	if packing == AdaptivePacking {
+		enc.wordFull = enc.wordMax - enc.wordMask
+	}
+	return &enc
+}
+
+// StdEncoding is the standard jase93 encoding.
+var StdEncoding = NewEncoding(encodeStd).withID('a')
+
+// Word packing parameters of StdEncoding. Each pair of characters encodes a word of WordBits bits, or of WordBits+1
+// bits if its low WordBits bits are less than WordFull.
+const (
+	Base     = 93
+	WordMax  = Base*Base - 1               // 8648
+	WordBits = 13                          // floor(log2(WordMax))
+	WordFull = WordMax - (1<<WordBits - 1) // 457
 )
 
+// WordBitLen returns the number of data bits carried by word, following the WordBits/WordFull rule.
+func WordBitLen(word uint16) int {
+	if word&(1<<WordBits-1) < WordFull {
+		return WordBits + 1
+	}
+	return WordBits
+}
+
+// EncodeWord returns the two characters encoding word, low digit first. word must not exceed WordMax.
+func EncodeWord(word uint16) (byte, byte) {
+	return encodeStd[word%Base], encodeStd[word/Base]
+}
+
+// DecodeWord returns the word encoded by the characters a and b, low digit first.
+// It reports false if either character is outside the alphabet.
+func DecodeWord(a, b byte) (uint16, bool) {
+	lo, hi := StdEncoding.decode[a], StdEncoding.decode[b]
+	if lo == -1 || hi == -1 {
+		return 0, false
+	}
+	return uint16(lo) + uint16(hi)*Base, true
+}
+
+// WordBitLen returns the number of data bits carried by word with enc, which depends on its alphabet and packing.
+func (enc *Encoding) WordBitLen(word uint16) int {
+	if uint32(word)&enc.wordMask < enc.wordFull {
+		return int(enc.wordBits) + 1
+	}
+	return int(enc.wordBits)
+}
+
+// EncodeWord returns the two characters encoding word with enc, low digit first. word must be less than the square of
+// the size of the alphabet.
+func (enc *Encoding) EncodeWord(word uint16) (byte, byte) {
+	return enc.encode[uint32(word)%enc.base], enc.encode[uint32(word)/enc.base]
+}
+
+// DecodeWord returns the word encoded by the characters a and b with enc, low digit first. It reports false if either
+// character is outside the alphabet.
+func (enc *Encoding) DecodeWord(a, b byte) (uint16, bool) {
+	lo, hi := enc.decode[a], enc.decode[b]
+	if lo == -1 || hi == -1 {
+		return 0, false
+	}
+	return uint16(lo) + uint16(hi)*uint16(enc.base), true
+}
+
+// div returns x / enc.base for x of at most 16 bits, multiplying by the reciprocal instead of dividing. Since divMul
+// exceeds 2**32 / base by less than 1, the error is less than x / 2**32, which cannot reach the next multiple of base.
+func (enc *Encoding) div(x uint32) uint32 {
+	return uint32(uint64(x) * enc.divMul >> 32)
+}
+
 // MaxEncodedLen returns the maximum number of bytes necessary to encode n source bytes.
+func (enc *Encoding) MaxEncodedLen(n int) int {
+	if enc.trailer {
+		n += maxTrailerLen
+	}
+	max := int(math.Ceil(float64(n) * 16 / float64(enc.wordBits)))
+	if enc.header {
+		max += HeaderLen
+	}
+	if enc.lineLen > 0 {
+		max += (max + enc.lineLen - 1) / enc.lineLen * 2
+	}
+	return max
+}
+
+// MaxEncodedLen returns the maximum number of bytes necessary to encode n source bytes with StdEncoding.
 func MaxEncodedLen(n int) int {
-	return int(math.Ceil(float64(n) * 16 / float64(wordBits)))
+	return StdEncoding.MaxEncodedLen(n)
 }
 
 type encoder struct {
-	state     uint32
-	stateBits uint8
+	encoding *Encoding
+	started  bool
+	bits     bitio.BitReader
+	raw      int64
+	col      int
+	scratch  []byte
+	words    int64
+	extra    int64
+	partial  uint // the number of bits of the current byte written by writeBits, or 0 if it is complete
 }
 
 func (e *encoder) reset() {
-	e.state = 0
-	e.stateBits = 0
+	if e.encoding == nil {
+		e.encoding = StdEncoding
+	}
+	e.started = false
+	e.bits.Reset()
+	e.raw = 0
+	e.col = 0
+	e.words = 0
+	e.extra = 0
+	e.partial = 0
+}
+
+// start appends the header to dst if the encoding requires one and it has not yet been written.
+func (e *encoder) start(dst []byte) []byte {
+	if !e.started {
+		e.started = true
+		if e.encoding.header {
+			dst = e.encoding.appendHeader(dst)
+		}
+	}
+	return dst
 }
 
 // write encodes src and appends it to dst.
 func (e *encoder) write(dst, src []byte) []byte {
-	for _, c := range src {
-		e.state |= uint32(c) << e.stateBits
-		e.stateBits += 8
+	if e.partial > 0 {
+		dst = e.align(dst)
+	}
+	start := len(dst)
+	dst = e.start(dst)
+	e.raw += int64(len(src))
+
+	// Work on local copies of the state so it can be kept in registers
+	enc := e.encoding
+	bits, words, extra := e.bits, e.words, e.extra
+
+	// Consume 16 bits at a time where available, which yields at most two words
+	for len(src) > 0 {
+		if len(src) >= 2 {
+			bits.Push16(binary.LittleEndian.Uint16(src))
+			src = src[2:]
+		} else {
+			bits.Push(src[0])
+			src = src[1:]
+		}
 
 		// Ensure we have an extra bit in case we need it
-		for e.stateBits > wordBits {
-			word := e.state & wordMask
-			e.state >>= wordBits
-			e.stateBits -= wordBits
-
-			if word < wordFull {
-				// We can fit one more bit into word without exceeding wordMax
-				word |= (e.state & 1) << wordBits
-				e.state >>= 1
-				e.stateBits--
-			}
+		for bits.Len() > enc.wordBits {
+			word := bits.ReadWord(enc.wordBits, enc.wordFull)
+			words++
+			extra += int64(bitio.Bit(word&enc.wordMask < enc.wordFull))
 
-			mod := word % base
-			div := word / base
-			dst = append(dst, encode[mod], encode[div])
+			div := enc.div(word)
+			dst = append(dst, enc.encode[word-div*enc.base], enc.encode[div])
 		}
 	}
 
-	return dst
+	e.bits, e.words, e.extra = bits, words, extra
+	return e.wrap(dst, start)
 }
 
 // flush flushes the encoding state and appends it to dst.
 func (e *encoder) flush(dst []byte) []byte {
-	if e.stateBits > 0 {
-		mod := e.state % base
-		dst = append(dst, encode[mod])
-
-		if e.stateBits > 8 || e.state >= base {
-			div := e.state / base
-			dst = append(dst, encode[div])
+	dst = e.align(dst)
+	enc := e.encoding
+	if enc.trailer {
+		var trailer [maxTrailerLen]byte
+		dst = e.write(dst, appendTrailer(trailer[:0], e.raw))
+	}
+	start := len(dst)
+	dst = e.start(dst)
+	if n := e.bits.Len(); n > 0 {
+		state := e.bits.ReadBits(n)
+		div := enc.div(state)
+		dst = append(dst, enc.encode[state-div*enc.base])
+
+		if n > 8 || state >= enc.base {
+			dst = append(dst, enc.encode[div])
+			e.words++
 		}
 	}
 
+	dst = e.wrap(dst, start)
+	if e.col > 0 {
+		dst = append(dst, '\r', '\n')
+		e.col = 0
+	}
 	return dst
 }
 
 // Encode encodes src and appends it to dst.
+func (enc *Encoding) Encode(dst, src []byte) []byte {
+	e := encoder{encoding: enc}
+	n := len(dst)
+	dst = e.write(dst, src)
+	dst = e.flush(dst)
+	recordEncode(len(src), len(dst)-n, nil)
+	return dst
+}
+
+// Encode encodes src with StdEncoding and appends it to dst.
 func Encode(dst, src []byte) []byte {
-	var enc encoder
-	dst = enc.write(dst, src)
-	return enc.flush(dst)
+	return StdEncoding.Encode(dst, src)
 }
 
 // Encoder encodes data to a wrapped io.Writer.
 type Encoder struct {
-	w   io.Writer
-	enc encoder
-	buf []byte
+	w          io.Writer
+	enc        encoder
+	buf        []byte
+	stats      Stats
+	start      time.Time
+	trace      tracing
+	tee        io.Writer
+	digest     hash.Hash
+	batch      *batch
+	scratch    []byte // the caller's buffer, if any
+	scratchLen int    // the length of data whose encoding fits in scratch
+	closed     bool
+	err        error // the result of Close
 }
 
+// ErrWriteAfterClose indicates that an Encoder was written to after it was closed.
+var ErrWriteAfterClose = errors.New("jase93: write after close")
+
 // NewEncoder creates a new Encoder that encodes to w.
+func (enc *Encoding) NewEncoder(w io.Writer) *Encoder {
+	e := new(Encoder)
+	e.enc.encoding = enc
+	return e.Reset(w)
+}
+
+// NewEncoder creates a new Encoder that encodes to w with StdEncoding.
 func NewEncoder(w io.Writer) *Encoder {
-	return new(Encoder).Reset(w)
+	return StdEncoding.NewEncoder(w)
 }
 
-// Reset sets the Encoder to encode to w and resets its encoding state.
+// Reset sets the Encoder to encode to w and resets its encoding state. The Encoding is retained.
 func (e *Encoder) Reset(w io.Writer) *Encoder {
+	e.trace.reset(e.Stats())
 	e.w = w
 	e.enc.reset()
-	e.buf = nil
+	e.buf = e.scratch
+	e.stats = Stats{}
+	e.start = time.Time{}
+	if e.batch != nil {
+		e.batch.reset()
+	}
+	e.closed = false
+	e.err = nil
 	return e
 }
 
-// Write encodes data to the wrapped io.Writer.
+// Write encodes data to the wrapped io.Writer. It returns ErrWriteAfterClose if the Encoder has been closed.
 func (e *Encoder) Write(data []byte) (int, error) {
+	if e.closed {
+		return 0, ErrWriteAfterClose
+	}
+	e.mark()
+	e.trace.start("jase93.Encode")
+	if e.tee != nil {
+		if _, err := e.tee.Write(data); err != nil {
+			return 0, err
+		}
+	}
+	if e.digest != nil {
+		e.digest.Write(data)
+	}
+	e.stats.RawBytes += int64(len(data))
+	if e.batch != nil {
+		recordEncode(len(data), 0, nil)
+		if e.batch.add(e.enc.write(e.batch.next(), data)) {
+			return len(data), e.flushBatch()
+		}
+		return len(data), nil
+	}
+	if e.scratch != nil {
+		return e.writeScratch(data)
+	}
+
 	e.buf = e.enc.write(e.buf[:0], data)
-	_, err := e.w.Write(e.buf)
+	n, err := e.w.Write(e.buf)
+	e.stats.EncodedBytes += int64(n)
+	recordEncode(len(data), n, err)
 	return len(data), err
 }
 
 // Close flushes the encoding state to the wrapped io.Writer. It does not close the wrapped io.Writer.
+// Subsequent calls do nothing and return the result of the first, until the Encoder is Reset.
 func (e *Encoder) Close() error {
-	e.buf = e.enc.flush(e.buf[:0])
-	_, err := e.w.Write(e.buf)
+	if e.closed {
+		return e.err
+	}
+	e.closed = true
+	e.mark()
+	e.trace.start("jase93.Encode")
+	var err error
+	if e.batch != nil {
+		e.batch.add(e.enc.flush(e.batch.next()))
+		err = e.flushBatch()
+	} else {
+		e.buf = e.enc.flush(e.buf[:0])
+		var n int
+		n, err = e.w.Write(e.buf)
+		e.stats.EncodedBytes += int64(n)
+		recordEncode(0, n, err)
+	}
+	e.trace.end(e.Stats(), err)
+	e.err = err
 	return err
 }
 
-// ErrInvalidData indicates that non-jase93 characters were encountered while decoding.
+// Pending returns the number of bits of data written to the Encoder that have not yet been encoded, because they do not
+// fill a word. They are encoded as further data is written, or by Close. When Pending returns 0, the encoded data
+// written so far ends on a word boundary and represents all of the data written, so a delimiter can follow it without
+// closing the Encoder. Encoded data held by a batch is not counted; see Flush.
+func (e *Encoder) Pending() (bits int) {
+	return int(e.enc.bits.Len())
+}
+
+// ErrInvalidData indicates that invalid data was encountered while decoding. Errors returned while decoding match it
+// with errors.Is, and may be inspected further with errors.As.
 var ErrInvalidData = errors.New("jase93: invalid data")
 
 type decoder struct {
-	word      int16
-	state     uint32
-	stateBits uint8
+	encoding *Encoding
+	enc      *Encoding // encoding of the current stream, once its header is read
+	header   []byte
+	norm     normalizer
+	offset   int64
+	word     int16
+	bits     bitio.BitWriter
+	tail     []byte // decoded data held back as a possible trailer
+	raw      int64  // decoded data released before tail
+	words    int64
+	extra    int64
+	history  *history
+	ranges   []decodeRange // the lookup of a constant-time decoder; see ConstantTime
 }
 
 func (d *decoder) reset() {
+	if d.encoding == nil {
+		d.encoding = StdEncoding
+	}
+	d.enc = d.encoding
+	if d.encoding.header {
+		d.enc = nil
+		d.header = d.header[:0]
+	}
+	d.norm.reset()
+	d.offset = 0
 	d.word = -1
-	d.state = 0
-	d.stateBits = 0
+	d.bits.Reset()
+	d.tail = d.tail[:0]
+	d.raw = 0
+	d.words = 0
+	d.extra = 0
+	d.ranges = nil
+	if d.history != nil {
+		d.history.reset()
+	}
+}
+
+// readHeader consumes header characters from src, returning the remainder once the header is complete.
+func (d *decoder) readHeader(src []byte) ([]byte, error) {
+	n := 0
+	for ; n < len(src) && len(d.header) < HeaderLen; n++ {
+		if !d.encoding.skipped(src[n]) {
+			d.header = append(d.header, src[n])
+		}
+	}
+	d.offset += int64(n)
+	src = src[n:]
+
+	if len(d.header) == HeaderLen {
+		enc, err := parseHeader(d.header)
+		if err != nil {
+			return src, err
+		}
+		if d.encoding.ignoreSpace && enc.decode[' '] != -1 {
+			// Spaces in the data would be skipped
+			return src, &HeaderError{Header: string(d.header)}
+		}
+		d.enc = enc
+	}
+	return src, nil
 }
 
 // write decodes src and appends it to dst.
 func (d *decoder) write(dst, src []byte) ([]byte, error) {
-	for _, c := range src {
-		nibble := decode[c]
+	if d.encoding.lenient {
+		src = d.norm.normalize(src)
+	}
+	if d.history == nil {
+		return d.decode(dst, src)
+	}
+
+	offset := d.offset
+	dst, err := d.decode(dst, src)
+	n := len(src)
+	if err != nil && d.offset-offset < int64(n) {
+		// Record up to and including the character that failed
+		n = int(d.offset-offset) + 1
+	}
+	d.history.record(src[:n])
+	return dst, d.debugError(err)
+}
+
+// decode decodes normalized src and appends it to dst.
+func (d *decoder) decode(dst, src []byte) ([]byte, error) {
+	if d.enc == nil {
+		var err error
+		if src, err = d.readHeader(src); err != nil || d.enc == nil {
+			return dst, err
+		}
+	}
+
+	enc := d.enc
+	if d.encoding.constantTime {
+		return d.decodeConstantTime(dst, src, enc)
+	}
+	start := len(dst)
+
+	// Work on local copies of the state so it can be kept in registers
+	bits, words, extra := d.bits, d.words, d.extra
+
+	for i := 0; i < len(src); {
+		// Validate a block of characters from printable alphabets eight at a time, so it can be decoded without checking
+		// each one
+		if d.word == -1 && enc.printable {
+			for end := i + validPrintableLen(src[i:], swarBlockLen); i < end; i += 4 {
+				n := bits.WriteWord(uint32(enc.decode[src[i]])+uint32(enc.decode[src[i+1]])*enc.base, enc.wordBits, enc.wordFull)
+				n += bits.WriteWord(uint32(enc.decode[src[i+2]])+uint32(enc.decode[src[i+3]])*enc.base, enc.wordBits, enc.wordFull)
+				extra += int64(n - 2*enc.wordBits)
+				words += 2
+				dst = bits.AppendBytes(dst)
+			}
+			if i == len(src) {
+				break
+			}
+		}
+
+		// Consume four characters, or two words, at a time where possible. Invalid characters decode to -1, so a
+		// single sign test validates all four; any other input falls back to decoding one character at a time.
+		if d.word == -1 && len(src)-i >= 4 {
+			c0, c1 := enc.decode[src[i]], enc.decode[src[i+1]]
+			c2, c3 := enc.decode[src[i+2]], enc.decode[src[i+3]]
+			if c0|c1|c2|c3 >= 0 {
+				n := bits.WriteWord(uint32(c0)+uint32(c1)*enc.base, enc.wordBits, enc.wordFull)
+				n += bits.WriteWord(uint32(c2)+uint32(c3)*enc.base, enc.wordBits, enc.wordFull)
+				extra += int64(n - 2*enc.wordBits)
+				words += 2
+				dst = bits.AppendBytes(dst)
+				i += 4
+				continue
+			}
+		}
+
+		c := src[i]
+		i++
+		nibble := enc.decode[c]
 		if nibble == -1 {
-			return dst, ErrInvalidData
+			if d.encoding.skipped(c) {
+				continue
+			}
+			d.bits, d.words, d.extra = bits, words, extra
+			d.offset += int64(i - 1)
+			return dst, newCorruptInputError(d.offset, src[i-1:])
 		}
 
 		if d.word == -1 {
@@ -157,78 +571,160 @@ func (d *decoder) write(dst, src []byte) ([]byte, error) {
 			continue
 		}
 
-		d.word += int16(nibble) * int16(base)
+		d.word += int16(nibble) * int16(enc.base)
 
-		// If the lower wordBits aren't a full word, then we know this word includes an extra bit
-		currentWordBits := wordBits
-		if (uint32(d.word) & wordMask) < wordFull {
-			currentWordBits++
-		}
-
-		d.state |= uint32(d.word) << d.stateBits
-		d.stateBits += currentWordBits
-
-		for d.stateBits >= 8 {
-			dst = append(dst, byte(d.state))
-			d.state >>= 8
-			d.stateBits -= 8
-		}
+		extra += int64(bits.WriteWord(uint32(d.word), enc.wordBits, enc.wordFull) - enc.wordBits)
+		words++
+		dst = bits.AppendBytes(dst)
 
 		d.word = -1
 	}
 
-	return dst, nil
+	d.bits, d.words, d.extra = bits, words, extra
+	d.offset += int64(len(src))
+	return d.holdTrailer(dst, start), nil
 }
 
 // flush flushes the decoding state and appends it to dst.
-func (d *decoder) flush(dst []byte) []byte {
+func (d *decoder) flush(dst []byte) ([]byte, error) {
+	if len(d.norm.pending) > 0 {
+		// An incomplete character is never valid
+		dst, err := d.decode(dst, d.norm.pending)
+		return dst, d.debugError(err)
+	}
+
+	if d.enc == nil {
+		return dst, d.debugError(&HeaderError{Header: string(d.header)})
+	}
+
 	if d.word != -1 {
-		dst = append(dst, byte(d.state)|(byte(d.word)<<d.stateBits))
+		start := len(dst)
+		d.bits.WriteBits(uint32(d.word), 8)
+		dst = d.holdTrailer(d.bits.AppendBytes(dst), start)
+		d.word = -1
 	}
 
-	return dst
+	if d.encoding.trailer {
+		dst, err := d.verifyTrailer(dst)
+		return dst, d.debugError(err)
+	}
+	return dst, nil
 }
 
 // Decode decodes src and appends it to dst.
-func Decode(dst, src []byte) ([]byte, error) {
-	var dec decoder
+func (enc *Encoding) Decode(dst, src []byte) ([]byte, error) {
+	dec := decoder{encoding: enc}
 	dec.reset()
+	n := len(dst)
 	var err error
 	dst, err = dec.write(dst, src)
-	if err != nil {
-		return dst, err
+	if err == nil {
+		dst, err = dec.flush(dst)
+	}
+	recordDecode(int64(len(src)), len(dst)-n, err)
+	if err == nil && enc.checkDouble {
+		err = CheckDoubleEncoded(dst[n:])
 	}
-	return dec.flush(dst), nil
+	return dst, err
+}
+
+// Decode decodes src with StdEncoding and appends it to dst.
+func Decode(dst, src []byte) ([]byte, error) {
+	return StdEncoding.Decode(dst, src)
 }
 
 // Decoder decodes data from a wrapped io.Reader.
 type Decoder struct {
-	r   io.Reader
-	eof bool
-	dec decoder
-	buf []byte
+	r      io.Reader
+	eof    bool
+	dec    decoder
+	in     []byte
+	buf    []byte
+	stats  Stats
+	start  time.Time
+	trace  tracing
+	digest hash.Hash
+	limit  int
+	own    []byte          // the decoded data buffer allocated for limit
+	bits   bitio.BitReader // bits of decoded data not yet read by ReadBits
+	err    error           // the first error returned by Read other than io.EOF; see Close
+
+	// Encoding detection; see SetDetectEncoding
+	detect     int
+	undetected *Encoding // the Encoding the Decoder was created with, once the stream's has been detected
 }
 
 // NewDecoder creates a new Decoder that decodes from r.
+func (enc *Encoding) NewDecoder(r io.Reader) *Decoder {
+	d := new(Decoder)
+	d.dec.encoding = enc
+	return d.Reset(r)
+}
+
+// NewDecoder creates a new Decoder that decodes from r with StdEncoding.
 func NewDecoder(r io.Reader) *Decoder {
-	return new(Decoder).Reset(r)
+	return StdEncoding.NewDecoder(r)
 }
 
-// Reset sets the Decoder to decode from r and resets its decoding state.
+// Reset sets the Decoder to decode from r and resets its decoding state. The Encoding is retained.
 func (d *Decoder) Reset(r io.Reader) *Decoder {
+	d.trace.reset(d.Stats())
 	d.r = r
 	d.eof = false
+	d.bits.Reset()
+	if d.undetected != nil {
+		d.dec.encoding, d.undetected = d.undetected, nil
+	}
 	d.dec.reset()
-	d.buf = nil
+	d.buf = d.own
+	d.stats = Stats{}
+	d.start = time.Time{}
+	d.err = nil
 	return d
 }
 
-// Read decodes data from the wrapped io.Reader.
-func (d *Decoder) Read(data []byte) (n int, err error) {
+// SetReadBufferSize sets the number of encoded bytes the Decoder requests per Read of the wrapped io.Reader.
+// If n is not positive, each Read requests as many bytes as the caller's buffer holds, which is the default.
+func (d *Decoder) SetReadBufferSize(n int) {
+	d.in = nil
+	if n > 0 {
+		d.in = make([]byte, n)
+	}
+}
+
+// Read decodes data from the wrapped io.Reader. Any bits remaining in a byte partly read by ReadBits are discarded.
+func (d *Decoder) Read(data []byte) (int, error) {
+	d.bits.Reset()
+	return d.read(data)
+}
+
+func (d *Decoder) read(data []byte) (n int, err error) {
 	if len(data) == 0 {
 		return 0, nil
 	}
 
+	d.mark()
+	d.trace.start("jase93.Decode")
+	encoded := d.stats.EncodedBytes
+	out := data
+	defer func() {
+		d.stats.RawBytes += int64(n)
+		if d.digest != nil {
+			d.digest.Write(out[:n])
+		}
+		recordDecode(d.stats.EncodedBytes-encoded, n, err)
+		if err != nil {
+			d.trace.end(d.Stats(), err)
+		}
+		if err != nil && err != io.EOF && d.err == nil {
+			d.err = err
+		}
+	}()
+
+	if d.eof && len(d.buf) == 0 {
+		return 0, io.EOF
+	}
+
 	// Read outstanding data
 	if len(d.buf) > 0 {
 		n += copy(data, d.buf)
@@ -236,39 +732,168 @@ func (d *Decoder) Read(data []byte) (n int, err error) {
 		if n == len(d.buf) {
 			// All buffered data was read
 			d.buf = d.buf[:0]
+			if d.limit > 0 {
+				d.buf = d.own[:0]
+			}
 			if d.eof {
 				return n, io.EOF
 			}
 		} else {
-			copy(d.buf, d.buf[n:])
-			d.buf = d.buf[:len(d.buf)-n]
+			d.buf = d.buf[n:]
 			// data must have been too small
 			return
 		}
 	}
 
-	rn, rerr := d.r.Read(data)
-	if rn > 0 {
-		d.buf, err = d.dec.write(d.buf, data[:rn])
-	}
-
-	if rerr == io.EOF {
-		d.eof = true
-		d.buf = d.dec.flush(d.buf)
-	}
+	err = d.fill(data)
 
 	cn := copy(data, d.buf)
 	n += cn
 	if cn == len(d.buf) {
 		// All buffered data was read
 		d.buf = d.buf[:0]
-		if d.eof {
+		if d.limit > 0 {
+			d.buf = d.own[:0]
+		}
+		if d.eof && err == nil {
 			err = io.EOF
 		}
 	} else {
-		copy(d.buf, d.buf[cn:])
-		d.buf = d.buf[:len(d.buf)-cn]
+		d.buf = d.buf[cn:]
+	}
+
+	return
+}
+
+// ErrIncomplete indicates that a Decoder was closed before its stream was read to the end.
+var ErrIncomplete = errors.New("jase93: stream not read to the end")
+
+// Close reports whether the stream was complete and intact: it returns nil only if Read has returned io.EOF, and so the
+// stream ended on a valid boundary and any length trailer was verified. Otherwise, it returns the first error returned
+// by Read, or ErrIncomplete if the stream or the decoded data was not read to the end. This gives callers of io.Copy a
+// definitive signal. Close does not close the wrapped io.Reader, and the Decoder may be Reset and reused.
+func (d *Decoder) Close() error {
+	if d.err != nil {
+		return d.err
+	}
+	if !d.eof || len(d.buf) > 0 {
+		return ErrIncomplete
+	}
+	return nil
+}
+
+// Buffered returns the number of decoded bytes the Decoder holds, which Read returns without reading the wrapped
+// io.Reader, and whether the wrapped io.Reader has returned io.EOF. The Decoder reads ahead of the data returned by
+// Read, so the wrapped io.Reader can only be handed to another reader without losing data once eof is true; n then
+// reports how much decoded data remains to be read first. Decoded bytes held back as a possible length trailer are not
+// counted.
+func (d *Decoder) Buffered() (n int, eof bool) {
+	return len(d.buf), d.eof
+}
+
+// fill decodes more data into buf, using data as the read buffer by default.
+func (d *Decoder) fill(data []byte) error {
+	if d.detect > 0 && d.undetected == nil {
+		if err := d.detectEncoding(); err != nil {
+			return err
+		}
+	}
+
+	switch r := d.r.(type) {
+	case *bytes.Reader:
+		if d.limit == 0 {
+			return d.readAll(r, r.Len())
+		}
+	case *strings.Reader:
+		if d.limit == 0 {
+			return d.readAll(r, r.Len())
+		}
+	case *bufio.Reader:
+		return d.readBuffered(r)
+	}
+	return d.readChunk(data)
+}
+
+// readChunk decodes a single Read of the wrapped io.Reader, using data as the read buffer by default.
+func (d *Decoder) readChunk(data []byte) (err error) {
+	in := data
+	if d.in != nil {
+		in = d.in
+	}
+
+	rn, rerr := d.r.Read(in)
+	d.stats.EncodedBytes += int64(rn)
+	if rn > 0 {
+		d.buf, err = d.dec.write(d.buf, in[:rn])
+	}
+
+	if rerr == io.EOF {
+		d.eof = true
+		if err == nil {
+			d.buf, err = d.dec.flush(d.buf)
+		}
+	} else if rerr != nil && err == nil {
+		err = rerr
 	}
 
 	return
 }
+
+// readBuffered decodes the data buffered by r directly from its buffer, filling it first if it is empty, rather than
+// copying it into a read buffer.
+func (d *Decoder) readBuffered(r *bufio.Reader) (err error) {
+	var rerr error
+	if r.Buffered() == 0 {
+		_, rerr = r.Peek(1)
+	}
+
+	n := r.Buffered()
+	if d.in != nil && n > len(d.in) {
+		n = len(d.in)
+	}
+	in, _ := r.Peek(n)
+	d.stats.EncodedBytes += int64(n)
+	if n > 0 {
+		d.buf, err = d.dec.write(d.buf, in)
+	}
+	r.Discard(n)
+
+	if rerr == io.EOF {
+		d.eof = true
+		if err == nil {
+			d.buf, err = d.dec.flush(d.buf)
+		}
+	} else if rerr != nil && err == nil {
+		err = rerr
+	}
+
+	return
+}
+
+// readAll decodes the n bytes remaining in an in-memory reader in one pass, without copying them first.
+func (d *Decoder) readAll(r io.WriterTo, n int) error {
+	if cap(d.buf) < n {
+		d.buf = make([]byte, 0, n)
+	}
+
+	rn, err := r.WriteTo((*decoderWriter)(d))
+	d.stats.EncodedBytes += rn
+	if err != nil {
+		return err
+	}
+
+	d.eof = true
+	d.buf, err = d.dec.flush(d.buf)
+	return err
+}
+
+// decoderWriter decodes written data into the buffer of its Decoder.
+type decoderWriter Decoder
+
+func (w *decoderWriter) Write(data []byte) (int, error) {
+	var err error
+	if w.buf, err = w.dec.write(w.buf, data); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
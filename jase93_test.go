@@ -1,12 +1,16 @@
 package jase93
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/base64"
+	"errors"
 	"io"
 	"io/ioutil"
 	"math/rand"
+	"strings"
 	"testing"
+	"testing/iotest"
 	"testing/quick"
 )
 
@@ -23,6 +27,11 @@ func TestMaxEncodedLen(t *testing.T) {
 			t.Errorf("MaxEncodedLen(%d) = %d != %d", tc.n, l, tc.len)
 		}
 	}
+
+	// The header is included
+	if l := StdEncoding.WithHeader().MaxEncodedLen(1); l != 2+HeaderLen {
+		t.Errorf("WithHeader().MaxEncodedLen(1) = %d != %d", l, 2+HeaderLen)
+	}
 }
 
 func TestEncode(t *testing.T) {
@@ -80,7 +89,7 @@ func TestDecode(t *testing.T) {
 	} {
 		dec, err := Decode(nil, tc.in)
 		if err != nil {
-			if err != tc.err {
+			if !errors.Is(err, tc.err) {
 				t.Error(err)
 			}
 			continue
@@ -107,7 +116,7 @@ func TestDecoder(t *testing.T) {
 		dec := NewDecoder(src)
 
 		dst, err := ioutil.ReadAll(dec)
-		if err != tc.err {
+		if !errors.Is(err, tc.err) {
 			t.Error(err)
 			continue
 		}
@@ -139,6 +148,44 @@ func TestEncodeDecode(t *testing.T) {
 	t.Logf("%d / %d = %g", encBytes, inBytes, float64(encBytes)/float64(inBytes))
 }
 
+func TestSimplePacking(t *testing.T) {
+	enc := StdEncoding.WithPacking(SimplePacking)
+
+	for _, tc := range []struct {
+		in, out []byte
+	}{
+		{[]byte{}, []byte{}},
+		{[]byte{0x00}, []byte(" ")},
+		{[]byte{0x00, 0x00}, []byte("   ")},
+		{[]byte{0xff}, []byte("g#")},
+		{[]byte{0xff, 0xff}, []byte("(z(")},
+		{[]byte{0x00, 0x20}, []byte("  !")},
+	} {
+		out := enc.Encode(nil, tc.in)
+		if !bytes.Equal(out, tc.out) {
+			t.Errorf("Encode(%q) = %q != %q", tc.in, out, tc.out)
+		}
+	}
+
+	// Every word carries exactly WordBits bits, regardless of the data
+	zeros := enc.Encode(nil, make([]byte, 1<<16))
+	ones := enc.Encode(nil, bytes.Repeat([]byte{0xff}, 1<<16))
+	if len(zeros) != len(ones) {
+		t.Errorf("len(zeros) = %d != len(ones) = %d", len(zeros), len(ones))
+	}
+
+	if err := quick.Check(func(in []byte) bool {
+		dec, err := enc.Decode(nil, enc.Encode(nil, in))
+		if err != nil {
+			t.Error(err)
+			return false
+		}
+		return bytes.Equal(in, dec)
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestPartialDecode(t *testing.T) {
 	src := []byte(`Man is distinguished, not only by his reason, but by this singular passion from other animals, which is a lust of the mind, that by a perseverance of delight in the continued and indefatigable generation of knowledge, exceeds the short vehemence of any carnal pleasure.`)
 	enc := Encode(nil, src)
@@ -166,6 +213,111 @@ func TestPartialDecode(t *testing.T) {
 	}
 }
 
+type readSizes struct {
+	r     io.Reader
+	sizes []int
+}
+
+func (r *readSizes) Read(buf []byte) (int, error) {
+	r.sizes = append(r.sizes, len(buf))
+	return r.r.Read(buf)
+}
+
+func TestReadBufferSize(t *testing.T) {
+	src := bytes.Repeat([]byte{0xff}, 1000)
+	r := &readSizes{r: bytes.NewReader(Encode(nil, src))}
+	d := NewDecoder(r)
+	d.SetReadBufferSize(7)
+
+	var dec []byte
+	buf := make([]byte, 100)
+	for {
+		n, err := d.Read(buf)
+		dec = append(dec, buf[:n]...)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !bytes.Equal(dec, src) {
+		t.Errorf("Decoder = %x != %x", dec, src)
+	}
+	for _, size := range r.sizes {
+		if size != 7 {
+			t.Fatalf("read sizes %v", r.sizes)
+		}
+	}
+}
+
+func TestInMemoryDecoder(t *testing.T) {
+	src := bytes.Repeat([]byte("Man is distinguished"), 100)
+	enc := Encode(nil, src)
+
+	br := bytes.NewReader(enc)
+	sr := strings.NewReader(string(enc))
+	for _, r := range []interface {
+		io.Reader
+		Len() int
+	}{br, sr} {
+		d := NewDecoder(r)
+
+		buf := make([]byte, 10)
+		n, err := d.Read(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.Len() != 0 {
+			t.Errorf("%T has %d bytes remaining after first Read", r, r.Len())
+		}
+
+		rest, err := ioutil.ReadAll(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dec := append(buf[:n], rest...); !bytes.Equal(dec, src) {
+			t.Errorf("Decoder(%T) = %q != %q", r, dec, src)
+		}
+	}
+
+	if _, err := ioutil.ReadAll(NewDecoder(strings.NewReader(`g#"`))); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("Decoder(%q) = %v", `g#"`, err)
+	}
+}
+
+func TestBufferedDecoder(t *testing.T) {
+	src := bytes.Repeat([]byte("Man is distinguished"), 100)
+	enc := Encode(nil, src)
+
+	for _, size := range []int{16, 64, 4096} {
+		// A one-byte reader makes each fill of the bufio.Reader yield a single byte
+		for _, r := range []io.Reader{bytes.NewReader(enc), iotest.OneByteReader(bytes.NewReader(enc))} {
+			dec, err := ioutil.ReadAll(NewDecoder(bufio.NewReaderSize(r, size)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(dec, src) {
+				t.Errorf("size %d: Decoder(%T) = %q != %q", size, r, dec, src)
+			}
+		}
+	}
+
+	// Reads consume only what was buffered, leaving the rest in the wrapped io.Reader
+	br := bytes.NewReader(enc)
+	d := NewDecoder(bufio.NewReaderSize(br, 16))
+	if _, err := d.Read(make([]byte, 1)); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.Stats().EncodedBytes; got != 16 {
+		t.Errorf("EncodedBytes = %d after first Read, want 16", got)
+	}
+
+	if _, err := ioutil.ReadAll(NewDecoder(bufio.NewReader(strings.NewReader(`g#"`)))); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("Decoder(%q) = %v", `g#"`, err)
+	}
+}
+
 func TestVector(t *testing.T) {
 	src := []byte(`Man is distinguished, not only by his reason, but by this singular passion from other animals, which is a lust of the mind, that by a perseverance of delight in the continued and indefatigable generation of knowledge, exceeds the short vehemence of any carnal pleasure.`)
 	t.Log(string(src))
@@ -327,3 +479,255 @@ func BenchmarkBase64Decoder(b *testing.B) {
 
 	b.SetBytes(int64(b.N))
 }
+
+func TestWordConstants(t *testing.T) {
+	enc := StdEncoding
+	if Base != enc.base || WordMax != enc.wordMax || WordBits != enc.wordBits || WordFull != enc.wordFull {
+		t.Errorf("constants %d, %d, %d, %d != %d, %d, %d, %d", Base, WordMax, WordBits, WordFull, enc.base, enc.wordMax, enc.wordBits, enc.wordFull)
+	}
+}
+
+func TestWord(t *testing.T) {
+	for word := 0; word <= WordMax; word++ {
+		a, b := EncodeWord(uint16(word))
+		dec, ok := DecodeWord(a, b)
+		if !ok || dec != uint16(word) {
+			t.Fatalf("DecodeWord(EncodeWord(%d)) = %d, %t", word, dec, ok)
+		}
+	}
+
+	if _, ok := DecodeWord(' ', '"'); ok {
+		t.Error(`DecodeWord(' ', '"') succeeded`)
+	}
+
+	for _, tc := range []struct {
+		word uint16
+		bits int
+	}{
+		{0, 14},
+		{WordFull - 1, 14},
+		{WordFull, 13},
+		{1 << WordBits, 14},
+		{1<<WordBits - 1, 13},
+		{WordMax, 14},
+	} {
+		if bits := WordBitLen(tc.word); bits != tc.bits {
+			t.Errorf("WordBitLen(%d) = %d != %d", tc.word, bits, tc.bits)
+		}
+	}
+}
+
+func TestEncodingWord(t *testing.T) {
+	for _, enc := range []*Encoding{StdEncoding, StdEncoding.WithPacking(SimplePacking), SpaceFreeEncoding, HumanEncoding} {
+		for word := uint32(0); word <= enc.wordMax; word++ {
+			a, b := enc.EncodeWord(uint16(word))
+			dec, ok := enc.DecodeWord(a, b)
+			if !ok || dec != uint16(word) {
+				t.Fatalf("%q: DecodeWord(EncodeWord(%d)) = %d, %t", enc.encode, word, dec, ok)
+			}
+			if bits := enc.WordBitLen(uint16(word)); uint(bits) != enc.wordBitLen(a, b) {
+				t.Fatalf("%q: WordBitLen(%d) = %d", enc.encode, word, bits)
+			}
+			if stdA, stdB := EncodeWord(uint16(word)); enc == StdEncoding && (a != stdA || b != stdB || enc.WordBitLen(uint16(word)) != WordBitLen(uint16(word))) {
+				t.Fatalf("StdEncoding word %d differs from EncodeWord or WordBitLen", word)
+			}
+		}
+	}
+	if _, ok := SpaceFreeEncoding.DecodeWord('!', ' '); ok {
+		t.Error(`SpaceFreeEncoding.DecodeWord('!', ' ') succeeded`)
+	}
+}
+
+func TestEncoderClose(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+	e.Write([]byte("abc"))
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+	encoded := buf.String()
+
+	if err := e.Close(); err != nil || buf.String() != encoded {
+		t.Errorf("second Close = %v, output %q", err, buf.String())
+	}
+	if n, err := e.Write([]byte("d")); n != 0 || err != ErrWriteAfterClose || buf.String() != encoded {
+		t.Errorf("Write after Close = %d, %v, output %q", n, err, buf.String())
+	}
+
+	errFail := errors.New("fail")
+	e.Reset(&errWriter{errFail})
+	e.Write(nil)
+	if err := e.Close(); err != errFail {
+		t.Errorf("Close = %v", err)
+	}
+	if err := e.Close(); err != errFail {
+		t.Errorf("second Close = %v, want first result", err)
+	}
+}
+
+func TestDecoderClose(t *testing.T) {
+	src := []byte("Man is distinguished, not only by his reason")
+	encoded := StdEncoding.WithLengthTrailer().Encode(nil, src)
+
+	d := StdEncoding.WithLengthTrailer().NewDecoder(iotest.HalfReader(bytes.NewReader(encoded)))
+	if err := d.Close(); err != ErrIncomplete {
+		t.Errorf("Close before Read = %v, want %v", err, ErrIncomplete)
+	}
+	if _, err := io.Copy(ioutil.Discard, d); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Close(); err != nil {
+		t.Errorf("Close = %v", err)
+	}
+
+	// Decoded data not yet read
+	d.Reset(bytes.NewReader(encoded))
+	d.Read(make([]byte, 1))
+	if err := d.Close(); err != ErrIncomplete {
+		t.Errorf("Close after partial Read = %v, want %v", err, ErrIncomplete)
+	}
+
+	// A truncated trailer is reported even if the caller ignored the error
+	d.Reset(bytes.NewReader(encoded[:len(encoded)-1]))
+	ioutil.ReadAll(d)
+	if err := d.Close(); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("Close of truncated stream = %v", err)
+	}
+
+	// The first error is reported
+	d = NewDecoder(io.MultiReader(strings.NewReader(`ab"`), strings.NewReader("cd")))
+	ioutil.ReadAll(d)
+	ioutil.ReadAll(d)
+	var cerr *CorruptInputError
+	if err := d.Close(); !errors.As(err, &cerr) || cerr.Offset != 2 {
+		t.Errorf("Close of corrupt stream = %v", err)
+	}
+}
+
+func TestEncoderPending(t *testing.T) {
+	src := make([]byte, 100)
+	rand.New(rand.NewSource(0)).Read(src)
+
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+	boundaries := 0
+	for i := range src {
+		e.Write(src[i : i+1])
+
+		// Whole words have been written, carrying all but the pending bits
+		encoded := 0
+		for j := 0; j+1 < buf.Len(); j += 2 {
+			word, _ := DecodeWord(buf.Bytes()[j], buf.Bytes()[j+1])
+			encoded += WordBitLen(word)
+		}
+		if n := e.Pending(); n != 8*(i+1)-encoded || n > WordBits {
+			t.Fatalf("Pending after %d bytes = %d, want %d", i+1, n, 8*(i+1)-encoded)
+		}
+		if e.Pending() == 0 {
+			boundaries++
+			if dec, err := Decode(nil, buf.Bytes()); err != nil || !bytes.Equal(dec, src[:i+1]) {
+				t.Errorf("output at boundary after %d bytes decodes to %x, %v", i+1, dec, err)
+			}
+		}
+	}
+	if boundaries == 0 {
+		t.Error("no word boundaries")
+	}
+	if err := e.Close(); err != nil || e.Pending() != 0 {
+		t.Errorf("Pending after Close = %d, %v", e.Pending(), err)
+	}
+}
+
+func TestDecoderBuffered(t *testing.T) {
+	src := []byte("Man is distinguished, not only by his reason")
+	encoded := Encode(nil, src)
+
+	d := NewDecoder(bytes.NewReader(encoded))
+	if n, eof := d.Buffered(); n != 0 || eof {
+		t.Errorf("Buffered before Read = %d, %v", n, eof)
+	}
+	d.Read(make([]byte, 10))
+	if n, eof := d.Buffered(); n != len(src)-10 || !eof {
+		t.Errorf("Buffered = %d, %v, want %d, true", n, eof, len(src)-10)
+	}
+	io.Copy(ioutil.Discard, d)
+	if n, eof := d.Buffered(); n != 0 || !eof {
+		t.Errorf("Buffered at EOF = %d, %v", n, eof)
+	}
+
+	// The wrapped reader is not at EOF until it says so
+	d.Reset(iotest.OneByteReader(bytes.NewReader(encoded)))
+	d.Read(make([]byte, 1))
+	if _, eof := d.Buffered(); eof {
+		t.Error("Buffered reports EOF after one byte")
+	}
+}
+
+func TestEncodingDiv(t *testing.T) {
+	var alphabet []byte
+	for c := byte(0x20); len(alphabet) < 128; c++ {
+		alphabet = append(alphabet, c)
+	}
+
+	for base := 16; base <= 128; base++ {
+		enc := NewEncoding(string(alphabet[:base]))
+		for x := uint32(0); x < 1<<16; x++ {
+			if q := enc.div(x); q != x/uint32(base) {
+				t.Fatalf("base %d: div(%d) = %d, want %d", base, x, q, x/uint32(base))
+			}
+		}
+	}
+}
+
+func TestDecodeBatchFallback(t *testing.T) {
+	src := make([]byte, 64)
+	rand.New(rand.NewSource(0)).Read(src)
+	enc := Encode(nil, src)
+
+	// An invalid character anywhere within a batch of four is reported at its own offset
+	for i := range enc {
+		bad := append([]byte(nil), enc...)
+		bad[i] = '"'
+		_, err := Decode(nil, bad)
+		var cerr *CorruptInputError
+		if !errors.As(err, &cerr) || cerr.Offset != int64(i) {
+			t.Errorf("offset %d: err = %v", i, err)
+		}
+	}
+
+	// Line breaks at every alignment fall back to decoding one character at a time
+	for n := 1; n <= 8; n++ {
+		e := StdEncoding.WithLineLength(n)
+		dec, err := e.Decode(nil, e.Encode(nil, src))
+		if err != nil || !bytes.Equal(dec, src) {
+			t.Errorf("line length %d: Decode = %x, %v", n, dec, err)
+		}
+	}
+}
+
+func BenchmarkEncode(b *testing.B) {
+	src := make([]byte, 1<<20)
+	rand.New(rand.NewSource(0)).Read(src)
+	dst := make([]byte, 0, MaxEncodedLen(len(src)))
+	b.SetBytes(int64(len(src)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		Encode(dst[:0], src)
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	src := make([]byte, 1<<20)
+	rand.New(rand.NewSource(0)).Read(src)
+	encoded := Encode(nil, src)
+	dst := make([]byte, 0, len(src))
+	b.SetBytes(int64(len(encoded)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := Decode(dst[:0], encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Package jase93cbor bridges jase93 text in JSON and raw byte strings in CBOR (RFC 8949).
+//
+// Text implements the MarshalCBOR and UnmarshalCBOR methods used by CBOR libraries such as github.com/fxamacker/cbor,
+// so no tag registration is needed: a Text field is written as a byte string tagged with Tag, and read from a byte
+// string with or without that tag.
+package jase93cbor // import "github.com/jdknezek/jase93-go/jase93cbor"
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+// Tag is the CBOR tag number marking a byte string that is expected to be converted to jase93 text, analogous to the
+// base64 conversion tags 21-23 of RFC 8949. It is in the first-come-first-served range and not registered with IANA.
+const Tag = 93093
+
+// ErrInvalidCBOR indicates that a CBOR data item was malformed or was not a definite-length byte string.
+var ErrInvalidCBOR = errors.New("jase93cbor: invalid CBOR byte string")
+
+// CBOR major types
+const (
+	majorBytes = 2
+	majorTag   = 6
+)
+
+// Text is jase93-encoded text, as it appears in JSON, that is represented in CBOR by the bytes it encodes.
+type Text string
+
+// MarshalCBOR returns the CBOR encoding of the bytes t encodes, as a byte string tagged with Tag.
+func (t Text) MarshalCBOR() ([]byte, error) {
+	raw, err := jase93.Decode(nil, []byte(t))
+	if err != nil {
+		return nil, err
+	}
+
+	data := appendHead(make([]byte, 0, 14+len(raw)), majorTag, Tag)
+	data = appendHead(data, majorBytes, uint64(len(raw)))
+	return append(data, raw...), nil
+}
+
+// UnmarshalCBOR sets t to the jase93 encoding of the CBOR byte string data, which may be tagged with Tag.
+func (t *Text) UnmarshalCBOR(data []byte) error {
+	major, n, rest, err := parseHead(data)
+	if err != nil {
+		return err
+	}
+	if major == majorTag && n == Tag {
+		if major, n, rest, err = parseHead(rest); err != nil {
+			return err
+		}
+	}
+	if major != majorBytes || n != uint64(len(rest)) {
+		return ErrInvalidCBOR
+	}
+
+	*t = Text(jase93.Encode(nil, rest))
+	return nil
+}
+
+// Bytes returns the bytes t encodes.
+func (t Text) Bytes() ([]byte, error) {
+	return jase93.Decode(nil, []byte(t))
+}
+
+// appendHead appends the head of a data item of the major type with argument n.
+func appendHead(dst []byte, major byte, n uint64) []byte {
+	major <<= 5
+	switch {
+	case n < 24:
+		return append(dst, major|byte(n))
+	case n <= 0xff:
+		return append(dst, major|24, byte(n))
+	case n <= 0xffff:
+		dst = append(dst, major|25, 0, 0)
+		binary.BigEndian.PutUint16(dst[len(dst)-2:], uint16(n))
+	case n <= 0xffffffff:
+		dst = append(dst, major|26, 0, 0, 0, 0)
+		binary.BigEndian.PutUint32(dst[len(dst)-4:], uint32(n))
+	default:
+		dst = append(dst, major|27, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(dst[len(dst)-8:], n)
+	}
+	return dst
+}
+
+// parseHead parses the head of the data item at the start of data, returning its major type, argument, and the data
+// following the head. Indefinite lengths are not supported.
+func parseHead(data []byte) (major byte, n uint64, rest []byte, err error) {
+	if len(data) == 0 {
+		return 0, 0, nil, ErrInvalidCBOR
+	}
+	major, info := data[0]>>5, data[0]&0x1f
+	data = data[1:]
+
+	size := 0
+	switch {
+	case info < 24:
+		return major, uint64(info), data, nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	default:
+		return 0, 0, nil, ErrInvalidCBOR
+	}
+	if len(data) < size {
+		return 0, 0, nil, ErrInvalidCBOR
+	}
+
+	for _, c := range data[:size] {
+		n = n<<8 | uint64(c)
+	}
+	return major, n, data[size:], nil
+}
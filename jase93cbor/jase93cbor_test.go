@@ -0,0 +1,60 @@
+package jase93cbor
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+func TestTextMarshalCBOR(t *testing.T) {
+	for _, n := range []int{0, 1, 23, 24, 255, 256, 65536} {
+		raw := bytes.Repeat([]byte{0xa5}, n)
+		text := Text(jase93.Encode(nil, raw))
+
+		data, err := text.MarshalCBOR()
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Tag 93093 requires a 4-byte argument
+		if !bytes.HasPrefix(data, []byte{0xda, 0x00, 0x01, 0x6b, 0xa5}) || !bytes.HasSuffix(data, raw) {
+			t.Errorf("MarshalCBOR(%d bytes) = %x...", n, data[:8])
+		}
+
+		var got Text
+		if err := got.UnmarshalCBOR(data); err != nil || got != text {
+			t.Errorf("UnmarshalCBOR(%d bytes) = %q, %v", n, got, err)
+		}
+	}
+}
+
+func TestTextUnmarshalCBORUntagged(t *testing.T) {
+	var got Text
+	if err := got.UnmarshalCBOR([]byte{0x43, 'a', 'b', 'c'}); err != nil || got != Text(jase93.Encode(nil, []byte("abc"))) {
+		t.Errorf("UnmarshalCBOR = %q, %v", got, err)
+	}
+	if b, err := got.Bytes(); err != nil || string(b) != "abc" {
+		t.Errorf("Bytes = %q, %v", b, err)
+	}
+}
+
+func TestTextInvalid(t *testing.T) {
+	for _, data := range [][]byte{
+		nil,
+		{0x63, 'a', 'b', 'c'},   // text string
+		{0x44, 'a', 'b', 'c'},   // truncated
+		{0x5f, 0x41, 'a', 0xff}, // indefinite length
+		{0xc1, 0x41, 'a'},       // other tag
+		{0x58},
+	} {
+		var got Text
+		if err := got.UnmarshalCBOR(data); err != ErrInvalidCBOR {
+			t.Errorf("UnmarshalCBOR(%x) = %v", data, err)
+		}
+	}
+
+	if _, err := Text(`"`).MarshalCBOR(); !errors.Is(err, jase93.ErrInvalidData) {
+		t.Errorf("MarshalCBOR(invalid) = %v", err)
+	}
+}
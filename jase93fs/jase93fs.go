@@ -0,0 +1,210 @@
+// Package jase93fs converts file trees to and from JSON manifests of jase93-encoded file contents, so assets such as
+// those of an embed.FS can be shipped inside JSON configuration:
+//
+//	{
+//		"static/app.css": "<encoded contents>",
+//		"static/logo.png": "<encoded contents>"
+//	}
+//
+// Paths are slash-separated and relative to the root of the tree, as accepted by fs.ValidPath. Only regular files are
+// included; directories are implied by the paths of the files they contain.
+package jase93fs // import "github.com/jdknezek/jase93-go/jase93fs"
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+// Manifest maps the paths of files to their jase93-encoded contents.
+type Manifest map[string]string
+
+// Build reads every regular file in fsys into a Manifest.
+func Build(fsys fs.FS) (Manifest, error) {
+	m := make(Manifest)
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		m[name] = string(jase93.Encode(nil, data))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// WriteTo writes m to w as a JSON object with sorted keys.
+func (m Manifest) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	// Encoded contents never need escaping, but would have '<', '>', and '&' escaped by default
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "\t")
+	if err := enc.Encode(m); err != nil {
+		return 0, err
+	}
+	return buf.WriteTo(w)
+}
+
+// Read reads a Manifest from r, as written by Manifest.WriteTo or any other JSON encoder.
+func Read(r io.Reader) (Manifest, error) {
+	var m Manifest
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// FS decodes the files of m into an fs.FS, which also implements fs.ReadFileFS. It returns an error if any path is
+// invalid or any contents do not decode, naming the file.
+func (m Manifest) FS() (fs.FS, error) {
+	fsys := &manifestFS{files: make(map[string][]byte, len(m)), dirs: map[string][]fs.DirEntry{".": nil}}
+
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !fs.ValidPath(name) || name == "." {
+			return nil, &fs.PathError{Op: "load", Path: name, Err: fs.ErrInvalid}
+		}
+		if _, ok := fsys.dirs[name]; ok {
+			return nil, &fs.PathError{Op: "load", Path: name, Err: errIsDir}
+		}
+
+		data, err := jase93.Decode(nil, []byte(m[name]))
+		if err != nil {
+			return nil, &fs.PathError{Op: "load", Path: name, Err: err}
+		}
+		fsys.files[name] = data
+
+		// Add the file to its directory, creating any missing ancestors
+		entry := fs.FileInfoToDirEntry(fileInfo{name: path.Base(name), size: int64(len(data))})
+		for dir := path.Dir(name); ; dir = path.Dir(dir) {
+			if _, ok := fsys.files[dir]; ok {
+				return nil, &fs.PathError{Op: "load", Path: name, Err: errParentIsFile}
+			}
+			_, exists := fsys.dirs[dir]
+			fsys.dirs[dir] = append(fsys.dirs[dir], entry)
+			if exists || dir == "." {
+				break
+			}
+			entry = fs.FileInfoToDirEntry(fileInfo{name: path.Base(dir), dir: true})
+		}
+	}
+
+	// Names were added in order, but directories were added after their first file
+	for _, entries := range fsys.dirs {
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	}
+	return fsys, nil
+}
+
+var (
+	errIsDir        = errors.New("is a directory")
+	errParentIsFile = errors.New("parent is not a directory")
+)
+
+type manifestFS struct {
+	files map[string][]byte
+	dirs  map[string][]fs.DirEntry
+}
+
+func (f *manifestFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if data, ok := f.files[name]; ok {
+		return &file{info: fileInfo{name: path.Base(name), size: int64(len(data))}, r: bytes.NewReader(data)}, nil
+	}
+	if entries, ok := f.dirs[name]; ok {
+		return &dir{info: fileInfo{name: path.Base(name), dir: true}, entries: entries}, nil
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+func (f *manifestFS) ReadFile(name string) ([]byte, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrInvalid}
+	}
+	data, ok := f.files[name]
+	if !ok {
+		if _, ok := f.dirs[name]; ok {
+			return nil, &fs.PathError{Op: "read", Path: name, Err: errIsDir}
+		}
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+	}
+	return append([]byte(nil), data...), nil
+}
+
+// fileInfo describes a file or directory of a manifestFS, which are read-only and have no modification times.
+type fileInfo struct {
+	name string
+	size int64
+	dir  bool
+}
+
+func (i fileInfo) Name() string       { return i.name }
+func (i fileInfo) Size() int64        { return i.size }
+func (i fileInfo) ModTime() time.Time { return time.Time{} }
+func (i fileInfo) IsDir() bool        { return i.dir }
+func (i fileInfo) Sys() interface{}   { return nil }
+
+func (i fileInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0555
+	}
+	return 0444
+}
+
+type file struct {
+	info fileInfo
+	r    *bytes.Reader
+}
+
+func (f *file) Stat() (fs.FileInfo, error)                   { return f.info, nil }
+func (f *file) Read(data []byte) (int, error)                { return f.r.Read(data) }
+func (f *file) ReadAt(data []byte, off int64) (int, error)   { return f.r.ReadAt(data, off) }
+func (f *file) Seek(offset int64, whence int) (int64, error) { return f.r.Seek(offset, whence) }
+func (f *file) Close() error                                 { return nil }
+
+type dir struct {
+	info    fileInfo
+	entries []fs.DirEntry
+}
+
+func (d *dir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *dir) Close() error               { return nil }
+
+func (d *dir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: errIsDir}
+}
+
+// ReadDir returns the next n entries, or all remaining entries if n is not positive, as specified by fs.ReadDirFile.
+func (d *dir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if n <= 0 || n > len(d.entries) {
+		if n > 0 && len(d.entries) == 0 {
+			return nil, io.EOF
+		}
+		n = len(d.entries)
+	}
+	entries := d.entries[:n:n]
+	d.entries = d.entries[n:]
+	return entries, nil
+}
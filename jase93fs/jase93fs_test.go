@@ -0,0 +1,77 @@
+package jase93fs
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+func TestManifest(t *testing.T) {
+	src := fstest.MapFS{
+		"config.json":          {Data: []byte(`{"debug": true}`)},
+		"static/app.css":       {Data: []byte("body {}")},
+		"static/img/logo.png":  {Data: []byte("\x89PNG\r\n\x1a\n\x00\xff")},
+		"static/img/empty.gif": {Data: nil},
+		"static/img":           {Mode: fs.ModeDir},
+	}
+
+	m, err := Build(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m) != 4 {
+		t.Errorf("manifest has %d files, want 4", len(m))
+	}
+
+	var buf bytes.Buffer
+	if _, err := m.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(buf.Bytes(), []byte(`\u00`)) {
+		t.Errorf("manifest contains escapes: %s", buf.Bytes())
+	}
+
+	if m, err = Read(&buf); err != nil {
+		t.Fatal(err)
+	}
+	fsys, err := m.FS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fstest.TestFS(fsys, "config.json", "static/app.css", "static/img/logo.png", "static/img/empty.gif"); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, f := range src {
+		if f.Mode.IsDir() {
+			continue
+		}
+		if data, err := fs.ReadFile(fsys, name); err != nil || !bytes.Equal(data, f.Data) {
+			t.Errorf("ReadFile(%q) = %q, %v, want %q", name, data, err, f.Data)
+		}
+	}
+}
+
+func TestManifestFSInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		m    Manifest
+		path string
+		err  error
+	}{
+		{Manifest{"/abs": ""}, "/abs", fs.ErrInvalid},
+		{Manifest{"a/../b": ""}, "a/../b", fs.ErrInvalid},
+		{Manifest{"bad": `"`}, "bad", jase93.ErrInvalidData},
+		{Manifest{"a": "", "a/b": ""}, "a/b", errParentIsFile},
+		{Manifest{"a/b/c": "", "a/b": ""}, "a/b/c", errParentIsFile},
+	} {
+		_, err := tc.m.FS()
+		var pe *fs.PathError
+		if !errors.As(err, &pe) || pe.Path != tc.path || !errors.Is(err, tc.err) {
+			t.Errorf("%v.FS() = %v, want %v for %q", tc.m, err, tc.err, tc.path)
+		}
+	}
+}
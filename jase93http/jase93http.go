@@ -0,0 +1,162 @@
+// Package jase93http applies jase93 as an HTTP content coding, so services behind text-only API gateways and proxies
+// can exchange binary bodies transparently. The coding is named by ContentEncoding in the Content-Encoding and
+// Accept-Encoding headers, and applies after any other coding, such as gzip.
+package jase93http // import "github.com/jdknezek/jase93-go/jase93http"
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+// ContentEncoding is the content coding token for jase93-encoded bodies.
+const ContentEncoding = "jase93"
+
+// Transport is an http.RoundTripper that applies the jase93 content coding on top of another RoundTripper. It accepts
+// jase93-encoded responses and decodes them, and optionally encodes request bodies.
+//
+// Since Transport sets Accept-Encoding, the Base transport no longer requests and decompresses gzip transparently.
+type Transport struct {
+	// Base is the RoundTripper that sends requests. If nil, http.DefaultTransport is used.
+	Base http.RoundTripper
+
+	// Encoding encodes request bodies and decodes responses. If nil, jase93.StdEncoding is used.
+	Encoding *jase93.Encoding
+
+	// EncodeRequests sets the Transport to encode request bodies and mark them with Content-Encoding, for servers known
+	// to accept the coding; HTTP has no way to negotiate it for requests.
+	EncodeRequests bool
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base == nil {
+		return http.DefaultTransport
+	}
+	return t.Base
+}
+
+func (t *Transport) encoding() *jase93.Encoding {
+	if t.Encoding == nil {
+		return jase93.StdEncoding
+	}
+	return t.Encoding
+}
+
+// RoundTrip sends req with Accept-Encoding including ContentEncoding, and its body encoded if EncodeRequests is set.
+// If the response is jase93-encoded, its body is decoded as it is read, and ContentEncoding is removed from its
+// Content-Encoding header, as is Content-Length.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	if !hasCoding(req.Header.Values("Accept-Encoding"), ContentEncoding) {
+		addCoding(req.Header, "Accept-Encoding", ContentEncoding)
+	}
+
+	if t.EncodeRequests && req.Body != nil && req.Body != http.NoBody {
+		enc := t.encoding()
+		req.Body = encodeBody(enc, req.Body)
+		if getBody := req.GetBody; getBody != nil {
+			req.GetBody = func() (io.ReadCloser, error) {
+				body, err := getBody()
+				if err != nil {
+					return nil, err
+				}
+				return encodeBody(enc, body), nil
+			}
+		}
+		req.ContentLength = -1
+		req.Header.Del("Content-Length")
+		addCoding(req.Header, "Content-Encoding", ContentEncoding)
+	}
+
+	resp, err := t.base().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if removeLastCoding(resp.Header, "Content-Encoding", ContentEncoding) {
+		resp.Body = &decodedBody{d: t.encoding().NewDecoder(resp.Body), body: resp.Body}
+		resp.ContentLength = -1
+		resp.Header.Del("Content-Length")
+		resp.Uncompressed = true
+	}
+	return resp, nil
+}
+
+// encodeBody returns a body that reads the encoding of body, which it closes once read. Closing the result before then
+// stops the encoding and closes body.
+func encodeBody(enc *jase93.Encoding, body io.ReadCloser) io.ReadCloser {
+	w, r := enc.EncodePipe()
+	go func() {
+		_, err := io.Copy(w, body)
+		body.Close()
+		if err != nil {
+			w.(interface{ CloseWithError(error) error }).CloseWithError(err)
+			return
+		}
+		w.Close()
+	}()
+	return r
+}
+
+// decodedBody decodes a response body.
+type decodedBody struct {
+	d    *jase93.Decoder
+	body io.ReadCloser
+}
+
+func (b *decodedBody) Read(data []byte) (int, error) {
+	return b.d.Read(data)
+}
+
+func (b *decodedBody) Close() error {
+	return b.body.Close()
+}
+
+// codings returns the comma-separated content codings of header values, in order.
+func codings(values []string) []string {
+	var tokens []string
+	for _, v := range values {
+		for _, token := range strings.Split(v, ",") {
+			if token = strings.TrimSpace(token); token != "" {
+				tokens = append(tokens, token)
+			}
+		}
+	}
+	return tokens
+}
+
+// hasCoding reports whether the Accept-Encoding header values include coding, ignoring any quality value.
+func hasCoding(values []string, coding string) bool {
+	for _, token := range codings(values) {
+		if i := strings.IndexByte(token, ';'); i >= 0 {
+			token = strings.TrimSpace(token[:i])
+		}
+		if strings.EqualFold(token, coding) {
+			return true
+		}
+	}
+	return false
+}
+
+// addCoding appends coding to the header key.
+func addCoding(h http.Header, key, coding string) {
+	tokens := append(codings(h.Values(key)), coding)
+	h.Set(key, strings.Join(tokens, ", "))
+}
+
+// removeLastCoding removes coding from the header key if it was the last one applied, reporting whether it was.
+func removeLastCoding(h http.Header, key, coding string) bool {
+	tokens := codings(h.Values(key))
+	if len(tokens) == 0 || !strings.EqualFold(tokens[len(tokens)-1], coding) {
+		return false
+	}
+
+	if tokens = tokens[:len(tokens)-1]; len(tokens) > 0 {
+		h.Set(key, strings.Join(tokens, ", "))
+	} else {
+		h.Del(key)
+	}
+	return true
+}
@@ -0,0 +1,105 @@
+package jase93http
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+// echoHandler echoes request bodies, decoding and encoding them as negotiated.
+func echoHandler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		if r.Header.Get("Content-Encoding") == ContentEncoding {
+			if body, err = jase93.Decode(nil, body); err != nil {
+				t.Errorf("request body: %v", err)
+			}
+		}
+		if !hasCoding(r.Header.Values("Accept-Encoding"), ContentEncoding) {
+			w.Write(body)
+			return
+		}
+
+		w.Header().Set("Content-Encoding", ContentEncoding)
+		w.Write(jase93.Encode(nil, body))
+	})
+}
+
+func TestTransport(t *testing.T) {
+	srv := httptest.NewServer(echoHandler(t))
+	defer srv.Close()
+
+	src := make([]byte, 100000)
+	rand.New(rand.NewSource(0)).Read(src)
+
+	for _, encodeRequests := range []bool{false, true} {
+		client := &http.Client{Transport: &Transport{EncodeRequests: encodeRequests}}
+		var gotEncoding string
+		srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotEncoding = r.Header.Get("Content-Encoding")
+			echoHandler(t).ServeHTTP(w, r)
+		})
+
+		resp, err := client.Post(srv.URL, "application/octet-stream", bytes.NewReader(src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil || !bytes.Equal(body, src) {
+			t.Errorf("EncodeRequests %v: response body differs: %v", encodeRequests, err)
+		}
+		if ce := resp.Header.Get("Content-Encoding"); ce != "" || !resp.Uncompressed {
+			t.Errorf("EncodeRequests %v: Content-Encoding %q, Uncompressed %v", encodeRequests, ce, resp.Uncompressed)
+		}
+		if want := map[bool]string{false: "", true: ContentEncoding}[encodeRequests]; gotEncoding != want {
+			t.Errorf("EncodeRequests %v: request Content-Encoding %q, want %q", encodeRequests, gotEncoding, want)
+		}
+	}
+}
+
+func TestTransportUnencoded(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "jase93, identity")
+		w.Write([]byte("raw"))
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: &Transport{}}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "raw" || resp.Header.Get("Content-Encoding") != "jase93, identity" {
+		t.Errorf("body %q, Content-Encoding %q", body, resp.Header.Get("Content-Encoding"))
+	}
+}
+
+func TestCodings(t *testing.T) {
+	h := http.Header{}
+	h.Add("Content-Encoding", "gzip")
+	addCoding(h, "Content-Encoding", ContentEncoding)
+	if got := h.Get("Content-Encoding"); got != "gzip, jase93" {
+		t.Errorf("addCoding = %q", got)
+	}
+	if !removeLastCoding(h, "Content-Encoding", ContentEncoding) || h.Get("Content-Encoding") != "gzip" {
+		t.Errorf("removeLastCoding = %q", h.Get("Content-Encoding"))
+	}
+	if removeLastCoding(h, "Content-Encoding", ContentEncoding) {
+		t.Error("removeLastCoding removed gzip")
+	}
+
+	if !hasCoding([]string{"gzip, JASE93;q=0.5"}, ContentEncoding) || hasCoding([]string{"gzip", "br"}, ContentEncoding) {
+		t.Error("hasCoding")
+	}
+}
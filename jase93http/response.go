@@ -0,0 +1,121 @@
+package jase93http
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+// EncodeResponseWriter returns an http.ResponseWriter that encodes the body written to it with jase93.StdEncoding on the
+// fly, for handlers that stream binary data to clients that only accept text. When the header is written, it adds
+// ContentEncoding to Content-Encoding and removes Content-Length; if no Content-Type is set, it is detected from the
+// raw data first, as net/http would.
+//
+// The returned writer implements io.Closer, which must be called to write the end of the encoding once the body is
+// complete; Handler does so. It also implements http.Flusher and http.Hijacker, passing them through to w, and has an
+// Unwrap method for http.ResponseController. Flush writes everything but the final, incomplete word of the encoding
+// written so far.
+func EncodeResponseWriter(w http.ResponseWriter) http.ResponseWriter {
+	rw := &responseWriter{ResponseWriter: w}
+	rw.e = jase93.NewEncoder(rawWriter{w})
+	return rw
+}
+
+// Handler returns a handler that serves the responses of h encoded, with EncodeResponseWriter, to clients that accept
+// ContentEncoding, and unencoded to other clients. It adds Accept-Encoding to the Vary header of every response.
+func Handler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if !hasCoding(r.Header.Values("Accept-Encoding"), ContentEncoding) {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		ew := EncodeResponseWriter(w)
+		defer ew.(io.Closer).Close()
+		h.ServeHTTP(ew, r)
+	})
+}
+
+type responseWriter struct {
+	http.ResponseWriter
+	e           *jase93.Encoder
+	wroteHeader bool
+	encoded     bool // the response has a body to encode
+}
+
+// rawWriter writes encoded data to the wrapped ResponseWriter, bypassing the responseWriter.
+type rawWriter struct {
+	w http.ResponseWriter
+}
+
+func (w rawWriter) Write(data []byte) (int, error) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+	return w.w.Write(data)
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	if w.wroteHeader || code < 200 {
+		// Informational responses precede the final one
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.wroteHeader = true
+
+	if code != http.StatusNoContent && code != http.StatusNotModified {
+		w.encoded = true
+		h := w.Header()
+		addCoding(h, "Content-Encoding", ContentEncoding)
+		h.Del("Content-Length")
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(data []byte) (int, error) {
+	if !w.wroteHeader {
+		if _, ok := w.Header()["Content-Type"]; !ok && len(data) > 0 {
+			w.Header().Set("Content-Type", http.DetectContentType(data))
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.encoded {
+		return 0, http.ErrBodyNotAllowed
+	}
+	return w.e.Write(data)
+}
+
+// Close writes the end of the encoding, if a body was written.
+func (w *responseWriter) Close() error {
+	if !w.encoded {
+		return nil
+	}
+	return w.e.Close()
+}
+
+// Flush writes the header, if it has not been written, so that the response is marked as encoded before any of it
+// reaches the client.
+func (w *responseWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+	return nil, nil, http.ErrNotSupported
+}
+
+// Unwrap returns the wrapped ResponseWriter, for http.ResponseController.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
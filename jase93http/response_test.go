@@ -0,0 +1,147 @@
+package jase93http
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+func TestHandler(t *testing.T) {
+	src := make([]byte, 100000)
+	rand.New(rand.NewSource(0)).Read(src)
+	copy(src, "\x89PNG\x0d\x0a\x1a\x0a")
+
+	srv := httptest.NewServer(Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < len(src); i += 1000 {
+			w.Write(src[i : i+1000])
+			w.(http.Flusher).Flush()
+		}
+	})))
+	defer srv.Close()
+
+	// Clients that accept the coding get the body encoded
+	req, _ := http.NewRequest("GET", srv.URL, nil)
+	req.Header.Set("Accept-Encoding", ContentEncoding)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if dec, err := jase93.Decode(nil, body); err != nil || !bytes.Equal(dec, src) {
+		t.Errorf("encoded body does not decode: %v", err)
+	}
+	if ce, ct := resp.Header.Get("Content-Encoding"), resp.Header.Get("Content-Type"); ce != ContentEncoding || ct != "image/png" {
+		t.Errorf("Content-Encoding %q, Content-Type %q", ce, ct)
+	}
+	if vary := resp.Header.Get("Vary"); vary != "Accept-Encoding" {
+		t.Errorf("Vary %q", vary)
+	}
+
+	// As does Transport
+	resp, err = (&http.Client{Transport: &Transport{}}).Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !bytes.Equal(body, src) {
+		t.Error("Transport: body differs")
+	}
+
+	// Other clients do not
+	req.Header.Set("Accept-Encoding", "identity")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !bytes.Equal(body, src) || resp.Header.Get("Content-Encoding") != "" {
+		t.Errorf("unencoded body differs, Content-Encoding %q", resp.Header.Get("Content-Encoding"))
+	}
+}
+
+func TestHandlerFlushFirst(t *testing.T) {
+	// Streaming handlers, such as those serving server-sent events, often flush the header before writing anything
+	srv := httptest.NewServer(Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.(http.Flusher).Flush()
+		io.WriteString(w, "data: hello\n\n")
+	})))
+	defer srv.Close()
+
+	req, _ := http.NewRequest("GET", srv.URL, nil)
+	req.Header.Set("Accept-Encoding", ContentEncoding)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if ce := resp.Header.Get("Content-Encoding"); ce != ContentEncoding {
+		t.Errorf("Content-Encoding %q", ce)
+	}
+	if dec, err := jase93.Decode(nil, body); err != nil || string(dec) != "data: hello\n\n" {
+		t.Errorf("body decodes to %q, %v", dec, err)
+	}
+}
+
+func TestEncodeResponseWriter(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Content-Length", "3")
+	w := EncodeResponseWriter(rec)
+	w.Header().Set("Content-Type", "application/octet-stream")
+	io.WriteString(w, "abc")
+	w.(io.Closer).Close()
+
+	if got, want := rec.Body.String(), string(jase93.Encode(nil, []byte("abc"))); got != want {
+		t.Errorf("body %q, want %q", got, want)
+	}
+	if h := rec.Header(); h.Get("Content-Encoding") != ContentEncoding || h.Get("Content-Length") != "" || h.Get("Content-Type") != "application/octet-stream" {
+		t.Errorf("header %v", h)
+	}
+
+	// Responses without bodies are left alone
+	rec = httptest.NewRecorder()
+	w = EncodeResponseWriter(rec)
+	w.WriteHeader(http.StatusNoContent)
+	if _, err := w.Write([]byte("x")); err != http.ErrBodyNotAllowed {
+		t.Errorf("Write to 204 response = %v", err)
+	}
+	w.(io.Closer).Close()
+	if rec.Code != http.StatusNoContent || rec.Header().Get("Content-Encoding") != "" || rec.Body.Len() != 0 {
+		t.Errorf("204 response: %d %v %q", rec.Code, rec.Header(), rec.Body)
+	}
+
+	// Hijacking passes through
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, buf, err := EncodeResponseWriter(w).(http.Hijacker).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		buf.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 2\r\n\r\nhi")
+		buf.Flush()
+		conn.Close()
+	}))
+	defer srv.Close()
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "hi" {
+		t.Errorf("hijacked body %q", body)
+	}
+	if _, _, err := EncodeResponseWriter(httptest.NewRecorder()).(http.Hijacker).Hijack(); err != http.ErrNotSupported {
+		t.Errorf("Hijack of recorder = %v", err)
+	}
+}
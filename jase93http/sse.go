@@ -0,0 +1,195 @@
+package jase93http
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+// DefaultEventLineLen is the maximum number of encoded characters per data line used by NewEventWriter when lineLen is
+// not positive.
+const DefaultEventLineLen = 1024
+
+// EndEvent is the type of the event that marks the end of a stream written by an EventWriter.
+const EndEvent = "jase93-end"
+
+// EventWriter writes a binary stream as Server-Sent Events, which can only carry text, so it can ride over EventSource
+// connections. Each Write is encoded with jase93.StdEncoding and sent as one message event, split into data lines of
+// bounded length:
+//
+//	data: <encoded data>
+//	data: <encoded data>
+//
+// The stream is the concatenation of the data lines of the message events, ignoring line breaks, so browsers can
+// reassemble it by removing the newlines EventSource inserts. Close sends the end of the encoding, followed by an
+// EndEvent event, so truncated streams are detected.
+//
+// If the wrapped writer is an http.Flusher, such as an http.ResponseWriter, it is flushed after each event.
+type EventWriter struct {
+	w       io.Writer
+	e       *jase93.Encoder
+	encoded bytes.Buffer
+	lineLen int
+	event   []byte
+	closed  bool
+}
+
+// NewEventWriter creates an EventWriter that writes events to w with data lines of at most lineLen encoded
+// characters. If lineLen is not positive, DefaultEventLineLen is used. The caller sets any response headers, such as
+// Content-Type: text/event-stream.
+func NewEventWriter(w io.Writer, lineLen int) *EventWriter {
+	if lineLen <= 0 {
+		lineLen = DefaultEventLineLen
+	}
+	ew := &EventWriter{w: w, lineLen: lineLen}
+	ew.e = jase93.NewEncoder(&ew.encoded)
+	return ew
+}
+
+// Write encodes data and sends it as an event. The final, incomplete word of the encoding is held back until the next
+// Write or Close.
+func (w *EventWriter) Write(data []byte) (int, error) {
+	if w.closed {
+		return 0, jase93.ErrWriteAfterClose
+	}
+	w.e.Write(data)
+	return len(data), w.writeEvent("")
+}
+
+// Close sends the end of the encoding and an EndEvent event. It does not close the wrapped io.Writer.
+func (w *EventWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	w.e.Close()
+	if err := w.writeEvent(""); err != nil {
+		return err
+	}
+	// The empty data line makes browsers dispatch the event
+	return w.writeEvent(EndEvent)
+}
+
+// writeEvent sends the encoded data as an event of type typ, if there is any or the event is typed.
+func (w *EventWriter) writeEvent(typ string) error {
+	encoded := w.encoded.Bytes()
+	if len(encoded) == 0 && typ == "" {
+		return nil
+	}
+
+	b := w.event[:0]
+	if typ != "" {
+		b = append(b, "event: "+typ+"\ndata\n"...)
+	}
+	for len(encoded) > 0 {
+		n := w.lineLen
+		if n > len(encoded) {
+			n = len(encoded)
+		}
+		b = append(b, "data: "...)
+		b = append(b, encoded[:n]...)
+		b = append(b, '\n')
+		encoded = encoded[n:]
+	}
+	b = append(b, '\n')
+	w.event = b
+	w.encoded.Reset()
+
+	if _, err := w.w.Write(b); err != nil {
+		return err
+	}
+	if f, ok := w.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// NewEventReader creates a Decoder that reassembles and decodes the stream sent by an EventWriter from the
+// Server-Sent Events read from r, such as the body of a response. Events of types other than message and EndEvent are
+// ignored. Reads return io.ErrUnexpectedEOF if r ends before the EndEvent event.
+func NewEventReader(r io.Reader) *jase93.Decoder {
+	return jase93.NewDecoder(&eventDataReader{r: bufio.NewReader(r)})
+}
+
+// eventDataReader reads the concatenated data of the message events of an event stream.
+type eventDataReader struct {
+	r     *bufio.Reader
+	typ   []byte
+	data  []byte // the data of the current event
+	ready []byte // the data of dispatched events
+	end   bool
+}
+
+func (r *eventDataReader) Read(data []byte) (int, error) {
+	for len(r.ready) == 0 {
+		if r.end {
+			return 0, io.EOF
+		}
+		if err := r.readLine(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(data, r.ready)
+	r.ready = r.ready[n:]
+	return n, nil
+}
+
+// readLine reads and processes the next line of the event stream, which may end with "\n" or "\r\n". Lines ending
+// with a lone "\r", which EventSource also accepts, are not supported.
+func (r *eventDataReader) readLine() error {
+	line, err := r.r.ReadSlice('\n')
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	} else if err != nil && err != bufio.ErrBufferFull {
+		return err
+	}
+	long := err == bufio.ErrBufferFull
+	isData := r.processLine(bytes.TrimRight(line, "\r\n"))
+
+	// Take lines too long for the buffer in pieces, keeping only data
+	for long {
+		line, err = r.r.ReadSlice('\n')
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		} else if err != nil && err != bufio.ErrBufferFull {
+			return err
+		}
+		long = err == bufio.ErrBufferFull
+		if isData {
+			r.data = append(r.data, bytes.TrimRight(line, "\r\n")...)
+		}
+	}
+	return nil
+}
+
+// processLine processes a line of the event stream, reporting whether it is a data line.
+func (r *eventDataReader) processLine(line []byte) bool {
+	if len(line) == 0 {
+		// Dispatch the event
+		switch string(r.typ) {
+		case "", "message":
+			r.ready = append(r.ready, r.data...)
+		case EndEvent:
+			r.end = true
+		}
+		r.typ, r.data = r.typ[:0], r.data[:0]
+		return false
+	}
+
+	field, value := line, []byte(nil)
+	if i := bytes.IndexByte(line, ':'); i >= 0 {
+		field, value = line[:i], bytes.TrimPrefix(line[i+1:], []byte{' '})
+	}
+	switch string(field) {
+	case "data":
+		r.data = append(r.data, value...)
+		return true
+	case "event":
+		r.typ = append(r.typ[:0], value...)
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package jase93http
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEventWriter(t *testing.T) {
+	src := make([]byte, 10000)
+	rand.New(rand.NewSource(0)).Read(src)
+
+	var buf bytes.Buffer
+	w := NewEventWriter(&buf, 80)
+	for i := 0; i < len(src); i += 1000 {
+		w.Write(src[i : i+1000])
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	events := strings.Split(strings.TrimSuffix(buf.String(), "\n\n"), "\n\n")
+	if len(events) != 12 || events[11] != "event: "+EndEvent+"\ndata" {
+		t.Errorf("%d events, ending %q", len(events), events[len(events)-1])
+	}
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if strings.HasPrefix(line, "data: ") && len(line) > len("data: ")+80 {
+			t.Errorf("data line of %d bytes", len(line))
+		}
+	}
+
+	dec, err := ioutil.ReadAll(NewEventReader(&buf))
+	if err != nil || !bytes.Equal(dec, src) {
+		t.Errorf("NewEventReader = %v", err)
+	}
+}
+
+func TestEventReader(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewEventWriter(&buf, 10)
+	io.WriteString(w, "Man is distinguished, not only by his reason")
+	w.Close()
+	stream := buf.String()
+
+	// Other events, comments, fields, and CRLF line endings are tolerated
+	mixed := ": comment\r\nretry: 1000\r\nevent: ping\r\ndata: ignored\r\n\r\n" + strings.Replace(stream, "\n", "\r\n", -1)
+	if dec, err := ioutil.ReadAll(NewEventReader(strings.NewReader(mixed))); err != nil || string(dec) != "Man is distinguished, not only by his reason" {
+		t.Errorf("mixed stream = %q, %v", dec, err)
+	}
+
+	// Truncated streams are detected
+	truncated := strings.TrimSuffix(stream, "event: "+EndEvent+"\ndata\n\n")
+	if _, err := ioutil.ReadAll(NewEventReader(strings.NewReader(truncated))); err != io.ErrUnexpectedEOF {
+		t.Errorf("truncated stream = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+
+	// Lines longer than the read buffer
+	long := NewEventWriter(&buf, 100000)
+	buf.Reset()
+	src := make([]byte, 50000)
+	long.Write(src)
+	long.Close()
+	if dec, err := ioutil.ReadAll(NewEventReader(&buf)); err != nil || !bytes.Equal(dec, src) {
+		t.Errorf("long lines = %v", err)
+	}
+}
+
+func TestEventWriterHTTP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "text/event-stream")
+		w := NewEventWriter(rw, 0)
+		io.WriteString(w, "hello, ")
+		io.WriteString(w, "world")
+		w.Close()
+	}))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if dec, err := ioutil.ReadAll(NewEventReader(resp.Body)); err != nil || string(dec) != "hello, world" {
+		t.Errorf("got %q, %v", dec, err)
+	}
+}
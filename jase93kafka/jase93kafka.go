@@ -0,0 +1,140 @@
+// Package jase93kafka encodes Kafka message values as jase93 text, for clusters whose downstream consumers, such as
+// connectors and log sinks, require text-safe payloads. It depends on no Kafka client; its types have the method sets
+// that the common clients expect:
+//
+//   - Serde has the Serialize, Deserialize, and DeserializeInto methods of the serializers and deserializers of
+//     github.com/confluentinc/confluent-kafka-go.
+//   - Value implements the Encoder interface of github.com/IBM/sarama, for ProducerMessage values.
+//   - Encode and Decode convert the []byte values of github.com/twmb/franz-go records directly.
+//
+// Null values, which Kafka uses as tombstones in compacted topics, are passed through as null rather than encoded.
+package jase93kafka // import "github.com/jdknezek/jase93-go/jase93kafka"
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+// ErrUnsupportedType indicates that a value was not of a type that can be serialized or deserialized into.
+var ErrUnsupportedType = errors.New("jase93kafka: unsupported type")
+
+// Encode encodes a message value with jase93.StdEncoding. A nil value is returned as nil.
+func Encode(value []byte) []byte {
+	if value == nil {
+		return nil
+	}
+	return jase93.Encode(make([]byte, 0, jase93.MaxEncodedLen(len(value))), value)
+}
+
+// Decode decodes a message value encoded by Encode. A nil value is returned as nil.
+func Decode(value []byte) ([]byte, error) {
+	if value == nil {
+		return nil, nil
+	}
+	return jase93.Decode(make([]byte, 0, len(value)), value)
+}
+
+// Serde serializes and deserializes message values. The zero value uses jase93.StdEncoding.
+type Serde struct {
+	// Encoding encodes and decodes values. If nil, jase93.StdEncoding is used.
+	Encoding *jase93.Encoding
+}
+
+func (s Serde) encoding() *jase93.Encoding {
+	if s.Encoding == nil {
+		return jase93.StdEncoding
+	}
+	return s.Encoding
+}
+
+// Serialize encodes msg, which must be a []byte, a string, or an encoding.BinaryMarshaler, as the value of a message
+// for topic. A nil msg is serialized as a null value.
+func (s Serde) Serialize(topic string, msg interface{}) ([]byte, error) {
+	var data []byte
+	switch m := msg.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		if m == nil {
+			return nil, nil
+		}
+		data = m
+	case string:
+		data = []byte(m)
+	case encoding.BinaryMarshaler:
+		var err error
+		if data, err = m.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedType, msg)
+	}
+
+	enc := s.encoding()
+	return enc.Encode(make([]byte, 0, enc.MaxEncodedLen(len(data))), data), nil
+}
+
+// Deserialize decodes the value of a message from topic, returning it as a []byte. A null value is returned as nil.
+func (s Serde) Deserialize(topic string, payload []byte) (interface{}, error) {
+	if payload == nil {
+		return nil, nil
+	}
+	data, err := s.encoding().Decode(make([]byte, 0, len(payload)), payload)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// DeserializeInto decodes the value of a message from topic into msg, which must be a *[]byte, a *string, or an
+// encoding.BinaryUnmarshaler. A null value sets a *[]byte to nil and a *string to "", and is passed to an
+// encoding.BinaryUnmarshaler as nil.
+func (s Serde) DeserializeInto(topic string, payload []byte, msg interface{}) error {
+	var data []byte
+	if payload != nil {
+		var err error
+		if data, err = s.encoding().Decode(make([]byte, 0, len(payload)), payload); err != nil {
+			return err
+		}
+	}
+
+	switch m := msg.(type) {
+	case *[]byte:
+		*m = data
+	case *string:
+		*m = string(data)
+	case encoding.BinaryUnmarshaler:
+		return m.UnmarshalBinary(data)
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedType, msg)
+	}
+	return nil
+}
+
+// Value is an encoded message value, which implements the Encoder interface of sarama.
+type Value struct {
+	encoded []byte
+}
+
+// NewValue encodes data with jase93.StdEncoding as a Value.
+func NewValue(data []byte) Value {
+	return Value{encoded: jase93.Encode(nil, data)}
+}
+
+// Encode returns the encoded value.
+func (v Value) Encode() ([]byte, error) {
+	return v.encoded, nil
+}
+
+// Length returns the length of the encoded value.
+func (v Value) Length() int {
+	return len(v.encoded)
+}
+
+// String returns the encoded value.
+func (v Value) String() string {
+	return string(v.encoded)
+}
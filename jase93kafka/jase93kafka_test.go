@@ -0,0 +1,98 @@
+package jase93kafka
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jdknezek/jase93-go"
+)
+
+func TestEncode(t *testing.T) {
+	src := []byte("Man is distinguished, not only by his reason")
+	value := Encode(src)
+	if dec, err := Decode(value); err != nil || !bytes.Equal(dec, src) {
+		t.Errorf("Decode(Encode) = %q, %v", dec, err)
+	}
+
+	if Encode(nil) != nil {
+		t.Error("Encode(nil) is not nil")
+	}
+	if dec, err := Decode(nil); dec != nil || err != nil {
+		t.Errorf("Decode(nil) = %q, %v", dec, err)
+	}
+	if dec, err := Decode([]byte{}); dec == nil || err != nil {
+		t.Errorf("Decode(empty) = %#v, %v", dec, err)
+	}
+}
+
+func TestSerde(t *testing.T) {
+	var s Serde
+	src := []byte("Man is distinguished, not only by his reason")
+
+	for _, msg := range []interface{}{src, string(src)} {
+		value, err := s.Serialize("topic", msg)
+		if err != nil || !bytes.Equal(value, jase93.Encode(nil, src)) {
+			t.Errorf("Serialize(%T) = %q, %v", msg, value, err)
+		}
+		got, err := s.Deserialize("topic", value)
+		if err != nil || !bytes.Equal(got.([]byte), src) {
+			t.Errorf("Deserialize = %q, %v", got, err)
+		}
+	}
+
+	// Tombstones pass through
+	if value, err := s.Serialize("topic", nil); value != nil || err != nil {
+		t.Errorf("Serialize(nil) = %q, %v", value, err)
+	}
+	if got, err := s.Deserialize("topic", nil); got != nil || err != nil {
+		t.Errorf("Deserialize(nil) = %v, %v", got, err)
+	}
+
+	// BinaryMarshalers and BinaryUnmarshalers
+	tm := time.Date(2009, 11, 10, 23, 0, 0, 0, time.UTC)
+	value, err := s.Serialize("topic", tm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got time.Time
+	if err := s.DeserializeInto("topic", value, &got); err != nil || !got.Equal(tm) {
+		t.Errorf("DeserializeInto(*time.Time) = %v, %v", got, err)
+	}
+
+	var str string
+	if err := s.DeserializeInto("topic", jase93.Encode(nil, src), &str); err != nil || str != string(src) {
+		t.Errorf("DeserializeInto(*string) = %q, %v", str, err)
+	}
+
+	if _, err := s.Serialize("topic", 1); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Serialize(int) = %v", err)
+	}
+	var n int
+	if err := s.DeserializeInto("topic", value, &n); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("DeserializeInto(*int) = %v", err)
+	}
+	if _, err := s.Deserialize("topic", []byte(`"`)); !errors.Is(err, jase93.ErrInvalidData) {
+		t.Errorf("Deserialize(invalid) = %v", err)
+	}
+
+	// A custom Encoding
+	human := Serde{Encoding: jase93.HumanEncoding}
+	value, _ = human.Serialize("topic", src)
+	if !bytes.Equal(value, jase93.HumanEncoding.Encode(nil, src)) {
+		t.Errorf("Serialize with HumanEncoding = %q", value)
+	}
+}
+
+func TestValue(t *testing.T) {
+	src := []byte("Man is distinguished, not only by his reason")
+	v := NewValue(src)
+	encoded, err := v.Encode()
+	if err != nil || v.Length() != len(encoded) || v.String() != string(encoded) {
+		t.Errorf("Encode = %q, %v; Length = %d", encoded, err, v.Length())
+	}
+	if dec, err := Decode(encoded); err != nil || !bytes.Equal(dec, src) {
+		t.Errorf("Decode = %q, %v", dec, err)
+	}
+}
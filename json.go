@@ -0,0 +1,165 @@
+package jase93
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"unicode/utf8"
+)
+
+// ErrNotJSONString indicates that a JSON value was expected to be a string but was not.
+var ErrNotJSONString = errors.New("jase93: JSON value is not a string")
+
+// DecodeJSONString decodes the jase93-encoded JSON string value at the current position of dec and writes the
+// decoded bytes to w, without holding the whole string in memory.
+//
+// r must be the io.Reader dec was created with. dec cannot be advanced past a value it did not decode itself, so it
+// must not be used afterward; instead, rest reads the input following the closing quote.
+func DecodeJSONString(w io.Writer, dec *json.Decoder, r io.Reader) (n int64, rest io.Reader, err error) {
+	br := bufio.NewReader(io.MultiReader(dec.Buffered(), r))
+
+	if err = skipJSONSeparator(br); err != nil {
+		return 0, br, err
+	}
+
+	n, err = io.Copy(w, NewDecoder(&jsonStringReader{r: br}))
+	return n, br, err
+}
+
+// EncodeJSONString writes the contents of r to w as a single jase93-encoded JSON string value, including its quotes,
+// without holding the whole string in memory. jase93 output never needs escaping, so multi-gigabyte blobs can be
+// embedded in JSON as they are read. It returns the number of bytes read from r.
+//
+// encoding/json cannot write a value in pieces, so to embed the string in a larger document, write the rest of the
+// document to w directly, flushing any json.Encoder writing to w first:
+//
+//	io.WriteString(w, `{"name":"backup.tar","data":`)
+//	jase93.EncodeJSONString(w, f)
+//	io.WriteString(w, "}\n")
+func EncodeJSONString(w io.Writer, r io.Reader) (n int64, err error) {
+	if _, err = io.WriteString(w, `"`); err != nil {
+		return 0, err
+	}
+
+	enc := NewEncoder(w)
+	if n, err = io.Copy(enc, r); err != nil {
+		return n, err
+	}
+	if err = enc.Close(); err != nil {
+		return n, err
+	}
+
+	_, err = io.WriteString(w, `"`)
+	return n, err
+}
+
+// skipJSONSeparator skips whitespace, at most one ':' or ',', and the opening quote of a string.
+func skipJSONSeparator(br *bufio.Reader) error {
+	separated := false
+	for {
+		c, err := br.ReadByte()
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		} else if err != nil {
+			return err
+		}
+
+		switch c {
+		case ' ', '\t', '\n', '\r':
+		case ':', ',':
+			if separated {
+				return ErrNotJSONString
+			}
+			separated = true
+		case '"':
+			return nil
+		default:
+			return ErrNotJSONString
+		}
+	}
+}
+
+// jsonStringReader reads the unescaped contents of a JSON string up to its closing quote.
+type jsonStringReader struct {
+	r    *bufio.Reader
+	off  int64 // the number of unescaped bytes read
+	done bool
+}
+
+func (s *jsonStringReader) Read(data []byte) (n int, err error) {
+	defer func() { s.off += int64(n) }()
+	for n < len(data) && !s.done {
+		var c byte
+		c, err = s.r.ReadByte()
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return
+		}
+
+		switch c {
+		case '"':
+			s.done = true
+		case '\\':
+			var r rune
+			if r, err = s.readEscape(s.off + int64(n)); err != nil {
+				return
+			}
+			if r < utf8.RuneSelf {
+				data[n] = byte(r)
+				n++
+			} else {
+				// Never valid jase93, so only the lead byte matters to the decoder
+				var buf [utf8.UTFMax]byte
+				n += copy(data[n:], buf[:utf8.EncodeRune(buf[:], r)])
+			}
+		default:
+			data[n] = c
+			n++
+		}
+	}
+
+	if s.done && n == 0 {
+		err = io.EOF
+	}
+	return
+}
+
+// readEscape reads the remainder of an escape sequence following a backslash, at offset in the unescaped string. An
+// invalid escape sequence is reported as a *CorruptInputError for the backslash.
+func (s *jsonStringReader) readEscape(offset int64) (rune, error) {
+	c, err := s.r.ReadByte()
+	if err != nil {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	switch c {
+	case '"', '\\', '/':
+		return rune(c), nil
+	case 'b':
+		return '\b', nil
+	case 'f':
+		return '\f', nil
+	case 'n':
+		return '\n', nil
+	case 'r':
+		return '\r', nil
+	case 't':
+		return '\t', nil
+	case 'u':
+		var hex [4]byte
+		if _, err := io.ReadFull(s.r, hex[:]); err != nil {
+			return 0, io.ErrUnexpectedEOF
+		}
+		v, err := strconv.ParseUint(string(hex[:]), 16, 16)
+		if err != nil {
+			return 0, newCorruptInputError(offset, []byte{'\\'})
+		}
+		return rune(v), nil
+	}
+
+	return 0, newCorruptInputError(offset, []byte{'\\'})
+}
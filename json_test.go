@@ -0,0 +1,112 @@
+package jase93
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestDecodeJSONString(t *testing.T) {
+	src := []byte(`Man is distinguished, not only by his reason, but by this singular passion from other animals.`)
+
+	// encoding/json escapes <, >, and & by default, which must be unescaped while streaming
+	doc, err := json.Marshal(map[string]interface{}{"data": string(Encode(nil, src)), "zz": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(doc, []byte(`\u`)) {
+		t.Fatalf("expected escapes in %s", doc)
+	}
+
+	r := bytes.NewReader(doc)
+	dec := json.NewDecoder(r)
+	for i := 0; i < 2; i++ {
+		if _, err := dec.Token(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	n, rest, err := DecodeJSONString(&buf, dec, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(src)) || !bytes.Equal(buf.Bytes(), src) {
+		t.Errorf("DecodeJSONString(%s) = %q != %q", doc, buf.Bytes(), src)
+	}
+
+	tail, err := ioutil.ReadAll(rest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(tail) != `,"zz":1}` {
+		t.Errorf("rest = %q", tail)
+	}
+}
+
+func TestDecodeJSONStringErrors(t *testing.T) {
+	for _, tc := range []struct {
+		doc string
+		err error
+	}{
+		{`[1]`, ErrNotJSONString},
+		{`["g#`, nil},
+		{`["\""]`, ErrInvalidData},
+	} {
+		r := strings.NewReader(tc.doc)
+		dec := json.NewDecoder(r)
+		if _, err := dec.Token(); err != nil {
+			t.Fatal(err)
+		}
+
+		_, _, err := DecodeJSONString(ioutil.Discard, dec, r)
+		if tc.err == nil {
+			if err == nil {
+				t.Errorf("DecodeJSONString(%s) succeeded", tc.doc)
+			}
+		} else if !errors.Is(err, tc.err) {
+			t.Errorf("DecodeJSONString(%s) = %v != %v", tc.doc, err, tc.err)
+		}
+	}
+
+	// Invalid escapes are reported at their offset in the unescaped string
+	for _, doc := range []string{`["g#\q"]`, `["g#\u00zz"]`} {
+		r := strings.NewReader(doc)
+		dec := json.NewDecoder(r)
+		if _, err := dec.Token(); err != nil {
+			t.Fatal(err)
+		}
+
+		_, _, err := DecodeJSONString(ioutil.Discard, dec, r)
+		var cie *CorruptInputError
+		if !errors.As(err, &cie) || cie.Offset != 2 || cie.Char != '\\' || !errors.Is(err, ErrInvalidData) {
+			t.Errorf("DecodeJSONString(%s) = %v", doc, err)
+		}
+	}
+}
+
+func TestEncodeJSONString(t *testing.T) {
+	src := bytes.Repeat([]byte("\x00\xff<&>\"\\"), 10000)
+
+	var buf bytes.Buffer
+	buf.WriteString(`{"name":"blob","data":`)
+	n, err := EncodeJSONString(&buf, bytes.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf.WriteString("}")
+	if n != int64(len(src)) {
+		t.Errorf("EncodeJSONString = %d, want %d", n, len(src))
+	}
+
+	var doc struct{ Name, Data string }
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if dec, err := Decode(nil, []byte(doc.Data)); err != nil || !bytes.Equal(dec, src) {
+		t.Errorf("decoded data = %q, %v", dec, err)
+	}
+}
@@ -0,0 +1,98 @@
+package jase93
+
+import "unicode/utf8"
+
+// Lenient creates a new Encoding identical to enc except that decoding first maps common Unicode lookalikes of
+// alphabet characters to ASCII, such as the smart quotes, non-breaking spaces, dashes, and fullwidth punctuation
+// introduced by word processors. Zero-width spaces and byte order marks are removed. Offsets reported by errors refer
+// to the normalized input.
+func (enc Encoding) Lenient() *Encoding {
+	enc.lenient = true
+	return &enc
+}
+
+// lookalikes maps Unicode characters to the ASCII characters they resemble.
+var lookalikes = map[rune]string{
+	'\u00a0': " ",   // no-break space
+	'\u02bc': "'",   // modifier letter apostrophe
+	'\u2000': " ",   // en quad
+	'\u2001': " ",   // em quad
+	'\u2002': " ",   // en space
+	'\u2003': " ",   // em space
+	'\u2004': " ",   // three-per-em space
+	'\u2005': " ",   // four-per-em space
+	'\u2006': " ",   // six-per-em space
+	'\u2007': " ",   // figure space
+	'\u2008': " ",   // punctuation space
+	'\u2009': " ",   // thin space
+	'\u200a': " ",   // hair space
+	'\u200b': "",    // zero width space
+	'\u2010': "-",   // hyphen
+	'\u2011': "-",   // non-breaking hyphen
+	'\u2012': "-",   // figure dash
+	'\u2013': "-",   // en dash
+	'\u2014': "-",   // em dash
+	'\u2015': "-",   // horizontal bar
+	'\u2018': "'",   // left single quotation mark
+	'\u2019': "'",   // right single quotation mark
+	'\u201b': "'",   // single high-reversed-9 quotation mark
+	'\u2026': "...", // horizontal ellipsis
+	'\u202f': " ",   // narrow no-break space
+	'\u2032': "'",   // prime
+	'\u2035': "`",   // reversed prime
+	'\u2039': "<",   // single left-pointing angle quotation mark
+	'\u203a': ">",   // single right-pointing angle quotation mark
+	'\u205f': " ",   // medium mathematical space
+	'\u2212': "-",   // minus sign
+	'\u3000': " ",   // ideographic space
+	'\ufeff': "",    // zero width no-break space (byte order mark)
+}
+
+// normalizer maps Unicode lookalikes in UTF-8 input to ASCII.
+type normalizer struct {
+	pending []byte // an incomplete character at the end of the previous input
+	buf     []byte
+}
+
+func (n *normalizer) reset() {
+	n.pending = n.pending[:0]
+}
+
+// normalize returns the normalized src, holding back any incomplete character at its end.
+// The result is only valid until the next call.
+func (n *normalizer) normalize(src []byte) []byte {
+	if len(n.pending) > 0 {
+		src = append(n.pending, src...)
+		n.pending = n.pending[:0]
+	}
+
+	dst := n.buf[:0]
+	for i := 0; i < len(src); {
+		c := src[i]
+		if c < utf8.RuneSelf {
+			dst = append(dst, c)
+			i++
+			continue
+		}
+
+		if !utf8.FullRune(src[i:]) {
+			n.pending = append(n.pending, src[i:]...)
+			break
+		}
+
+		r, size := utf8.DecodeRune(src[i:])
+		if s, ok := lookalikes[r]; ok {
+			dst = append(dst, s...)
+		} else if r >= '\uff01' && r <= '\uff5e' {
+			// Fullwidth forms of ASCII
+			dst = append(dst, byte(r-'\uff01'+'!'))
+		} else {
+			// Leave the decoder to report the invalid character
+			dst = append(dst, src[i:i+size]...)
+		}
+		i += size
+	}
+
+	n.buf = dst
+	return dst
+}
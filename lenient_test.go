@@ -0,0 +1,50 @@
+package jase93
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"testing/iotest"
+)
+
+func TestLenient(t *testing.T) {
+	enc := StdEncoding.Lenient()
+
+	for _, tc := range []struct {
+		in, out string
+	}{
+		{"(z(", "(z("},
+		{"\u2018\u00a0\u2013\u2026\uff5e\ufeff", "' -...~"},
+		{"\uff01\uff03\uff5d", "!#}"},
+	} {
+		want, err := Decode(nil, []byte(tc.out))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dec, err := enc.Decode(nil, []byte(tc.in))
+		if err != nil || !bytes.Equal(dec, want) {
+			t.Errorf("Decode(%q) = %x, %v != %x", tc.in, dec, err, want)
+		}
+
+		// Characters split across reads are reassembled
+		dec, err = ioutil.ReadAll(enc.NewDecoder(iotest.OneByteReader(bytes.NewBufferString(tc.in))))
+		if err != nil || !bytes.Equal(dec, want) {
+			t.Errorf("Decoder(%q) = %x, %v != %x", tc.in, dec, err, want)
+		}
+	}
+
+	for _, in := range []string{"\u201c", "g#\xe2\x80"} {
+		if _, err := enc.Decode(nil, []byte(in)); !errors.Is(err, ErrInvalidData) {
+			t.Errorf("Decode(%q) = %v", in, err)
+		}
+		if _, err := ioutil.ReadAll(enc.NewDecoder(bytes.NewBufferString(in))); !errors.Is(err, ErrInvalidData) {
+			t.Errorf("Decoder(%q) = %v", in, err)
+		}
+	}
+
+	if _, err := StdEncoding.Decode(nil, []byte("\u2018")); err == nil {
+		t.Error("StdEncoding decoded a lookalike")
+	}
+}
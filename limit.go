@@ -0,0 +1,51 @@
+package jase93
+
+// MinMemoryLimit is the minimum limit accepted by Encoder.SetMemoryLimit and Decoder.SetMemoryLimit.
+const MinMemoryLimit = 2 * MinScratchLen
+
+// SetMemoryLimit limits the Encoder's buffers to a total of n bytes, which must be at least MinMemoryLimit, allocating
+// them up front. Writes larger than the buffers allow are encoded and written in pieces, so the limit holds however
+// the Encoder is used. This disables batching, which must not be re-enabled while limited. If n is not positive, the
+// buffers are allocated and grown as needed, which is the default. The limit is retained by Reset.
+func (e *Encoder) SetMemoryLimit(n int) {
+	if n <= 0 {
+		e.SetScratch(nil)
+		return
+	}
+	if n < MinMemoryLimit {
+		panic("jase93: memory limit must be at least MinMemoryLimit")
+	}
+
+	e.SetBatchSize(0)
+	if e.enc.encoding.lineLen > 0 {
+		// Half is needed to wrap lines; see SetScratch
+		n /= 2
+	}
+	e.SetScratch(make([]byte, 0, n))
+}
+
+// SetMemoryLimit limits the Decoder's read and decoded data buffers to a total of n bytes, which must be at least
+// MinMemoryLimit, allocating them up front. Each Read of the wrapped io.Reader requests at most half of n bytes, and
+// in-memory readers are decoded in pieces rather than in one pass, so the limit holds however the Decoder is used. This
+// replaces the read buffer size; see SetReadBufferSize. Lenient decoding and length trailers each use up to half of n
+// more, to normalize the input and hold back the trailer respectively. A sample for SetDetectEncoding larger than the
+// read buffer is not buffered; unless the io.Reader is a bufio.Reader that holds it, Read returns a *LimitExceededError
+// instead. Decoded data not yet read is discarded. If n is not positive, the buffers are allocated and grown as needed,
+// which is the default. The limit is retained by Reset.
+func (d *Decoder) SetMemoryLimit(n int) {
+	if n <= 0 {
+		d.limit, d.own = 0, nil
+		d.SetReadBufferSize(0)
+		return
+	}
+	if n < MinMemoryLimit {
+		panic("jase93: memory limit must be at least MinMemoryLimit")
+	}
+
+	// Decoding never produces more bytes than it consumes characters, beyond those held back by the final character
+	// and a trailer, which are released into the same buffer
+	d.limit = n
+	d.SetReadBufferSize(n/2 - maxTrailerLen - 1)
+	d.own = make([]byte, 0, n-len(d.in))
+	d.buf = d.own
+}
@@ -0,0 +1,88 @@
+package jase93
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestEncoderMemoryLimit(t *testing.T) {
+	src := make([]byte, 10000)
+	rand.New(rand.NewSource(0)).Read(src)
+
+	for _, enc := range []*Encoding{StdEncoding, StdEncoding.WithLineLength(MIMELineLen)} {
+		var out bytes.Buffer
+		e := enc.NewEncoder(&out)
+		e.SetBatchSize(1 << 20)
+		e.SetMemoryLimit(MinMemoryLimit)
+		if _, err := e.Write(src); err != nil {
+			t.Fatal(err)
+		}
+		if err := e.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		if want := enc.Encode(nil, src); !bytes.Equal(out.Bytes(), want) {
+			t.Errorf("line length %d: encoding differs", enc.lineLen)
+		}
+		if used := cap(e.buf) + cap(e.enc.scratch); used > MinMemoryLimit {
+			t.Errorf("line length %d: %d bytes of buffers, want at most %d", enc.lineLen, used, MinMemoryLimit)
+		}
+	}
+}
+
+func TestDecoderMemoryLimit(t *testing.T) {
+	src := make([]byte, 10000)
+	rand.New(rand.NewSource(0)).Read(src)
+	encoded := Encode(nil, src)
+
+	for _, r := range []io.Reader{
+		bytes.NewReader(encoded),
+		strings.NewReader(string(encoded)),
+		bufio.NewReader(bytes.NewReader(encoded)),
+	} {
+		d := NewDecoder(r)
+		d.SetMemoryLimit(MinMemoryLimit)
+
+		// Reading everything at once would normally decode an in-memory reader in one pass
+		dec, err := ioutil.ReadAll(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(dec, src) {
+			t.Errorf("%T: decoding differs", r)
+		}
+		if used := len(d.in) + cap(d.own); used > MinMemoryLimit {
+			t.Errorf("%T: %d bytes of buffers, want at most %d", r, used, MinMemoryLimit)
+		}
+		if cap(d.buf) > cap(d.own) {
+			t.Errorf("%T: decoded data buffer grew to %d bytes", r, cap(d.buf))
+		}
+	}
+}
+
+func TestDecoderMemoryLimitDetect(t *testing.T) {
+	src := make([]byte, 1000)
+	rand.New(rand.NewSource(0)).Read(src)
+	encoded := Encode(nil, src)
+
+	d := NewDecoder(bytes.NewReader(encoded))
+	d.SetMemoryLimit(MinMemoryLimit)
+	d.SetDetectEncoding(0)
+	var lerr *LimitExceededError
+	if _, err := d.Read(make([]byte, 100)); !errors.As(err, &lerr) || !errors.Is(err, ErrInvalidData) {
+		t.Errorf("Read = %v", err)
+	}
+
+	// A sample that fits in the read buffer is detected as usual
+	d.Reset(bytes.NewReader(encoded))
+	d.SetDetectEncoding(len(d.in))
+	if dec, err := ioutil.ReadAll(d); err != nil || !bytes.Equal(dec, src) {
+		t.Errorf("ReadAll = %d bytes, %v", len(dec), err)
+	}
+}
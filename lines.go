@@ -0,0 +1,48 @@
+package jase93
+
+// Line lengths for WithLineLength.
+const (
+	MIMELineLen = 76  // The maximum line length of MIME base64 bodies (RFC 2045)
+	SMTPLineLen = 998 // The maximum line length of SMTP mail (RFC 5322), excluding CRLF
+)
+
+// WithLineLength creates a new Encoding identical to enc except that encoded output is wrapped into lines of at most n
+// characters, each terminated by "\r\n", for use in mail bodies and other line-limited transports. When decoding, '\r'
+// and '\n' are skipped wherever they occur. If n is not positive, output is not wrapped.
+//
+// StdEncoding includes ' ', so transports that strip trailing whitespace from lines will corrupt the data.
+func (enc Encoding) WithLineLength(n int) *Encoding {
+	if n < 0 {
+		n = 0
+	}
+	enc.lineLen = n
+	return &enc
+}
+
+// IgnoreLineBreaks creates a new Encoding identical to enc except that decoding skips '\r' and '\n' wherever they
+// occur, as WithLineLength does, but encoded output is not wrapped. It decodes text wrapped to any line length, or
+// ending with a line break, as written by editors and by tools such as jase93 normalize.
+func (enc Encoding) IgnoreLineBreaks() *Encoding {
+	enc.ignoreBreaks = true
+	return &enc
+}
+
+// wrap wraps the output dst[start:] into lines if the Encoding requires it, continuing the current line.
+func (e *encoder) wrap(dst []byte, start int) []byte {
+	lineLen := e.encoding.lineLen
+	if lineLen == 0 || len(dst) == start {
+		return dst
+	}
+
+	e.scratch = append(e.scratch[:0], dst[start:]...)
+	dst = dst[:start]
+	for _, c := range e.scratch {
+		if e.col == lineLen {
+			dst = append(dst, '\r', '\n')
+			e.col = 0
+		}
+		dst = append(dst, c)
+		e.col++
+	}
+	return dst
+}
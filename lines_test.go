@@ -0,0 +1,105 @@
+package jase93
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestWithLineLength(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for _, lineLen := range []int{1, 2, 3, MIMELineLen, SMTPLineLen} {
+		enc := StdEncoding.WithLineLength(lineLen)
+		for _, n := range []int{0, 1, 57, 1000, 5000} {
+			src := make([]byte, n)
+			rng.Read(src)
+
+			encoded := enc.Encode(nil, src)
+			if len(encoded) > enc.MaxEncodedLen(n) {
+				t.Errorf("lineLen %d: len(Encode(%d bytes)) = %d > MaxEncodedLen %d", lineLen, n, len(encoded), enc.MaxEncodedLen(n))
+			}
+
+			unwrapped := Encode(nil, src)
+			if got := strings.Replace(string(encoded), "\r\n", "", -1); got != string(unwrapped) {
+				t.Errorf("lineLen %d: Encode(%d bytes) unwrapped differs", lineLen, n)
+			}
+			if n > 0 && !bytes.HasSuffix(encoded, []byte("\r\n")) {
+				t.Errorf("lineLen %d: Encode(%d bytes) does not end with CRLF", lineLen, n)
+			}
+			for _, line := range strings.Split(strings.TrimSuffix(string(encoded), "\r\n"), "\r\n") {
+				if len(line) > lineLen || strings.ContainsAny(line, "\r\n") {
+					t.Fatalf("lineLen %d: line %q", lineLen, line)
+				}
+			}
+
+			var buf bytes.Buffer
+			e := enc.NewEncoder(&buf)
+			for i := 0; i < n; i += 7 {
+				end := i + 7
+				if end > n {
+					end = n
+				}
+				e.Write(src[i:end])
+			}
+			e.Close()
+			if !bytes.Equal(buf.Bytes(), encoded) {
+				t.Errorf("lineLen %d: Encoder(%d bytes) differs from Encode", lineLen, n)
+			}
+
+			dec, err := ioutil.ReadAll(enc.NewDecoder(iotest.OneByteReader(bytes.NewReader(encoded))))
+			if err != nil || !bytes.Equal(dec, src) {
+				t.Errorf("lineLen %d: Decoder(%d bytes) = %d bytes, %v", lineLen, n, len(dec), err)
+			}
+		}
+	}
+}
+
+func TestWithLineLengthTolerant(t *testing.T) {
+	src := []byte("Man is distinguished, not only by his reason")
+	encoded := string(Encode(nil, src))
+	mangled := "\n" + encoded[:5] + "\n" + encoded[5:20] + "\r\r\n" + encoded[20:] + "\n\n"
+
+	if dec, err := StdEncoding.WithLineLength(SMTPLineLen).Decode(nil, []byte(mangled)); err != nil || !bytes.Equal(dec, src) {
+		t.Errorf("Decode = %q, %v", dec, err)
+	}
+	if _, err := Decode(nil, []byte(mangled)); err == nil {
+		t.Error("Decode without line length accepted newlines")
+	}
+}
+
+func TestWithLineLengthHeader(t *testing.T) {
+	enc := StdEncoding.WithHeader().WithLineLength(4)
+	encoded := enc.Encode(nil, []byte("abcdef"))
+	if !bytes.HasPrefix(encoded, []byte("aa")) || bytes.Index(encoded, []byte("\r\n")) != 4 {
+		t.Errorf("Encode = %q", encoded)
+	}
+	if dec, err := enc.Decode(nil, encoded); err != nil || string(dec) != "abcdef" {
+		t.Errorf("Decode = %q, %v", dec, err)
+	}
+}
+
+func TestIgnoreLineBreaks(t *testing.T) {
+	src := make([]byte, 1000)
+	rand.New(rand.NewSource(1)).Read(src)
+	enc := StdEncoding.IgnoreLineBreaks()
+
+	if got := enc.Encode(nil, src); !bytes.Equal(got, Encode(nil, src)) {
+		t.Error("Encode wraps output")
+	}
+	for _, encoded := range [][]byte{
+		StdEncoding.WithLineLength(MIMELineLen).Encode(nil, src),
+		StdEncoding.WithLineLength(7).Encode(nil, src),
+		append(Encode(nil, src), '\n'),
+	} {
+		if dec, err := enc.Decode(nil, encoded); err != nil || !bytes.Equal(dec, src) {
+			t.Errorf("Decode = %d bytes, %v", len(dec), err)
+		}
+		if dec, err := ioutil.ReadAll(enc.NewDecoder(iotest.OneByteReader(bytes.NewReader(encoded)))); err != nil || !bytes.Equal(dec, src) {
+			t.Errorf("Decoder = %d bytes, %v", len(dec), err)
+		}
+	}
+}
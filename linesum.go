@@ -0,0 +1,52 @@
+package jase93
+
+import "io"
+
+const (
+	lineChecksumHeader = "Line-Checksum"
+	lineChecksumCRC8   = "CRC-8"
+)
+
+// ArmorWithLineChecksums is like Armor, but appends a 2-character checksum to each line of encoded data, so that
+// errors in data transcribed by hand, such as a backup read aloud over the phone, can be located to the line. Lines
+// are ArmorLineLen characters long, plus their checksums, and the block is marked by a "Line-Checksum: CRC-8" header,
+// which may not be included in headers.
+//
+// Each checksum is the CRC-8 of the line's characters, initialized with the 0-based index of the line modulo 256 so
+// that swapped lines are detected too, and encoded as a word with SpaceFreeEncoding.EncodeWord. Dearmor verifies the
+// checksums automatically, returning a *LineChecksumError from the Body of a block with a mistyped line.
+func ArmorWithLineChecksums(w io.Writer, blockType string, headers map[string]string) (io.WriteCloser, error) {
+	return armor(w, blockType, headers, true)
+}
+
+// verifyLine verifies the checksum of the next line of the body and returns its data.
+func (a *armorBodyReader) verifyLine(line string) (string, error) {
+	index := a.index
+	a.index++
+
+	n := len(line) - 2
+	if n < 1 {
+		return "", &LineChecksumError{Line: a.lines.n - 1}
+	}
+	if sum, ok := SpaceFreeEncoding.DecodeWord(line[n], line[n+1]); !ok || sum != uint16(crc8(uint8(index), []byte(line[:n]))) {
+		return "", &LineChecksumError{Line: a.lines.n - 1}
+	}
+	return line[:n], nil
+}
+
+const crc8Poly = 0x07
+
+// crc8 updates the CRC-8 crc, with polynomial x^8 + x^2 + x + 1, with data.
+func crc8(crc uint8, data []byte) uint8 {
+	for _, c := range data {
+		crc ^= c
+		for i := 0; i < 8; i++ {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ crc8Poly
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
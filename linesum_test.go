@@ -0,0 +1,74 @@
+package jase93
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestCRC8(t *testing.T) {
+	if crc := crc8(0, []byte("123456789")); crc != 0xf4 {
+		t.Errorf("crc8 = %#x != 0xf4", crc)
+	}
+}
+
+func TestArmorWithLineChecksums(t *testing.T) {
+	src := make([]byte, 300)
+	rand.New(rand.NewSource(0)).Read(src)
+
+	var buf bytes.Buffer
+	w, err := ArmorWithLineChecksums(&buf, "BACKUP", map[string]string{"Comment": "read aloud"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write(src)
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	armored := buf.String()
+	lines := strings.Split(armored, "\n")
+	if lines[2] != "Line-Checksum: CRC-8" || len(lines[4]) != ArmorLineLen+2 {
+		t.Fatalf("ArmorWithLineChecksums = %q", armored)
+	}
+
+	block, err := Dearmor(strings.NewReader(armored))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(block.Header) != 1 {
+		t.Errorf("Header = %q", block.Header)
+	}
+	if dec, err := ioutil.ReadAll(block.Body); err != nil || !bytes.Equal(dec, src) {
+		t.Errorf("Dearmor = %x, %v", dec, err)
+	}
+
+	// Lines 5 through 9 hold data; the 7th line of the input is its third
+	mistyped := append([]string(nil), lines...)
+	mistyped[6] = strings.Replace(mistyped[6], mistyped[6][10:11], "~", 1)
+	swapped := append([]string(nil), lines...)
+	swapped[5], swapped[6] = swapped[6], swapped[5]
+
+	for _, tc := range []struct {
+		lines []string
+		line  int
+	}{
+		{mistyped, 7},
+		{swapped, 6},
+	} {
+		block, err := Dearmor(strings.NewReader(strings.Join(tc.lines, "\n")))
+		if err == nil {
+			_, err = ioutil.ReadAll(block.Body)
+		}
+		var lerr *LineChecksumError
+		if !errors.As(err, &lerr) || lerr.Line != tc.line || !errors.Is(err, ErrChecksum) {
+			t.Errorf("Dearmor = %v, want a checksum mismatch at line %d", err, tc.line)
+		}
+	}
+
+	if _, err := ArmorWithLineChecksums(&buf, "BACKUP", map[string]string{"Line-Checksum": "CRC-8"}); err != ErrInvalidArmor {
+		t.Errorf("ArmorWithLineChecksums(Line-Checksum header) = %v", err)
+	}
+}
@@ -0,0 +1,178 @@
+package jase93
+
+import (
+	"crypto/hmac"
+	"encoding/binary"
+	"hash"
+	"io"
+)
+
+// DefaultMACWindow is the number of bytes of data per record used by NewMACWriter and NewMACReader when window is not
+// positive.
+const DefaultMACWindow = 64 << 10
+
+// MACWriter encodes a stream authenticated with a message authentication code, such as HMAC-SHA256, so that a
+// MACReader can verify it in a single pass without releasing unverified data.
+//
+// The data is divided into records of window bytes, and each is followed by its tag: the MAC of the record's sequence
+// number as 8 big-endian bytes, a byte that is 1 for the final record and 0 otherwise, and the record's data. Every
+// record but the final one holds exactly window bytes, so the final one, which may be empty, holds fewer, and a stream
+// truncated at a record boundary is detected. The records and tags are encoded as one stream.
+type MACWriter struct {
+	e      *Encoder
+	mac    hash.Hash
+	window int
+	buf    []byte
+	tag    []byte
+	seq    uint64
+	closed bool
+	err    error
+}
+
+// NewMACWriter creates a MACWriter that encodes to w with enc, authenticating each window bytes of data with mac,
+// such as hmac.New(sha256.New, key). If window is not positive, DefaultMACWindow is used. Both ends must use the same
+// window.
+func (enc *Encoding) NewMACWriter(w io.Writer, mac hash.Hash, window int) *MACWriter {
+	if window <= 0 {
+		window = DefaultMACWindow
+	}
+	return &MACWriter{e: enc.NewEncoder(w), mac: mac, window: window, buf: make([]byte, 0, window)}
+}
+
+// NewMACWriter creates a MACWriter that encodes to w with StdEncoding; see Encoding.NewMACWriter.
+func NewMACWriter(w io.Writer, mac hash.Hash, window int) *MACWriter {
+	return StdEncoding.NewMACWriter(w, mac, window)
+}
+
+// Write encodes data, writing each record once it is complete. It returns ErrWriteAfterClose if the MACWriter has been
+// closed.
+func (m *MACWriter) Write(data []byte) (int, error) {
+	if m.closed {
+		return 0, ErrWriteAfterClose
+	}
+	written := 0
+	for len(data) > 0 {
+		n := copy(m.buf[len(m.buf):m.window], data)
+		m.buf = m.buf[:len(m.buf)+n]
+		data = data[n:]
+		if len(m.buf) == m.window {
+			if err := m.writeRecord(false); err != nil {
+				return written, err
+			}
+		}
+		written += n
+	}
+	return written, nil
+}
+
+// Close writes the final record and flushes the encoding. It does not close the wrapped io.Writer. Subsequent calls do
+// nothing and return the result of the first.
+func (m *MACWriter) Close() error {
+	if m.closed {
+		return m.err
+	}
+	m.closed = true
+	m.err = m.writeRecord(true)
+	if err := m.e.Close(); m.err == nil {
+		m.err = err
+	}
+	return m.err
+}
+
+// writeRecord writes the buffered data and its tag.
+func (m *MACWriter) writeRecord(final bool) error {
+	m.tag = macTag(m.tag[:0], m.mac, m.seq, final, m.buf)
+	if _, err := m.e.Write(m.buf); err != nil {
+		return err
+	}
+	if _, err := m.e.Write(m.tag); err != nil {
+		return err
+	}
+	m.seq++
+	m.buf = m.buf[:0]
+	return nil
+}
+
+// macTag appends the tag of a record to dst.
+func macTag(dst []byte, mac hash.Hash, seq uint64, final bool, data []byte) []byte {
+	var header [9]byte
+	binary.BigEndian.PutUint64(header[:8], seq)
+	if final {
+		header[8] = 1
+	}
+	mac.Reset()
+	mac.Write(header[:])
+	mac.Write(data)
+	return mac.Sum(dst)
+}
+
+// MACReader decodes a stream written by a MACWriter, returning the data of each record only once its tag has been
+// verified, so no more than one record of unverified data, window bytes, is ever held, and none is returned. Reads
+// return a *MACError at the first record that fails verification, including a final record that was truncated or is
+// missing.
+type MACReader struct {
+	d      *Decoder
+	mac    hash.Hash
+	window int
+	rec    []byte
+	tag    []byte
+	out    []byte // verified data not yet returned
+	seq    uint64
+	final  bool
+	err    error
+}
+
+// NewMACReader creates a MACReader that decodes from r with enc, verifying each record with mac, which must be keyed
+// as the MACWriter's was. If window is not positive, DefaultMACWindow is used.
+func (enc *Encoding) NewMACReader(r io.Reader, mac hash.Hash, window int) *MACReader {
+	if window <= 0 {
+		window = DefaultMACWindow
+	}
+	return &MACReader{d: enc.NewDecoder(r), mac: mac, window: window, rec: make([]byte, window+mac.Size())}
+}
+
+// NewMACReader creates a MACReader that decodes from r with StdEncoding; see Encoding.NewMACReader.
+func NewMACReader(r io.Reader, mac hash.Hash, window int) *MACReader {
+	return StdEncoding.NewMACReader(r, mac, window)
+}
+
+// Read reads verified data.
+func (m *MACReader) Read(data []byte) (int, error) {
+	for len(m.out) == 0 {
+		if m.err != nil {
+			return 0, m.err
+		}
+		if m.final {
+			return 0, io.EOF
+		}
+		m.err = m.readRecord()
+	}
+	n := copy(data, m.out)
+	m.out = m.out[n:]
+	return n, nil
+}
+
+// readRecord reads and verifies the next record.
+func (m *MACReader) readRecord() error {
+	n, err := io.ReadFull(m.d, m.rec)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		// Only the final record is short
+		m.final, err = true, nil
+	}
+	if err != nil {
+		return err
+	}
+
+	size := m.mac.Size()
+	if n < size {
+		return &MACError{Record: m.seq}
+	}
+	data, tag := m.rec[:n-size], m.rec[n-size:n]
+	m.tag = macTag(m.tag[:0], m.mac, m.seq, m.final, data)
+	if !hmac.Equal(tag, m.tag) {
+		return &MACError{Record: m.seq}
+	}
+	m.seq++
+	m.out = data
+	return nil
+}
@@ -0,0 +1,96 @@
+package jase93
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"testing"
+	"testing/iotest"
+)
+
+func TestMAC(t *testing.T) {
+	key := []byte("key")
+	src := make([]byte, 1000)
+	rand.New(rand.NewSource(0)).Read(src)
+
+	for _, n := range []int{0, 1, 99, 100, 101, 1000} {
+		var buf bytes.Buffer
+		w := NewMACWriter(&buf, hmac.New(sha256.New, key), 100)
+		if _, err := w.Write(src[:n]); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		r := NewMACReader(iotest.HalfReader(&buf), hmac.New(sha256.New, key), 100)
+		if got, err := ioutil.ReadAll(r); err != nil || !bytes.Equal(got, src[:n]) {
+			t.Errorf("%d bytes: read %d bytes, %v", n, len(got), err)
+		}
+	}
+}
+
+func TestMACErrors(t *testing.T) {
+	key := []byte("key")
+	src := make([]byte, 1000)
+	rand.New(rand.NewSource(0)).Read(src)
+
+	var buf bytes.Buffer
+	w := NewMACWriter(&buf, hmac.New(sha256.New, key), 100)
+	w.Write(src)
+	w.Close()
+	encoded := buf.Bytes()
+
+	// Each record decodes to 132 bytes, about 162 characters
+	tampered := append([]byte(nil), encoded...)
+	tampered[500] = encoded[501]
+	if tampered[500] == encoded[500] {
+		tampered[500] = encoded[499]
+	}
+
+	for _, tc := range []struct {
+		name    string
+		encoded []byte
+		key     string
+		record  uint64
+		valid   int // the number of bytes returned before the error
+	}{
+		{"tampered", tampered, "key", 3, 300},
+		{"wrong key", encoded, "other", 0, 0},
+		{"truncated", encoded[:len(encoded)-200], "key", 9, 900},
+		{"empty", nil, "key", 0, 0},
+	} {
+		r := NewMACReader(bytes.NewReader(tc.encoded), hmac.New(sha256.New, []byte(tc.key)), 100)
+		got, err := ioutil.ReadAll(r)
+		var merr *MACError
+		if !errors.As(err, &merr) || merr.Record != tc.record || !errors.Is(err, ErrChecksum) {
+			t.Errorf("%s: ReadAll = %v, want record %d", tc.name, err, tc.record)
+		}
+		if !bytes.Equal(got, src[:tc.valid]) {
+			t.Errorf("%s: read %d bytes before the error, want %d", tc.name, len(got), tc.valid)
+		}
+		if _, err := r.Read(make([]byte, 1)); err != io.EOF && !errors.As(err, &merr) {
+			t.Errorf("%s: Read after error = %v", tc.name, err)
+		}
+	}
+
+	// A stream truncated at a record boundary lacks its final record
+	buf.Reset()
+	w = NewMACWriter(&buf, hmac.New(sha256.New, key), 100)
+	w.Write(src[:100])
+	w.e.Close()
+	got, err := ioutil.ReadAll(NewMACReader(&buf, hmac.New(sha256.New, key), 100))
+	var merr *MACError
+	if !errors.As(err, &merr) || merr.Record != 1 || !bytes.Equal(got, src[:100]) {
+		t.Errorf("ReadAll(without final record) = %d bytes, %v", len(got), err)
+	}
+
+	w.Close()
+	if _, err := w.Write(src); err != ErrWriteAfterClose {
+		t.Errorf("Write after Close = %v", err)
+	}
+}
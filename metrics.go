@@ -0,0 +1,86 @@
+package jase93
+
+import (
+	"errors"
+	"io"
+	"sync/atomic"
+)
+
+// Metrics receives counts of the work done by every Encoding, Encoder, and Decoder once installed with SetMetrics.
+// Implementations must be safe for concurrent use, and can forward the counts to expvar, Prometheus, or similar.
+type Metrics interface {
+	// AddEncoded counts raw bytes encoded into encoded bytes.
+	AddEncoded(raw, encoded int64)
+	// AddDecoded counts encoded bytes decoded into raw bytes.
+	AddDecoded(encoded, raw int64)
+	// AddError counts an error of the given kind, as returned by ErrorKind.
+	AddError(kind string)
+}
+
+type metricsHolder struct {
+	m Metrics
+}
+
+var metrics atomic.Value
+
+// SetMetrics installs m to receive counts from all subsequent operations. A nil m disables metrics.
+func SetMetrics(m Metrics) {
+	metrics.Store(metricsHolder{m})
+}
+
+func currentMetrics() Metrics {
+	h, _ := metrics.Load().(metricsHolder)
+	return h.m
+}
+
+// ErrorKind classifies err for metrics as one of "corrupt_input", "header", "checksum", "armor", "invalid_data", or
+// "io" for errors from a wrapped io.Reader or io.Writer.
+func ErrorKind(err error) string {
+	var cie *CorruptInputError
+	var he *HeaderError
+	var ce *ChecksumError
+	var ae *ArmorError
+	switch {
+	case errors.As(err, &cie):
+		return "corrupt_input"
+	case errors.As(err, &he):
+		return "header"
+	case errors.As(err, &ce):
+		return "checksum"
+	case errors.As(err, &ae):
+		return "armor"
+	case errors.Is(err, ErrInvalidData):
+		return "invalid_data"
+	}
+	return "io"
+}
+
+// recordEncode reports an encoding operation to the installed Metrics, if any.
+func recordEncode(raw, encoded int, err error) {
+	m := currentMetrics()
+	if m == nil {
+		return
+	}
+
+	if raw != 0 || encoded != 0 {
+		m.AddEncoded(int64(raw), int64(encoded))
+	}
+	if err != nil {
+		m.AddError(ErrorKind(err))
+	}
+}
+
+// recordDecode reports a decoding operation to the installed Metrics, if any.
+func recordDecode(encoded int64, raw int, err error) {
+	m := currentMetrics()
+	if m == nil {
+		return
+	}
+
+	if encoded != 0 || raw != 0 {
+		m.AddDecoded(encoded, int64(raw))
+	}
+	if err != nil && err != io.EOF {
+		m.AddError(ErrorKind(err))
+	}
+}
@@ -0,0 +1,66 @@
+package jase93
+
+import (
+	"bytes"
+	"io/ioutil"
+	"sync"
+	"testing"
+)
+
+type testMetrics struct {
+	sync.Mutex
+	encodedRaw, encoded int64
+	decoded, decodedRaw int64
+	errors              map[string]int
+}
+
+func (m *testMetrics) AddEncoded(raw, encoded int64) {
+	m.Lock()
+	defer m.Unlock()
+	m.encodedRaw += raw
+	m.encoded += encoded
+}
+
+func (m *testMetrics) AddDecoded(encoded, raw int64) {
+	m.Lock()
+	defer m.Unlock()
+	m.decoded += encoded
+	m.decodedRaw += raw
+}
+
+func (m *testMetrics) AddError(kind string) {
+	m.Lock()
+	defer m.Unlock()
+	m.errors[kind]++
+}
+
+func TestMetrics(t *testing.T) {
+	m := &testMetrics{errors: make(map[string]int)}
+	SetMetrics(m)
+	defer SetMetrics(nil)
+
+	src := []byte("Man is distinguished")
+
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+	e.Write(src)
+	e.Close()
+	enc := buf.Bytes()
+
+	if _, err := ioutil.ReadAll(NewDecoder(&buf)); err != nil {
+		t.Fatal(err)
+	}
+	ioutil.ReadAll(NewDecoder(bytes.NewBufferString(`g#"`)))
+	StdEncoding.WithHeader().Decode(nil, []byte("zz"))
+
+	if m.encodedRaw != int64(len(src)) || m.encoded != int64(len(enc)) {
+		t.Errorf("encoded %d -> %d", m.encodedRaw, m.encoded)
+	}
+	// g# decodes to a byte before the invalid character is reached
+	if m.decoded != int64(len(enc)+3+2) || m.decodedRaw != int64(len(src)+1) {
+		t.Errorf("decoded %d -> %d", m.decoded, m.decodedRaw)
+	}
+	if m.errors["corrupt_input"] != 1 || m.errors["header"] != 1 || len(m.errors) != 2 {
+		t.Errorf("errors = %v", m.errors)
+	}
+}
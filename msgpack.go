@@ -0,0 +1,89 @@
+package jase93
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// ErrInvalidMsgpack indicates that a msgpack object was malformed or was not bin or nil.
+var ErrInvalidMsgpack = errors.New("jase93: invalid msgpack bin")
+
+// msgpack format bytes
+const (
+	msgpackNil   = 0xc0
+	msgpackBin8  = 0xc4
+	msgpackBin16 = 0xc5
+	msgpackBin32 = 0xc6
+)
+
+// MarshalMsgpack implements msgpack.Marshaler of github.com/vmihailenco/msgpack, writing b as raw bin, or nil if b is
+// nil. Within msgpack, the bytes need no encoding.
+func (b Bytes) MarshalMsgpack() ([]byte, error) {
+	return b.MarshalMsg(nil)
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler of github.com/vmihailenco/msgpack.
+func (b *Bytes) UnmarshalMsgpack(data []byte) error {
+	rest, err := b.UnmarshalMsg(data)
+	if err == nil && len(rest) > 0 {
+		err = ErrInvalidMsgpack
+	}
+	return err
+}
+
+// MarshalMsg implements msgp.Marshaler of github.com/tinylib/msgp, appending b to dst as raw bin, or nil if b is nil.
+func (b Bytes) MarshalMsg(dst []byte) ([]byte, error) {
+	n := len(b)
+	switch {
+	case b == nil:
+		return append(dst, msgpackNil), nil
+	case n <= 0xff:
+		dst = append(dst, msgpackBin8, byte(n))
+	case n <= 0xffff:
+		dst = append(dst, msgpackBin16, byte(n>>8), byte(n))
+	default:
+		dst = append(dst, msgpackBin32, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+	return append(dst, b...), nil
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler of github.com/tinylib/msgp, returning the data following the object.
+func (b *Bytes) UnmarshalMsg(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return data, ErrInvalidMsgpack
+	}
+
+	var size, n int
+	switch data[0] {
+	case msgpackNil:
+		*b = nil
+		return data[1:], nil
+	case msgpackBin8:
+		size = 1
+	case msgpackBin16:
+		size = 2
+	case msgpackBin32:
+		size = 4
+	default:
+		return data, ErrInvalidMsgpack
+	}
+	if len(data) < 1+size {
+		return data, ErrInvalidMsgpack
+	}
+
+	var head [4]byte
+	copy(head[4-size:], data[1:1+size])
+	n = int(binary.BigEndian.Uint32(head[:]))
+	if n < 0 || len(data)-1-size < n {
+		return data, ErrInvalidMsgpack
+	}
+
+	data = data[1+size:]
+	*b = append((*b)[:0], data[:n]...)
+	return data[n:], nil
+}
+
+// Msgsize implements msgp.Sizer of github.com/tinylib/msgp, returning an upper bound on the size of b in msgpack.
+func (b Bytes) Msgsize() int {
+	return 5 + len(b)
+}
@@ -0,0 +1,58 @@
+package jase93
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBytesMsgpack(t *testing.T) {
+	for _, tt := range []struct {
+		n    int
+		head []byte
+	}{
+		{0, []byte{0xc4, 0}},
+		{255, []byte{0xc4, 0xff}},
+		{256, []byte{0xc5, 1, 0}},
+		{65536, []byte{0xc6, 0, 1, 0, 0}},
+	} {
+		in := Bytes(bytes.Repeat([]byte{0x5a}, tt.n))
+		data, err := in.MarshalMsgpack()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.HasPrefix(data, tt.head) || len(data) != len(tt.head)+tt.n || len(data) > in.Msgsize() {
+			t.Errorf("MarshalMsgpack(%d bytes) = %x...", tt.n, data[:len(tt.head)])
+		}
+
+		var out Bytes
+		if err := out.UnmarshalMsgpack(data); err != nil || !bytes.Equal(out, in) {
+			t.Errorf("UnmarshalMsgpack(%d bytes) = %d bytes, %v", tt.n, len(out), err)
+		}
+	}
+}
+
+func TestBytesMsg(t *testing.T) {
+	data, _ := Bytes(nil).MarshalMsg([]byte{0x93})
+	data, _ = Bytes("abc").MarshalMsg(data)
+	if want := []byte{0x93, 0xc0, 0xc4, 3, 'a', 'b', 'c'}; !bytes.Equal(data, want) {
+		t.Fatalf("MarshalMsg = %x, want %x", data, want)
+	}
+
+	out := Bytes("x")
+	rest, err := out.UnmarshalMsg(data[1:])
+	if err != nil || out != nil {
+		t.Errorf("UnmarshalMsg(nil) = %q, %v", out, err)
+	}
+	if rest, err = out.UnmarshalMsg(rest); err != nil || string(out) != "abc" || len(rest) != 0 {
+		t.Errorf("UnmarshalMsg = %q, %x, %v", out, rest, err)
+	}
+}
+
+func TestBytesMsgpackInvalid(t *testing.T) {
+	for _, data := range [][]byte{nil, {0xa3, 'a', 'b', 'c'}, {0xc4, 4, 'a'}, {0xc5, 0}, {0xc0, 0xc0}} {
+		var out Bytes
+		if err := out.UnmarshalMsgpack(data); err != ErrInvalidMsgpack {
+			t.Errorf("UnmarshalMsgpack(%x) = %v", data, err)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package jase93
+
+// MustDecode is like Decode but panics if src is invalid. It simplifies initializing variables with constant data,
+// such as fixtures and keys.
+func (enc *Encoding) MustDecode(src []byte) []byte {
+	data, err := enc.Decode(nil, src)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// MustDecodeString is like MustDecode but decodes a string.
+func (enc *Encoding) MustDecodeString(s string) []byte {
+	return enc.MustDecode([]byte(s))
+}
+
+// MustDecode decodes src with StdEncoding, panicking if it is invalid. See Encoding.MustDecode.
+func MustDecode(src []byte) []byte {
+	return StdEncoding.MustDecode(src)
+}
+
+// MustDecodeString decodes s with StdEncoding, panicking if it is invalid. See Encoding.MustDecode.
+func MustDecodeString(s string) []byte {
+	return StdEncoding.MustDecodeString(s)
+}
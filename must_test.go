@@ -0,0 +1,25 @@
+package jase93
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+var mustFixture = MustDecodeString("(z(")
+
+func TestMustDecode(t *testing.T) {
+	if want := []byte{0xff, 0xff}; !bytes.Equal(mustFixture, want) {
+		t.Errorf("MustDecodeString = %q, want %q", mustFixture, want)
+	}
+	if data := MustDecode([]byte("g#")); !bytes.Equal(data, []byte{0xff}) {
+		t.Errorf("MustDecode = %q", data)
+	}
+
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, ErrInvalidData) {
+			t.Errorf("MustDecodeString panicked with %v", err)
+		}
+	}()
+	MustDecodeString(`g#"`)
+}
@@ -0,0 +1,71 @@
+package jase93
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPaper(t *testing.T) {
+	key := make([]byte, 200)
+	rand.New(rand.NewSource(0)).Read(key)
+
+	var buf bytes.Buffer
+	if err := WritePaper(&buf, "PRIVATE KEY", map[string]string{"Comment": "laptop"}, key); err != nil {
+		t.Fatal(err)
+	}
+	paper := buf.String()
+	lines := strings.Split(strings.TrimSuffix(paper, "\n"), "\n")
+	body := lines[5 : len(lines)-1]
+	if len(body) < 3 {
+		t.Fatalf("WritePaper = %q", paper)
+	}
+
+	read := func(lines []string) ([]byte, error) {
+		block, err := ReadPaper(strings.NewReader("preamble\n" + strings.Join(lines, "\r\n")))
+		if err != nil {
+			return nil, err
+		}
+		if block.Type != "PRIVATE KEY" || !reflect.DeepEqual(block.Header, map[string]string{"Comment": "laptop"}) {
+			t.Errorf("ReadPaper = %q, %q", block.Type, block.Header)
+		}
+		return ioutil.ReadAll(block.Body)
+	}
+	if data, err := read(lines); err != nil || !bytes.Equal(data, key) {
+		t.Fatalf("ReadPaper = %x, %v", data, err)
+	}
+
+	// Lines out of order, duplicated, reflowed, and with letters in the wrong case
+	lower := strings.NewReplacer("C", "c", "K", "k", "P", "p", "S", "s", "U", "u", "V", "v", "W", "w", "X", "x", "Z", "z")
+	typed := append([]string(nil), lines[:5]...)
+	for i := len(body) - 1; i >= 0; i-- {
+		typed = append(typed, "  "+lower.Replace(strings.Join(strings.Fields(body[i]), "   ")), "")
+	}
+	typed = append(typed, body[0], lines[len(lines)-1])
+	if data, err := read(typed); err != nil || !bytes.Equal(data, key) {
+		t.Errorf("ReadPaper(typed) = %x, %v", data, err)
+	}
+
+	// Every missing and mistyped line is reported
+	damaged := append([]string(nil), lines[:5]...)
+	damaged = append(damaged, body[0])
+	damaged = append(damaged, strings.Replace(body[2], body[2][len(body[2])-5:len(body[2])-4], "#", 1))
+	damaged = append(damaged, body[3:]...)
+	damaged = append(damaged, lines[len(lines)-1])
+	_, err := read(damaged)
+	var perr *PaperError
+	if !errors.As(err, &perr) || !reflect.DeepEqual(perr.Missing, []int{2}) || !reflect.DeepEqual(perr.Bad, []int{3}) || !errors.Is(err, ErrChecksum) {
+		t.Errorf("ReadPaper(damaged) = %v", err)
+	}
+	if want := "jase93: invalid paper backup: missing lines 2; checksum mismatch on lines 3"; err == nil || err.Error() != want {
+		t.Errorf("error = %v, want %q", err, want)
+	}
+
+	if err := WritePaper(&buf, "KEY", map[string]string{"Lines": "1"}, key); err != ErrInvalidArmor {
+		t.Errorf("WritePaper(Lines header) = %v", err)
+	}
+}
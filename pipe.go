@@ -0,0 +1,93 @@
+package jase93
+
+import "io"
+
+// EncodePipe creates a synchronous in-memory pipe that encodes with enc: data written to w is encoded, and the encoding
+// can be read from r. It is built on io.Pipe and starts no goroutines; as with io.Pipe, each write to w blocks until
+// its encoding has been read from r, so w and r are used from different goroutines.
+//
+// Closing w flushes the encoding, after which reads from r return io.EOF; w may also be closed with an error that reads
+// then return, if it implements CloseWithError(error) error, as it does. Closing r, or closing it with an error, makes
+// writes to w fail, so the writer is never left blocked.
+func (enc *Encoding) EncodePipe() (w io.WriteCloser, r io.ReadCloser) {
+	pr, pw := io.Pipe()
+	return &encodePipe{e: enc.NewEncoder(pipeWriter{pw}), pw: pw}, pr
+}
+
+// EncodePipe creates a pipe that encodes with StdEncoding; see Encoding.EncodePipe.
+func EncodePipe() (w io.WriteCloser, r io.ReadCloser) {
+	return StdEncoding.EncodePipe()
+}
+
+// DecodePipe creates a synchronous in-memory pipe that decodes with enc: encoded data written to w is decoded, and the
+// data can be read from r. It is built on io.Pipe and starts no goroutines; as with io.Pipe, w and r are used from
+// different goroutines.
+//
+// Closing w ends the stream, after which reads from r return the remaining data and then io.EOF, or an error if the
+// stream ended incorrectly. If decoding fails, reads from r return the error and writes to w fail with it too, so the
+// writer is never left blocked. Closing r likewise makes writes to w fail.
+func (enc *Encoding) DecodePipe() (w io.WriteCloser, r io.ReadCloser) {
+	pr, pw := io.Pipe()
+	return pipeWriter{pw}, &decodePipe{d: enc.NewDecoder(pr), pr: pr}
+}
+
+// DecodePipe creates a pipe that decodes with StdEncoding; see Encoding.DecodePipe.
+func DecodePipe() (w io.WriteCloser, r io.ReadCloser) {
+	return StdEncoding.DecodePipe()
+}
+
+// pipeWriter is an io.PipeWriter that ignores empty writes, which would otherwise block until the next read.
+type pipeWriter struct {
+	*io.PipeWriter
+}
+
+func (w pipeWriter) Write(data []byte) (int, error) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+	return w.PipeWriter.Write(data)
+}
+
+type encodePipe struct {
+	e  *Encoder
+	pw *io.PipeWriter
+}
+
+func (p *encodePipe) Write(data []byte) (int, error) {
+	return p.e.Write(data)
+}
+
+// Close flushes the encoding and closes the pipe.
+func (p *encodePipe) Close() error {
+	err := p.e.Close()
+	p.pw.CloseWithError(err)
+	return err
+}
+
+// CloseWithError closes the pipe without flushing the encoding, so reads return err.
+func (p *encodePipe) CloseWithError(err error) error {
+	return p.pw.CloseWithError(err)
+}
+
+type decodePipe struct {
+	d  *Decoder
+	pr *io.PipeReader
+}
+
+func (p *decodePipe) Read(data []byte) (int, error) {
+	n, err := p.d.Read(data)
+	if err != nil && err != io.EOF {
+		// Fail the writer, which would otherwise wait for the rest of the stream to be read
+		p.pr.CloseWithError(err)
+	}
+	return n, err
+}
+
+func (p *decodePipe) Close() error {
+	return p.pr.Close()
+}
+
+// CloseWithError closes the pipe, so writes fail with err.
+func (p *decodePipe) CloseWithError(err error) error {
+	return p.pr.CloseWithError(err)
+}
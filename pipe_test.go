@@ -0,0 +1,92 @@
+package jase93
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"testing"
+)
+
+func TestEncodePipe(t *testing.T) {
+	src := make([]byte, 10000)
+	rand.New(rand.NewSource(0)).Read(src)
+
+	w, r := EncodePipe()
+	go func() {
+		for i := 0; i < len(src); i += 7 {
+			end := i + 7
+			if end > len(src) {
+				end = len(src)
+			}
+			w.Write(src[i:end])
+		}
+		w.Close()
+	}()
+	encoded, err := ioutil.ReadAll(r)
+	if err != nil || !bytes.Equal(encoded, Encode(nil, src)) {
+		t.Errorf("ReadAll = %v", err)
+	}
+
+	// Errors propagate in both directions
+	errFail := errors.New("fail")
+	w, r = EncodePipe()
+	go w.(interface{ CloseWithError(error) error }).CloseWithError(errFail)
+	if _, err := ioutil.ReadAll(r); err != errFail {
+		t.Errorf("ReadAll after CloseWithError = %v, want %v", err, errFail)
+	}
+
+	w, r = EncodePipe()
+	r.Close()
+	if _, err := w.Write(src); err != io.ErrClosedPipe {
+		t.Errorf("Write after reader closed = %v, want %v", err, io.ErrClosedPipe)
+	}
+}
+
+func TestDecodePipe(t *testing.T) {
+	src := make([]byte, 10000)
+	rand.New(rand.NewSource(0)).Read(src)
+	encoded := Encode(nil, src)
+
+	w, r := DecodePipe()
+	go func() {
+		for i := 0; i < len(encoded); i += 7 {
+			end := i + 7
+			if end > len(encoded) {
+				end = len(encoded)
+			}
+			w.Write(encoded[i:end])
+		}
+		w.Close()
+	}()
+	dec, err := ioutil.ReadAll(r)
+	if err != nil || !bytes.Equal(dec, src) {
+		t.Errorf("ReadAll = %v", err)
+	}
+
+	// A decoding error fails the writer rather than leaving it blocked
+	w, r = DecodePipe()
+	werr := make(chan error)
+	go func() {
+		_, err := w.Write([]byte(`ab"`))
+		for err == nil {
+			_, err = w.Write(encoded)
+		}
+		werr <- err
+	}()
+	_, err = ioutil.ReadAll(r)
+	if !errors.Is(err, ErrInvalidData) {
+		t.Errorf("ReadAll of corrupt stream = %v", err)
+	}
+	if err := <-werr; !errors.Is(err, ErrInvalidData) {
+		t.Errorf("Write of corrupt stream = %v", err)
+	}
+
+	// Empty writes do not block
+	w, r = DecodePipe()
+	if _, err := w.Write(nil); err != nil {
+		t.Errorf("empty Write = %v", err)
+	}
+	r.Close()
+}
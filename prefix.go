@@ -0,0 +1,30 @@
+package jase93
+
+import "errors"
+
+// DecodePrefix decodes the leading portion of src up to the first character outside the alphabet and appends it to
+// dst, reporting the number of bytes of src consumed, so parsers of other syntax can continue from src[consumed:].
+// Stopping at an invalid character is not an error; an error is returned only if a stream header is invalid.
+// Lenient normalization is not applied.
+func (enc *Encoding) DecodePrefix(dst, src []byte) (out []byte, consumed int, err error) {
+	d := decoder{encoding: enc}
+	d.reset()
+
+	consumed = len(src)
+	if dst, err = d.decode(dst, src); err != nil {
+		var ce *CorruptInputError
+		if !errors.As(err, &ce) || d.enc == nil {
+			return dst, int(d.offset), err
+		}
+		consumed = int(ce.Offset)
+	}
+
+	dst, err = d.flush(dst)
+	return dst, consumed, err
+}
+
+// DecodePrefix decodes the leading portion of src with StdEncoding and appends it to dst. See
+// Encoding.DecodePrefix.
+func DecodePrefix(dst, src []byte) (out []byte, consumed int, err error) {
+	return StdEncoding.DecodePrefix(dst, src)
+}
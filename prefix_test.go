@@ -0,0 +1,36 @@
+package jase93
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestDecodePrefix(t *testing.T) {
+	for _, data := range []string{"", "a", "ab", "abc", "Man is distinguished"} {
+		encoded := Encode(nil, []byte(data))
+		for _, rest := range []string{"", `"`, `\"`, "\n more"} {
+			out, consumed, err := DecodePrefix(nil, []byte(string(encoded)+rest))
+			if err != nil || string(out) != data || consumed != len(encoded) {
+				t.Errorf("DecodePrefix(%q) = %q, %d, %v", string(encoded)+rest, out, consumed, err)
+			}
+		}
+	}
+}
+
+func TestDecodePrefixHeader(t *testing.T) {
+	enc := StdEncoding.WithHeader()
+	encoded := enc.Encode(nil, []byte("data"))
+
+	out, consumed, err := enc.DecodePrefix([]byte("x"), append(encoded, '"'))
+	if err != nil || string(out) != "xdata" || consumed != len(encoded) {
+		t.Errorf("DecodePrefix = %q, %d, %v", out, consumed, err)
+	}
+
+	if _, _, err := enc.DecodePrefix(nil, []byte(`a"`)); !errors.Is(err, ErrInvalidHeader) {
+		t.Errorf("DecodePrefix(invalid header) = %v", err)
+	}
+	if out, consumed, err := enc.DecodePrefix(nil, nil); !errors.Is(err, ErrInvalidHeader) || consumed != 0 || !bytes.Equal(out, nil) {
+		t.Errorf("DecodePrefix(empty) = %q, %d, %v", out, consumed, err)
+	}
+}
@@ -0,0 +1,69 @@
+package jase93
+
+import "io"
+
+// reencodeBufferSize is the number of encoded bytes a Reencoder reads at a time.
+const reencodeBufferSize = 32 << 10
+
+// Reencoder decodes a stream encoded with one Encoding and encodes it with another, in a single streaming pass.
+type Reencoder struct {
+	dst io.Writer
+	src io.Reader
+	dec decoder
+	enc encoder
+	in  []byte
+	raw []byte
+	out []byte
+}
+
+// NewReencoder creates a Reencoder that decodes src with from and writes its encoding with to to dst, such as for
+// migrating archives between alphabet variants. Rather than copying between a Decoder and an Encoder, each buffer of
+// src is decoded and encoded into buffers reused for the next, so memory use is independent of the stream size.
+// Stream headers and length trailers are read and written as from and to require.
+func NewReencoder(dst io.Writer, src io.Reader, from, to *Encoding) *Reencoder {
+	r := &Reencoder{dst: dst, src: src}
+	r.dec.encoding = from
+	r.dec.reset()
+	r.enc.encoding = to
+	r.enc.reset()
+	return r
+}
+
+// Reencode re-encodes src to dst until src returns io.EOF, flushing the encoding at the end, and returns the number of
+// encoded bytes written. It does not close dst. A decoding error, such as a *CorruptInputError, is returned once the
+// data preceding the buffer that contained it has been written.
+func (r *Reencoder) Reencode() (written int64, err error) {
+	if r.in == nil {
+		r.in = make([]byte, reencodeBufferSize)
+	}
+
+	for {
+		n, rerr := r.src.Read(r.in)
+		var derr error
+		r.raw, derr = r.dec.write(r.raw[:0], r.in[:n])
+		if derr == nil && rerr == io.EOF {
+			r.raw, derr = r.dec.flush(r.raw)
+		}
+		if derr != nil {
+			return written, derr
+		}
+
+		r.out = r.enc.write(r.out[:0], r.raw)
+		if rerr == io.EOF {
+			r.out = r.enc.flush(r.out)
+		}
+		if len(r.out) > 0 {
+			n, werr := r.dst.Write(r.out)
+			written += int64(n)
+			if werr != nil {
+				return written, werr
+			}
+		}
+
+		if rerr == io.EOF {
+			return written, nil
+		} else if rerr != nil {
+			return written, rerr
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package jase93
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+	"testing/iotest"
+)
+
+func TestReencoder(t *testing.T) {
+	src := make([]byte, 100000)
+	rand.New(rand.NewSource(0)).Read(src)
+
+	for _, tc := range []struct {
+		name     string
+		from, to *Encoding
+	}{
+		{"std to human", StdEncoding, HumanEncoding},
+		{"header to filename", StdEncoding.WithHeader(), FilenameEncoding.WithHeader()},
+		{"trailer to wrapped", StdEncoding.WithLengthTrailer(), CookieEncoding.WithLineLength(MIMELineLen)},
+	} {
+		encoded := tc.from.Encode(nil, src)
+		want := tc.to.Encode(nil, src)
+
+		var buf bytes.Buffer
+		n, err := NewReencoder(&buf, iotest.HalfReader(bytes.NewReader(encoded)), tc.from, tc.to).Reencode()
+		if err != nil || n != int64(len(want)) || !bytes.Equal(buf.Bytes(), want) {
+			t.Errorf("%s: Reencode = %d, %v, want %d bytes", tc.name, n, err, len(want))
+		}
+	}
+}
+
+func TestReencoderErrors(t *testing.T) {
+	encoded := Encode(nil, []byte("Man is distinguished, not only by his reason"))
+	encoded[40] = '"'
+
+	var buf bytes.Buffer
+	_, err := NewReencoder(&buf, iotest.OneByteReader(bytes.NewReader(encoded)), StdEncoding, HumanEncoding).Reencode()
+	var cerr *CorruptInputError
+	if !errors.As(err, &cerr) || cerr.Offset != 40 {
+		t.Errorf("Reencode(corrupt) = %v", err)
+	}
+
+	truncated := StdEncoding.WithLengthTrailer().Encode(nil, []byte("Man is distinguished"))
+	truncated = truncated[:len(truncated)-2]
+	if _, err := NewReencoder(&buf, bytes.NewReader(truncated), StdEncoding.WithLengthTrailer(), HumanEncoding).Reencode(); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("Reencode(truncated) = %v", err)
+	}
+
+	werr := errors.New("write failed")
+	if _, err := NewReencoder(&errWriter{werr}, bytes.NewReader(encoded[:20]), StdEncoding, HumanEncoding).Reencode(); err != werr {
+		t.Errorf("Reencode(failing writer) = %v", err)
+	}
+}
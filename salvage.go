@@ -0,0 +1,96 @@
+package jase93
+
+import "errors"
+
+// Damage describes a corrupt region of encoded input skipped by Salvage.
+type Damage struct {
+	Offset    int64 // The offset in the encoded input of the first word that was lost
+	Len       int64 // The number of encoded characters skipped, including valid characters of lost words
+	RawOffset int64 // The offset in the decoded output of the first byte that may be wrong
+	RawLen    int64 // The number of decoded bytes that may be wrong
+}
+
+// Salvage decodes as much of src as possible and appends it to dst, skipping corrupt regions instead of failing.
+// It is intended to recover data from damaged files, where any recovery beats none.
+//
+// Salvage assumes that corrupt characters overwrote the same number of valid characters, so decoding resumes at the
+// next word boundary after each run of characters outside the alphabet, counting only characters that enc does not
+// skip, such as line breaks. Each lost word is replaced with WordBits zero bits, so the output remains aligned unless a
+// lost word carried an extra bit, in which case the rest of the output is shifted by one bit. The returned Damage
+// describes each skipped region and the bytes it affected.
+//
+// Lenient normalization is not applied. An error is returned only if a stream header is invalid.
+func (enc *Encoding) Salvage(dst, src []byte) ([]byte, []Damage, error) {
+	d := decoder{encoding: enc}
+	d.reset()
+	start := len(dst)
+
+	var damage []Damage
+	pos := int64(0)
+	for {
+		callStart := len(dst)
+		var err error
+		dst, err = d.decode(dst, src[pos:])
+		if err == nil {
+			break
+		}
+		var ce *CorruptInputError
+		if !errors.As(err, &ce) || d.enc == nil {
+			return dst, damage, err
+		}
+
+		// The lost words begin with the character before the corrupt run if it began mid-word. Characters are counted
+		// as decoded, so skipped line breaks and whitespace do not shift word boundaries.
+		begin, chars := ce.Offset, int64(0)
+		if d.word != -1 {
+			chars++
+			for begin--; enc.skipped(src[begin]); begin-- {
+			}
+		}
+
+		// Skip the corrupt run, and the rest of the word it ends in, if it is not the last
+		end := ce.Offset
+		for end < int64(len(src)) && d.enc.decode[src[end]] == -1 {
+			if !enc.skipped(src[end]) {
+				chars++
+			}
+			end++
+		}
+		words := chars / 2
+		if chars%2 == 1 && end < int64(len(src)) {
+			words++
+			end++
+		}
+
+		// Decoded data held back as a possible trailer has not been appended to dst yet
+		decoded := func() int64 {
+			return int64(len(dst)-start+len(d.tail))*8 + int64(d.bits.Len())
+		}
+		raw := decoded()
+		for i := int64(0); i < words; i++ {
+			d.bits.WriteBits(0, d.enc.wordBits)
+		}
+		dst = d.bits.AppendBytes(dst)
+		rawEnd := decoded()
+		dst = d.holdTrailer(dst, callStart)
+
+		damage = append(damage, Damage{
+			Offset:    begin,
+			Len:       end - begin,
+			RawOffset: raw / 8,
+			RawLen:    (rawEnd+7)/8 - raw/8,
+		})
+
+		d.word = -1
+		d.offset = end
+		pos = end
+	}
+
+	dst, err := d.flush(dst)
+	return dst, damage, err
+}
+
+// Salvage decodes as much of src as possible with StdEncoding and appends it to dst. See Encoding.Salvage.
+func Salvage(dst, src []byte) ([]byte, []Damage, error) {
+	return StdEncoding.Salvage(dst, src)
+}
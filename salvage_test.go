@@ -0,0 +1,128 @@
+package jase93
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestSalvage(t *testing.T) {
+	// All zero bytes are encoded with 14-bit words, so use data whose words carry exactly WordBits bits
+	src := bytes.Repeat([]byte{0xff}, 260)
+	encoded := Encode(nil, src)
+
+	for _, tt := range []struct {
+		offset, n int
+	}{
+		{0, 1},
+		{10, 2},
+		{11, 2},
+		{101, 7},
+		{len(encoded) - 3, 3},
+	} {
+		damaged := append([]byte(nil), encoded...)
+		for i := tt.offset; i < tt.offset+tt.n; i++ {
+			damaged[i] = '\\'
+		}
+
+		out, damage, err := Salvage(nil, damaged)
+		if err != nil {
+			t.Fatalf("Salvage(%d+%d) error %v", tt.offset, tt.n, err)
+		}
+		if len(damage) != 1 {
+			t.Fatalf("Salvage(%d+%d) damage = %+v", tt.offset, tt.n, damage)
+		}
+
+		dmg := damage[0]
+		if dmg.Offset > int64(tt.offset) || dmg.Offset+dmg.Len < int64(tt.offset+tt.n) || dmg.Offset%2 != 0 {
+			t.Errorf("Salvage(%d+%d) damage = %+v", tt.offset, tt.n, dmg)
+		}
+
+		// Everything outside the damaged raw range must be recovered
+		if len(out) < len(src)-1 {
+			t.Errorf("Salvage(%d+%d) = %d bytes, want about %d", tt.offset, tt.n, len(out), len(src))
+		}
+		for i, c := range out {
+			if c != 0xff && (int64(i) < dmg.RawOffset || int64(i) >= dmg.RawOffset+dmg.RawLen) && i < len(src) {
+				t.Errorf("Salvage(%d+%d) byte %d = %#x outside damage %+v", tt.offset, tt.n, i, c, dmg)
+				break
+			}
+		}
+	}
+}
+
+func TestSalvageMultiple(t *testing.T) {
+	src := bytes.Repeat([]byte("The quick brown fox jumps over the lazy dog. "), 20)
+	encoded := Encode(nil, src)
+	damaged := append([]byte(nil), encoded...)
+	copy(damaged[100:], "\x00\x00\x00\x00")
+	copy(damaged[500:], "\n")
+
+	_, damage, err := Salvage(nil, damaged)
+	if err != nil || len(damage) != 2 {
+		t.Errorf("Salvage = %+v, %v", damage, err)
+	}
+
+	if out, damage, err := Salvage(nil, encoded); err != nil || len(damage) != 0 || !bytes.Equal(out, src) {
+		t.Errorf("Salvage(undamaged) = %d bytes, %+v, %v", len(out), damage, err)
+	}
+}
+
+func TestSalvageHeader(t *testing.T) {
+	enc := StdEncoding.WithHeader()
+	encoded := enc.Encode(nil, bytes.Repeat([]byte{0xff}, 100))
+	encoded[20] = '"'
+
+	if _, damage, err := enc.Salvage(nil, encoded); err != nil || len(damage) != 1 || damage[0].Offset != 20 {
+		t.Errorf("Salvage = %+v, %v", damage, err)
+	}
+
+	encoded[0] = '"'
+	if _, _, err := enc.Salvage(nil, encoded); !errors.Is(err, ErrInvalidHeader) {
+		t.Errorf("Salvage(invalid header) = %v", err)
+	}
+}
+
+func TestSalvageTrailer(t *testing.T) {
+	enc := StdEncoding.WithLengthTrailer()
+	src := bytes.Repeat([]byte{0xff}, 160)
+	encoded := enc.Encode(nil, src)
+	encoded[90] = '"'
+
+	out, damage, err := enc.Salvage(nil, encoded)
+	if err != nil || len(damage) != 1 || len(out) != len(src) {
+		t.Errorf("Salvage = %d bytes, %+v, %v", len(out), damage, err)
+	}
+}
+
+func TestSalvageLineBreaks(t *testing.T) {
+	src := bytes.Repeat([]byte{0xff}, 200)
+
+	// Lines ending with "\n" alone put word boundaries at odd offsets after lines of odd length
+	for _, lineLen := range []int{7, MIMELineLen} {
+		enc := StdEncoding.WithLineLength(lineLen)
+		encoded := bytes.Replace(enc.Encode(nil, src), []byte("\r\n"), []byte("\n"), -1)
+		for _, offset := range []int{lineLen + 3, 2*lineLen + 6, 3*lineLen + 7} {
+			damaged := append([]byte(nil), encoded...)
+			if damaged[offset] == '\n' {
+				continue
+			}
+			damaged[offset] = '"'
+
+			out, damage, err := enc.Salvage(nil, damaged)
+			if err != nil || len(damage) != 1 || len(out) != len(src) {
+				t.Fatalf("lineLen %d: Salvage(%d) = %d bytes, %+v, %v", lineLen, offset, len(out), damage, err)
+			}
+			dmg := damage[0]
+			if dmg.Offset > int64(offset) || dmg.Offset+dmg.Len <= int64(offset) || dmg.RawLen > 3 {
+				t.Errorf("lineLen %d: Salvage(%d) damage = %+v", lineLen, offset, dmg)
+			}
+			for i, c := range out {
+				if c != 0xff && (int64(i) < dmg.RawOffset || int64(i) >= dmg.RawOffset+dmg.RawLen) {
+					t.Errorf("lineLen %d: Salvage(%d) byte %d = %#x outside damage %+v", lineLen, offset, i, c, dmg)
+					break
+				}
+			}
+		}
+	}
+}
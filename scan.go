@@ -0,0 +1,50 @@
+package jase93
+
+import (
+	"bufio"
+	"bytes"
+)
+
+// SplitRecords returns a bufio.SplitFunc that yields each record of a stream of encoded records terminated by delim,
+// which must not be in the alphabet. The final record need not be terminated. If delim is '\n', a preceding '\r' is
+// also removed.
+//
+// If decode is true, each token is the decoded record, and invalid records stop the bufio.Scanner with an error.
+// Otherwise, each token is the encoded record.
+func (enc *Encoding) SplitRecords(delim byte, decode bool) bufio.SplitFunc {
+	if enc.decode[delim] != -1 {
+		panic("jase93: record delimiter is in the encoding alphabet")
+	}
+
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		if atEOF && len(data) == 0 {
+			return 0, nil, nil
+		}
+
+		if i := bytes.IndexByte(data, delim); i >= 0 {
+			advance, token = i+1, data[:i]
+		} else if atEOF {
+			advance, token = len(data), data
+		} else {
+			return 0, nil, nil
+		}
+
+		if delim == '\n' && len(token) > 0 && token[len(token)-1] == '\r' {
+			token = token[:len(token)-1]
+		}
+		if decode {
+			if token, err = enc.Decode(make([]byte, 0, len(token)), token); err != nil {
+				return 0, nil, err
+			}
+		}
+		return advance, token, nil
+	}
+}
+
+// ScanRecords is a bufio.SplitFunc that yields each line of a stream of records encoded with StdEncoding, decoded.
+// It is equivalent to StdEncoding.SplitRecords('\n', true).
+func ScanRecords(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	return scanRecords(data, atEOF)
+}
+
+var scanRecords = StdEncoding.SplitRecords('\n', true)
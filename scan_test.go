@@ -0,0 +1,57 @@
+package jase93
+
+import (
+	"bufio"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestScanRecords(t *testing.T) {
+	records := []string{"first", "", "\x00third\xff", "last"}
+	var b strings.Builder
+	for i, r := range records {
+		b.Write(Encode(nil, []byte(r)))
+		if i == 1 {
+			b.WriteString("\r\n")
+		} else if i < len(records)-1 {
+			b.WriteString("\n")
+		}
+	}
+
+	s := bufio.NewScanner(strings.NewReader(b.String()))
+	s.Split(ScanRecords)
+	var got []string
+	for s.Scan() {
+		got = append(got, s.Text())
+	}
+	if s.Err() != nil || !reflect.DeepEqual(got, records) {
+		t.Errorf("ScanRecords = %q, %v, want %q", got, s.Err(), records)
+	}
+}
+
+func TestSplitRecordsRaw(t *testing.T) {
+	in := "abc\x00de\x00"
+	s := bufio.NewScanner(strings.NewReader(in))
+	s.Split(StdEncoding.SplitRecords(0, false))
+	var got []string
+	for s.Scan() {
+		got = append(got, s.Text())
+	}
+	if want := []string{"abc", "de"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SplitRecords = %q, want %q", got, want)
+	}
+}
+
+func TestScanRecordsInvalid(t *testing.T) {
+	s := bufio.NewScanner(strings.NewReader("abc\nd\"e\nfg\n"))
+	s.Split(ScanRecords)
+	n := 0
+	for s.Scan() {
+		n++
+	}
+	if n != 1 || !errors.Is(s.Err(), ErrInvalidData) {
+		t.Errorf("scanned %d records, Err = %v", n, s.Err())
+	}
+}
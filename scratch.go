@@ -0,0 +1,61 @@
+package jase93
+
+import "sort"
+
+// MinScratchLen is the minimum capacity of a buffer passed to Encoder.SetScratch.
+const MinScratchLen = 128
+
+// scratchSlack is the capacity of a scratch buffer reserved for output beyond MaxEncodedLen of each piece: the header,
+// the bits left over from previous writes, a line break continuing the previous line, and the end of the encoding.
+const scratchSlack = 64
+
+// SetScratch sets the Encoder to encode into scratch, which must have a capacity of at least MinScratchLen, rather
+// than a buffer of its own. Each Write is split into pieces whose encoding is guaranteed to fit, so encoding never
+// grows the heap, regardless of the size of the data written. Larger buffers require fewer writes to the wrapped
+// io.Writer.
+//
+// The Encoder owns scratch until SetScratch is called again, and retains it across Reset. A nil scratch restores the
+// default of a buffer allocated and grown as needed. Scratch is not used while batching; see SetBatchSize.
+func (e *Encoder) SetScratch(scratch []byte) {
+	if scratch == nil {
+		e.scratch, e.scratchLen = nil, 0
+		return
+	}
+	if cap(scratch) < MinScratchLen {
+		panic("jase93: scratch buffer must have a capacity of at least MinScratchLen")
+	}
+
+	enc := e.enc.encoding
+	n := sort.Search(cap(scratch), func(n int) bool {
+		return enc.MaxEncodedLen(n+1)+scratchSlack > cap(scratch)
+	})
+	e.scratch, e.scratchLen = scratch[:0], n
+	e.buf = e.scratch
+
+	// Line wrapping copies the encoded data, so its buffer must be large enough too
+	if enc.lineLen > 0 && cap(e.enc.scratch) < cap(scratch) {
+		e.enc.scratch = make([]byte, 0, cap(scratch))
+	}
+}
+
+// writeScratch encodes data to the wrapped io.Writer in pieces whose encoding fits into the scratch buffer.
+func (e *Encoder) writeScratch(data []byte) (written int, err error) {
+	for len(data) > 0 {
+		piece := data
+		if len(piece) > e.scratchLen {
+			piece = piece[:e.scratchLen]
+		}
+		data = data[len(piece):]
+
+		e.buf = e.enc.write(e.scratch[:0], piece)
+		var n int
+		n, err = e.w.Write(e.buf)
+		e.stats.EncodedBytes += int64(n)
+		recordEncode(len(piece), n, err)
+		written += len(piece)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,46 @@
+package jase93
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+	"testing"
+)
+
+func TestEncoderScratch(t *testing.T) {
+	src := make([]byte, 10000)
+	rand.New(rand.NewSource(0)).Read(src)
+
+	for _, enc := range []*Encoding{StdEncoding, StdEncoding.WithHeader(), StdEncoding.WithLengthTrailer().WithLineLength(3)} {
+		for _, size := range []int{MinScratchLen, 1000} {
+			scratch := make([]byte, size)
+			var out bytes.Buffer
+			e := enc.NewEncoder(&out)
+			e.SetScratch(scratch)
+			e.Write(src[:1])
+			e.Write(src[1:])
+			if err := e.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if want := enc.Encode(nil, src); !bytes.Equal(out.Bytes(), want) {
+				t.Errorf("%d byte scratch: encoding differs", size)
+			}
+			if cap(e.buf) != size || &e.buf[:1][0] != &scratch[0] {
+				t.Errorf("%d byte scratch: buffer was reallocated", size)
+			}
+		}
+	}
+
+	e := NewEncoder(ioutil.Discard)
+	e.SetScratch(make([]byte, MinScratchLen))
+	if n := testing.AllocsPerRun(100, func() { e.Write(src) }); n != 0 {
+		t.Errorf("Write allocated %v times", n)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("SetScratch accepted a small buffer")
+		}
+	}()
+	e.SetScratch(make([]byte, MinScratchLen-1))
+}
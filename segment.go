@@ -0,0 +1,84 @@
+package jase93
+
+import "errors"
+
+// ErrSegmentLen indicates that SplitEncoded was asked for segments too short to encode a byte of data.
+var ErrSegmentLen = errors.New("jase93: segment length too short")
+
+// SplitEncoded splits encoded data into segments of at most n characters, each of which decodes independently, for
+// protocols that cap the size of each message, such as IRC and MQTT. Since the words of a stream rarely end on a byte
+// boundary, the data is decoded and each segment re-encoded with enc, so each has any header, length trailer, and line
+// breaks that enc requires. Each segment holds as much data as fits. JoinEncoded reverses SplitEncoded.
+//
+// SplitEncoded returns no segments for empty data, any error from decoding, and ErrSegmentLen if n is too short to
+// encode a single byte with enc.
+func (enc *Encoding) SplitEncoded(encoded []byte, n int) ([][]byte, error) {
+	if enc.MaxEncodedLen(1) > n {
+		return nil, ErrSegmentLen
+	}
+	data, err := enc.Decode(nil, encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the length of data that always fits
+	lo, hi := 1, n
+	for lo < hi {
+		mid := lo + (hi-lo+1)/2
+		if enc.MaxEncodedLen(mid) <= n {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+
+	var segments [][]byte
+	for len(data) > 0 {
+		// Most data encodes more densely than the worst case, so search for the longest prefix that fits. Each byte
+		// takes more than one character, so no more than n bytes fit.
+		k, most := lo, n
+		if most > len(data) {
+			most = len(data)
+		}
+		if k > most {
+			k = most
+		}
+		segment := enc.Encode(nil, data[:k])
+		for k < most {
+			mid := k + (most-k+1)/2
+			if next := enc.Encode(nil, data[:mid]); len(next) <= n {
+				k, segment = mid, next
+			} else {
+				most = mid - 1
+			}
+		}
+
+		segments = append(segments, segment)
+		data = data[k:]
+	}
+	return segments, nil
+}
+
+// SplitEncoded splits data encoded with StdEncoding into independently decodable segments; see
+// Encoding.SplitEncoded.
+func SplitEncoded(encoded []byte, n int) ([][]byte, error) {
+	return StdEncoding.SplitEncoded(encoded, n)
+}
+
+// JoinEncoded decodes the segments written by SplitEncoded, in order, and returns the encoding of their data as one
+// stream, which is identical to the data split if that was encoded canonically.
+func (enc *Encoding) JoinEncoded(segments [][]byte) ([]byte, error) {
+	var data []byte
+	for _, segment := range segments {
+		var err error
+		if data, err = enc.Decode(data, segment); err != nil {
+			return nil, err
+		}
+	}
+	return enc.Encode(nil, data), nil
+}
+
+// JoinEncoded joins segments written by SplitEncoded with StdEncoding; see Encoding.JoinEncoded.
+func JoinEncoded(segments [][]byte) ([]byte, error) {
+	return StdEncoding.JoinEncoded(segments)
+}
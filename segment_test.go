@@ -0,0 +1,78 @@
+package jase93
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestSplitEncoded(t *testing.T) {
+	src := make([]byte, 5000)
+	rand.New(rand.NewSource(0)).Read(src)
+	copy(src, make([]byte, 1000)) // zeros encode more densely
+
+	for _, enc := range []*Encoding{StdEncoding, StdEncoding.WithHeader(), StdEncoding.WithLengthTrailer(), CookieEncoding.WithLineLength(20)} {
+		encoded := enc.Encode(nil, src)
+		for _, n := range []int{enc.MaxEncodedLen(1), 64, 255, 1000, len(encoded)} {
+			segments, err := enc.SplitEncoded(encoded, n)
+			if err != nil {
+				t.Fatalf("SplitEncoded(%d) = %v", n, err)
+			}
+
+			var data []byte
+			for i, segment := range segments {
+				if len(segment) > n {
+					t.Errorf("SplitEncoded(%d): segment %d has %d characters", n, i, len(segment))
+				}
+				if data, err = enc.Decode(data, segment); err != nil {
+					t.Fatalf("SplitEncoded(%d): segment %d: %v", n, i, err)
+				}
+			}
+			if !bytes.Equal(data, src) {
+				t.Errorf("SplitEncoded(%d): segments decode to %d bytes", n, len(data))
+			}
+			if n == len(encoded) && len(segments) != 1 {
+				t.Errorf("SplitEncoded(%d) = %d segments, want 1", n, len(segments))
+			}
+
+			if joined, err := enc.JoinEncoded(segments); err != nil || !bytes.Equal(joined, encoded) {
+				t.Errorf("JoinEncoded(SplitEncoded(%d)) = %d characters, %v", n, len(joined), err)
+			}
+		}
+	}
+}
+
+func TestSplitEncodedErrors(t *testing.T) {
+	if segments, err := SplitEncoded(nil, 10); err != nil || len(segments) != 0 {
+		t.Errorf("SplitEncoded(empty) = %q, %v", segments, err)
+	}
+	if _, err := SplitEncoded(Encode(nil, []byte("data")), 1); err != ErrSegmentLen {
+		t.Errorf("SplitEncoded(n = 1) = %v", err)
+	}
+	if _, err := SplitEncoded([]byte(`bad"data`), 10); err == nil {
+		t.Error("SplitEncoded(corrupt) succeeded")
+	}
+	if _, err := JoinEncoded([][]byte{Encode(nil, []byte("ok")), []byte(`"`)}); err == nil {
+		t.Error("JoinEncoded(corrupt) succeeded")
+	}
+}
+
+func TestSplitEncodedLarge(t *testing.T) {
+	src := make([]byte, 5<<20)
+	rand.New(rand.NewSource(0)).Read(src)
+	encoded := Encode(nil, src)
+
+	const n = 1 << 20
+	segments, err := SplitEncoded(encoded, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, segment := range segments[:len(segments)-1] {
+		if len(segment) > n || len(segment) < n-2 {
+			t.Errorf("segment %d has %d characters, want about %d", i, len(segment), n)
+		}
+	}
+	if joined, err := JoinEncoded(segments); err != nil || !bytes.Equal(joined, encoded) {
+		t.Errorf("JoinEncoded(SplitEncoded) = %d characters, %v", len(joined), err)
+	}
+}
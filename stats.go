@@ -0,0 +1,61 @@
+package jase93
+
+import "time"
+
+// Stats reports the work done by an Encoder or Decoder since it was created or last Reset.
+type Stats struct {
+	RawBytes     int64         // Raw bytes written to an Encoder, or decoded bytes read from a Decoder
+	EncodedBytes int64         // Encoded bytes written by an Encoder, or read by a Decoder
+	Words        int64         // Two-character words encoded or decoded, including a final partial word
+	ExtraBits    int64         // Words that carried an extra bit, possibly including a final partial word when decoding
+	Elapsed      time.Duration // Time from the first call to Write, Close, or Read until the most recent one
+}
+
+// Ratio returns the ratio of encoded to raw bytes, such as 1.23 for 23% expansion. It is 0 if no raw bytes were
+// processed.
+func (s Stats) Ratio() float64 {
+	if s.RawBytes == 0 {
+		return 0
+	}
+	return float64(s.EncodedBytes) / float64(s.RawBytes)
+}
+
+// Throughput returns the raw bytes processed per second.
+func (s Stats) Throughput() float64 {
+	if s.Elapsed <= 0 {
+		return 0
+	}
+	return float64(s.RawBytes) / s.Elapsed.Seconds()
+}
+
+// Stats returns the work done by the Encoder.
+func (e *Encoder) Stats() Stats {
+	s := e.stats
+	s.Words, s.ExtraBits = e.enc.words, e.enc.extra
+	return s
+}
+
+// mark records the time of an operation.
+func (e *Encoder) mark() {
+	now := time.Now()
+	if e.start.IsZero() {
+		e.start = now
+	}
+	e.stats.Elapsed = now.Sub(e.start)
+}
+
+// Stats returns the work done by the Decoder.
+func (d *Decoder) Stats() Stats {
+	s := d.stats
+	s.Words, s.ExtraBits = d.dec.words, d.dec.extra
+	return s
+}
+
+// mark records the time of an operation.
+func (d *Decoder) mark() {
+	now := time.Now()
+	if d.start.IsZero() {
+		d.start = now
+	}
+	d.stats.Elapsed = now.Sub(d.start)
+}
@@ -0,0 +1,44 @@
+package jase93
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	src := []byte{0x00, 0x20, 0xff, 0xff, 0xff}
+	enc := Encode(nil, src)
+
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+	if _, err := e.Write(src); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first word takes an extra bit, the second does not, and the final 12 bits are flushed as a partial word
+	want := Stats{RawBytes: 5, EncodedBytes: int64(len(enc)), Words: 3, ExtraBits: 1}
+	if s := e.Stats(); s.RawBytes != want.RawBytes || s.EncodedBytes != want.EncodedBytes || s.Words != want.Words || s.ExtraBits != want.ExtraBits {
+		t.Errorf("Encoder.Stats() = %+v != %+v", s, want)
+	}
+
+	d := NewDecoder(bytes.NewBuffer(enc))
+	if _, err := io.Copy(ioutil.Discard, d); err != nil {
+		t.Fatal(err)
+	}
+	if s := d.Stats(); s.RawBytes != want.RawBytes || s.EncodedBytes != want.EncodedBytes || s.Words != want.Words || s.ExtraBits != want.ExtraBits {
+		t.Errorf("Decoder.Stats() = %+v != %+v", s, want)
+	}
+
+	if r := (Stats{RawBytes: 100, EncodedBytes: 123}).Ratio(); r != 1.23 {
+		t.Errorf("Ratio() = %g", r)
+	}
+
+	if s := e.Reset(&buf).Stats(); s != (Stats{}) {
+		t.Errorf("Stats() after Reset = %+v", s)
+	}
+}
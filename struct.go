@@ -0,0 +1,210 @@
+package jase93
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// ErrNotStruct indicates that a value passed to the struct codec was not a struct, or a pointer to one.
+var ErrNotStruct = errors.New("jase93: value is not a struct")
+
+// FieldError reports a struct field that could not be marshaled or unmarshaled.
+type FieldError struct {
+	Field string // The name of the struct field
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return "jase93: field " + e.Field + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+var bytesType = reflect.TypeOf([]byte(nil))
+
+// structField describes an exported struct field.
+type structField struct {
+	index     int
+	name      string // The key of the field in the map or JSON object
+	encoded   bool   // Whether the field is tagged `jase93`
+	omitEmpty bool
+}
+
+// structFields returns the exported fields of t, keyed as by encoding/json unless a `jase93` tag specifies a name.
+// Fields tagged `jase93` must be of type []byte.
+func structFields(t reflect.Type) ([]structField, error) {
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+
+		sf := structField{index: i, name: f.Name}
+		jsonTag := f.Tag.Get("json")
+		if jsonTag == "-" {
+			continue
+		}
+		if name, opts := parseTag(jsonTag); name != "" || opts != "" {
+			if name != "" {
+				sf.name = name
+			}
+			sf.omitEmpty = strings.Contains(opts, "omitempty")
+		}
+
+		if tag, ok := f.Tag.Lookup("jase93"); ok {
+			if tag == "-" {
+				continue
+			}
+			if f.Type != bytesType {
+				return nil, &FieldError{Field: f.Name, Err: errors.New("tagged field is not []byte")}
+			}
+			name, opts := parseTag(tag)
+			if name != "" {
+				sf.name = name
+			}
+			sf.encoded = true
+			sf.omitEmpty = sf.omitEmpty || strings.Contains(opts, "omitempty")
+		}
+
+		fields = append(fields, sf)
+	}
+	return fields, nil
+}
+
+// parseTag splits a struct tag into its name and comma-separated options.
+func parseTag(tag string) (name, opts string) {
+	if i := strings.IndexByte(tag, ','); i >= 0 {
+		return tag[:i], tag[i+1:]
+	}
+	return tag, ""
+}
+
+// structValue returns the struct v holds or points to.
+func structValue(v interface{}) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, ErrNotStruct
+	}
+	return rv, nil
+}
+
+// StructToMap returns a map of the exported fields of the struct v, or the struct v points to, ready to be marshaled
+// as a JSON object. Fields are keyed as by encoding/json, except that []byte fields tagged `jase93:"name"` are keyed
+// by name, if given, and encoded as jase93 strings with StdEncoding. The "omitempty" option omits empty fields, and a
+// tag of "-" excludes a field.
+//
+// Other fields are stored as-is, so their own marshaling is unaffected.
+func StructToMap(v interface{}) (map[string]interface{}, error) {
+	rv, err := structValue(v)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := structFields(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		fv := rv.Field(f.index)
+		if f.omitEmpty && isEmptyValue(fv) {
+			continue
+		}
+		if f.encoded {
+			m[f.name] = string(Encode(nil, fv.Bytes()))
+		} else {
+			m[f.name] = fv.Interface()
+		}
+	}
+	return m, nil
+}
+
+// MarshalStruct returns the JSON encoding of StructToMap(v).
+func MarshalStruct(v interface{}) ([]byte, error) {
+	m, err := StructToMap(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalStruct parses the JSON object data into the struct v points to, reversing MarshalStruct: fields tagged
+// `jase93` are decoded from jase93 strings, and other fields are unmarshaled by encoding/json. Fields missing from data
+// are left unchanged.
+func UnmarshalStruct(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return ErrNotStruct
+	}
+	rv, err := structValue(v)
+	if err != nil {
+		return err
+	}
+	fields, err := structFields(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	for _, f := range fields {
+		msg, ok := raw[f.name]
+		if !ok {
+			continue
+		}
+
+		fv := rv.Field(f.index)
+		if !f.encoded {
+			if err := json.Unmarshal(msg, fv.Addr().Interface()); err != nil {
+				return &FieldError{Field: rv.Type().Field(f.index).Name, Err: err}
+			}
+			continue
+		}
+
+		var s *string
+		if err := json.Unmarshal(msg, &s); err != nil {
+			return &FieldError{Field: rv.Type().Field(f.index).Name, Err: err}
+		}
+		if s == nil {
+			fv.SetBytes(nil)
+			continue
+		}
+		b, err := Decode(nil, []byte(*s))
+		if err != nil {
+			return &FieldError{Field: rv.Type().Field(f.index).Name, Err: err}
+		}
+		fv.SetBytes(b)
+	}
+	return nil
+}
+
+// isEmptyValue reports whether v is empty, as for the "omitempty" option of encoding/json.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package jase93
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type structTestRecord struct {
+	ID      int    `json:"id"`
+	Key     []byte `jase93:"key"`
+	Sig     []byte `json:"signature" jase93:",omitempty"`
+	Raw     []byte
+	Skip    []byte `jase93:"-"`
+	private []byte
+}
+
+func TestStructToMap(t *testing.T) {
+	r := structTestRecord{ID: 7, Key: []byte{0, 1, 0xfe, 0xff}, Raw: []byte{1}, Skip: []byte{2}}
+	m, err := StructToMap(&r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]interface{}{"id": 7, "key": string(Encode(nil, r.Key)), "Raw": []byte{1}}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("StructToMap = %#v, want %#v", m, want)
+	}
+}
+
+func TestMarshalStruct(t *testing.T) {
+	r := structTestRecord{ID: 7, Key: []byte("key \x00\xff"), Sig: []byte("sig"), Raw: []byte{1}}
+	data, err := MarshalStruct(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc["signature"] != string(Encode(nil, r.Sig)) {
+		t.Errorf("signature = %v", doc["signature"])
+	}
+
+	var got structTestRecord
+	if err := UnmarshalStruct(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, r) {
+		t.Errorf("UnmarshalStruct = %#v, want %#v", got, r)
+	}
+}
+
+func TestStructErrors(t *testing.T) {
+	if _, err := StructToMap(1); err != ErrNotStruct {
+		t.Errorf("StructToMap(1) = %v", err)
+	}
+	if err := UnmarshalStruct([]byte(`{}`), structTestRecord{}); err != ErrNotStruct {
+		t.Errorf("UnmarshalStruct(non-pointer) = %v", err)
+	}
+
+	var bad struct {
+		N int `jase93:"n"`
+	}
+	var fe *FieldError
+	if _, err := StructToMap(bad); !errors.As(err, &fe) || fe.Field != "N" {
+		t.Errorf("StructToMap(non-[]byte tag) = %v", err)
+	}
+
+	var r structTestRecord
+	if err := UnmarshalStruct([]byte(`{"key":"\\"}`), &r); !errors.As(err, &fe) || !errors.Is(err, ErrInvalidData) {
+		t.Errorf("UnmarshalStruct(invalid key) = %v", err)
+	}
+}
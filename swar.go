@@ -0,0 +1,54 @@
+package jase93
+
+import "encoding/binary"
+
+// SWAR ("SIMD within a register") constants for testing all eight bytes of a uint64 at once.
+const (
+	swarOnes = 0x0101010101010101
+	swarHigh = 0x8080808080808080
+
+	// swarBlockLen is the number of characters validated ahead of decoding, so the block is still cached when decoded.
+	swarBlockLen = 1 << 10
+)
+
+// isPrintable reports whether alphabet consists of exactly the printable ASCII characters other than '"' and '\\', in
+// any order, as StdEncoding's does. Membership in such an alphabet can be tested with SWAR.
+func isPrintable(alphabet string) bool {
+	if len(alphabet) != len(encodeStd) {
+		return false
+	}
+	for i := 0; i < len(alphabet); i++ {
+		c := alphabet[i]
+		if c < ' ' || c > '~' || c == '"' || c == '\\' {
+			return false
+		}
+	}
+	// NewEncoding has already rejected duplicates
+	return true
+}
+
+// validPrintable8 reports whether the eight bytes of x are all printable ASCII characters other than '"' and '\\'.
+func validPrintable8(x uint64) bool {
+	const (
+		quote     = swarOnes * '"'
+		backslash = swarOnes * '\\'
+	)
+	q, b := x^quote, x^backslash // zero bytes where x is '"' or '\\'
+
+	bad := x                       // 0x80 and above
+	bad |= (x - swarOnes*' ') &^ x // below ' '
+	bad |= x + swarOnes*(0x7f-'~') // above '~'
+	bad |= (q - swarOnes) &^ q     // equal to '"'
+	bad |= (b - swarOnes) &^ b     // equal to '\\'
+	return bad&swarHigh == 0
+}
+
+// validPrintableLen returns the length of the longest prefix of src, in multiples of eight bytes and at most max, that
+// consists of printable ASCII characters other than '"' and '\\'.
+func validPrintableLen(src []byte, max int) int {
+	n := 0
+	for n+8 <= len(src) && n < max && validPrintable8(binary.LittleEndian.Uint64(src[n:])) {
+		n += 8
+	}
+	return n
+}
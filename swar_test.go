@@ -0,0 +1,48 @@
+package jase93
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"testing"
+)
+
+func TestValidPrintable8(t *testing.T) {
+	if !StdEncoding.printable {
+		t.Fatal("StdEncoding is not printable")
+	}
+	if NewEncoding(encodeStd[1:] + "\"").printable {
+		t.Error("alphabet with '\"' is printable")
+	}
+
+	// Every byte value in every position, among otherwise valid bytes
+	valid := []byte("a !~z}#[")
+	for pos := 0; pos < 8; pos++ {
+		for c := 0; c < 256; c++ {
+			b := append([]byte(nil), valid...)
+			b[pos] = byte(c)
+			want := StdEncoding.decode[c] != -1
+			if got := validPrintable8(binary.LittleEndian.Uint64(b)); got != want {
+				t.Errorf("validPrintable8(%q) = %v, want %v", b, got, want)
+			}
+		}
+	}
+
+	// Random combinations, biased towards the boundaries of the valid range
+	r := rand.New(rand.NewSource(0))
+	near := []byte{0x00, 0x1f, ' ', '!', '"', '#', '[', '\\', ']', '~', 0x7f, 0x80, 0xff}
+	for i := 0; i < 100000; i++ {
+		var b [8]byte
+		want := true
+		for j := range b {
+			b[j] = near[r.Intn(len(near))]
+			want = want && StdEncoding.decode[b[j]] != -1
+		}
+		if got := validPrintable8(binary.LittleEndian.Uint64(b[:])); got != want {
+			t.Fatalf("validPrintable8(%q) = %v, want %v", b, got, want)
+		}
+	}
+
+	if n := validPrintableLen([]byte("0123456789abcdef01234\"6789abcdef"), 1<<10); n != 16 {
+		t.Errorf("validPrintableLen = %d, want 16", n)
+	}
+}
@@ -0,0 +1,56 @@
+package jase93
+
+import (
+	"io"
+	"sync"
+)
+
+// SyncEncoder serializes calls to an Encoder, or another encoding io.WriteCloser such as one returned by Armor, so
+// that multiple goroutines may write to one stream.
+//
+// Each Write is atomic: the data of one Write is encoded contiguously, never interleaved with that of another. Writes
+// are encoded in the order in which they acquire the lock, so the relative order of concurrent writes is unspecified,
+// while writes by a single goroutine retain their order. Close waits for pending writes that have acquired the lock.
+type SyncEncoder struct {
+	mu sync.Mutex
+	w  io.WriteCloser
+}
+
+// NewSyncEncoder returns a SyncEncoder that serializes calls to w.
+func NewSyncEncoder(w io.WriteCloser) *SyncEncoder {
+	return &SyncEncoder{w: w}
+}
+
+// Write writes data to the wrapped io.WriteCloser while holding the lock.
+func (s *SyncEncoder) Write(data []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(data)
+}
+
+// Close closes the wrapped io.WriteCloser while holding the lock.
+func (s *SyncEncoder) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Close()
+}
+
+// SyncDecoder serializes calls to a Decoder, or another decoding io.Reader such as a Block body, so that multiple
+// goroutines may read from one stream. Each Read is atomic, so each decoded byte is returned by exactly one Read, in
+// stream order.
+type SyncDecoder struct {
+	mu sync.Mutex
+	r  io.Reader
+}
+
+// NewSyncDecoder returns a SyncDecoder that serializes calls to r.
+func NewSyncDecoder(r io.Reader) *SyncDecoder {
+	return &SyncDecoder{r: r}
+}
+
+// Read reads from the wrapped io.Reader while holding the lock.
+func (s *SyncDecoder) Read(data []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.r.Read(data)
+}
@@ -0,0 +1,103 @@
+package jase93
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSyncEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := Armor(&buf, "LOG", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewSyncEncoder(w)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				fmt.Fprintf(s, "goroutine %d line %03d\n", g, i)
+			}
+		}(g)
+	}
+	wg.Wait()
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	block, err := Dearmor(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if _, err := io.Copy(&out, block.Body); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 800 {
+		t.Fatalf("got %d lines", len(lines))
+	}
+	last := make(map[string]string)
+	for _, line := range lines {
+		var g, i int
+		if n, _ := fmt.Sscanf(line, "goroutine %d line %d", &g, &i); n != 2 {
+			t.Fatalf("interleaved line %q", line)
+		}
+		key := fmt.Sprint(g)
+		if line <= last[key] {
+			t.Fatalf("line %q after %q", line, last[key])
+		}
+		last[key] = line
+	}
+}
+
+func TestSyncDecoder(t *testing.T) {
+	src := make([]byte, 10000)
+	for i := range src {
+		src[i] = byte(i)
+	}
+	s := NewSyncDecoder(NewDecoder(bytes.NewReader(Encode(nil, src))))
+
+	var mu sync.Mutex
+	var got []int
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			buf := make([]byte, 7)
+			for {
+				n, err := s.Read(buf)
+				mu.Lock()
+				for _, c := range buf[:n] {
+					got = append(got, int(c))
+				}
+				mu.Unlock()
+				if err != nil {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	want := make([]int, len(src))
+	for i, c := range src {
+		want[i] = int(c)
+	}
+	sort.Ints(want)
+	sort.Ints(got)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("read %d bytes, want each of %d bytes once", len(got), len(src))
+	}
+}
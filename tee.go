@@ -0,0 +1,32 @@
+package jase93
+
+import (
+	"hash"
+	"io"
+)
+
+// SetTee sets the Encoder to write the raw data passed to each Write to w before encoding it, so raw and encoded forms
+// can be produced in one pass. If writing to w fails, Write returns the error without encoding the data. A nil w
+// disables the tee. The tee is retained by Reset.
+func (e *Encoder) SetTee(w io.Writer) {
+	e.tee = w
+}
+
+// NewTeeEncoder creates a new Encoder that encodes to encoded with StdEncoding, and also writes the raw data to raw.
+func NewTeeEncoder(raw, encoded io.Writer) *Encoder {
+	e := NewEncoder(encoded)
+	e.SetTee(raw)
+	return e
+}
+
+// SetDigest sets the Encoder to write the raw data passed to each Write to h, so a digest of the data is computed
+// while encoding it. A nil h disables the digest. The digest is retained by Reset, but h is not reset.
+func (e *Encoder) SetDigest(h hash.Hash) {
+	e.digest = h
+}
+
+// SetDigest sets the Decoder to write the decoded data returned by each Read to h, so a digest of the data is computed
+// while decoding it. A nil h disables the digest. The digest is retained by Reset, but h is not reset.
+func (d *Decoder) SetDigest(h hash.Hash) {
+	d.digest = h
+}
@@ -0,0 +1,84 @@
+package jase93
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"testing/iotest"
+)
+
+func TestNewTeeEncoder(t *testing.T) {
+	src := []byte("Man is distinguished, not only by his reason")
+
+	var raw, encoded bytes.Buffer
+	e := NewTeeEncoder(&raw, &encoded)
+	e.Write(src[:10])
+	e.Write(src[10:])
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(raw.Bytes(), src) {
+		t.Errorf("raw = %q", raw.Bytes())
+	}
+	if want := Encode(nil, src); !bytes.Equal(encoded.Bytes(), want) {
+		t.Errorf("encoded = %q, want %q", encoded.Bytes(), want)
+	}
+
+	raw.Reset()
+	e.Reset(&encoded)
+	e.Write(src)
+	if !bytes.Equal(raw.Bytes(), src) {
+		t.Errorf("tee not retained by Reset: raw = %q", raw.Bytes())
+	}
+}
+
+func TestEncoderTeeError(t *testing.T) {
+	errTee := errors.New("tee failed")
+	var encoded bytes.Buffer
+	e := NewTeeEncoder(&errWriter{errTee}, &encoded)
+
+	if n, err := e.Write([]byte("data")); n != 0 || err != errTee {
+		t.Errorf("Write = %d, %v", n, err)
+	}
+	e.Close()
+	if encoded.Len() != 0 {
+		t.Errorf("encoded = %q after failed tee", encoded.Bytes())
+	}
+}
+
+type errWriter struct {
+	err error
+}
+
+func (w *errWriter) Write([]byte) (int, error) {
+	return 0, w.err
+}
+
+func TestSetDigest(t *testing.T) {
+	src := bytes.Repeat([]byte("Man is distinguished, not only by his reason. "), 100)
+	want := sha256.Sum256(src)
+
+	var encoded bytes.Buffer
+	h := sha256.New()
+	e := NewEncoder(&encoded)
+	e.SetDigest(h)
+	e.Write(src[:1000])
+	e.Write(src[1000:])
+	e.Close()
+	if got := h.Sum(nil); !bytes.Equal(got, want[:]) {
+		t.Errorf("Encoder digest = %x, want %x", got, want)
+	}
+
+	h.Reset()
+	d := NewDecoder(iotest.OneByteReader(bytes.NewReader(encoded.Bytes())))
+	d.SetDigest(h)
+	if _, err := ioutil.ReadAll(iotest.HalfReader(d)); err != nil {
+		t.Fatal(err)
+	}
+	if got := h.Sum(nil); !bytes.Equal(got, want[:]) {
+		t.Errorf("Decoder digest = %x, want %x", got, want)
+	}
+}
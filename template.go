@@ -0,0 +1,34 @@
+package jase93
+
+import "errors"
+
+// ErrNotBytes indicates that a template function argument was not a string or []byte.
+var ErrNotBytes = errors.New("jase93: value is not a string or []byte")
+
+// FuncMap returns template functions for text/template and html/template, to which it may be passed directly:
+//
+//	jase93encode DATA   encodes a string or []byte with StdEncoding
+//	jase93decode TEXT   decodes a string with StdEncoding into a string, failing execution if it is invalid
+//
+// Both return plain strings, so html/template escapes them for their context as usual.
+func FuncMap() map[string]interface{} {
+	return map[string]interface{}{
+		"jase93encode": templateEncode,
+		"jase93decode": templateDecode,
+	}
+}
+
+func templateEncode(data interface{}) (string, error) {
+	switch data := data.(type) {
+	case string:
+		return string(Encode(nil, []byte(data))), nil
+	case []byte:
+		return string(Encode(nil, data)), nil
+	}
+	return "", ErrNotBytes
+}
+
+func templateDecode(text string) (string, error) {
+	data, err := Decode(nil, []byte(text))
+	return string(data), err
+}
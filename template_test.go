@@ -0,0 +1,41 @@
+package jase93
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestFuncMapText(t *testing.T) {
+	tmpl := template.Must(template.New("").Funcs(FuncMap()).Parse(`{{jase93encode .}}|{{jase93encode . | jase93decode}}`))
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, "<key>"); err != nil {
+		t.Fatal(err)
+	}
+	if want := string(Encode(nil, []byte("<key>"))) + "|<key>"; b.String() != want {
+		t.Errorf("Execute = %q, want %q", b.String(), want)
+	}
+
+	if err := tmpl.Execute(&b, 1); err == nil {
+		t.Error("Execute(1) succeeded")
+	}
+	tmpl = template.Must(template.New("").Funcs(FuncMap()).Parse(`{{jase93decode .}}`))
+	if err := tmpl.Execute(&b, `"`); err == nil {
+		t.Error("Execute(invalid) succeeded")
+	}
+}
+
+func TestFuncMapHTML(t *testing.T) {
+	tmpl := htmltemplate.Must(htmltemplate.New("").Funcs(FuncMap()).Parse(`<p title="{{jase93encode .}}">{{jase93encode . | jase93decode}}</p>`))
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, []byte("<&>")); err != nil {
+		t.Fatal(err)
+	}
+	encoded := htmltemplate.HTMLEscapeString(string(Encode(nil, []byte("<&>"))))
+	if want := `<p title="` + encoded + `">&lt;&amp;&gt;</p>`; b.String() != want {
+		t.Errorf("Execute = %q, want %q", b.String(), want)
+	}
+}
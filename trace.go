@@ -0,0 +1,86 @@
+package jase93
+
+import (
+	"context"
+	"io"
+)
+
+// Tracer starts a Span for each stream of a traced Encoder or Decoder. It is shaped so that an OpenTelemetry
+// trace.Tracer can be adapted in a few lines:
+//
+//	type otelTracer struct{ trace.Tracer }
+//
+//	func (t otelTracer) Start(ctx context.Context, name string) jase93.Span {
+//		_, span := t.Tracer.Start(ctx, name)
+//		return otelSpan{span}
+//	}
+//
+//	type otelSpan struct{ trace.Span }
+//
+//	func (s otelSpan) End(stats jase93.Stats, err error) {
+//		s.SetAttributes(
+//			attribute.Int64("jase93.raw_bytes", stats.RawBytes),
+//			attribute.Int64("jase93.encoded_bytes", stats.EncodedBytes),
+//		)
+//		if err != nil {
+//			s.RecordError(err)
+//			s.SetStatus(codes.Error, err.Error())
+//		}
+//		s.Span.End()
+//	}
+//
+// Counters suited to a meter are provided by Metrics.
+type Tracer interface {
+	// Start starts a span named "jase93.Encode" or "jase93.Decode" as a child of any span in ctx.
+	Start(ctx context.Context, name string) Span
+}
+
+// Span is a traced Encoder or Decoder stream.
+type Span interface {
+	// End ends the span with the Stats of the stream, and the error that ended it, if any. It is called once, when an
+	// Encoder is closed, when a Decoder reaches the end of its input or fails, or when either is Reset.
+	End(stats Stats, err error)
+}
+
+// tracing tracks the Span of a stream.
+type tracing struct {
+	ctx    context.Context
+	tracer Tracer
+	span   Span
+	done   bool
+}
+
+// start starts a span for the stream, unless one was already started.
+func (t *tracing) start(name string) {
+	if t.tracer != nil && t.span == nil && !t.done {
+		t.span = t.tracer.Start(t.ctx, name)
+	}
+}
+
+// end ends the span of the stream, if any.
+func (t *tracing) end(stats Stats, err error) {
+	if t.span != nil {
+		if err == io.EOF {
+			err = nil
+		}
+		t.span.End(stats, err)
+		t.span = nil
+	}
+	t.done = true
+}
+
+// reset ends the span of the stream, if any, and prepares for the next stream.
+func (t *tracing) reset(stats Stats) {
+	t.end(stats, nil)
+	t.done = false
+}
+
+// SetTracer sets t to trace each stream of the Encoder, as a child of any span in ctx. A nil t disables tracing.
+func (e *Encoder) SetTracer(ctx context.Context, t Tracer) {
+	e.trace.ctx, e.trace.tracer = ctx, t
+}
+
+// SetTracer sets t to trace each stream of the Decoder, as a child of any span in ctx. A nil t disables tracing.
+func (d *Decoder) SetTracer(ctx context.Context, t Tracer) {
+	d.trace.ctx, d.trace.tracer = ctx, t
+}
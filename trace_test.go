@@ -0,0 +1,71 @@
+package jase93
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+)
+
+type testSpan struct {
+	name  string
+	ended int
+	stats Stats
+	err   error
+}
+
+func (s *testSpan) End(stats Stats, err error) {
+	s.ended++
+	s.stats, s.err = stats, err
+}
+
+type testTracer struct {
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(ctx context.Context, name string) Span {
+	s := &testSpan{name: name}
+	t.spans = append(t.spans, s)
+	return s
+}
+
+func TestTracer(t *testing.T) {
+	tracer := &testTracer{}
+	src := []byte("Man is distinguished")
+
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+	e.SetTracer(context.Background(), tracer)
+	e.Write(src[:10])
+	e.Write(src[10:])
+	e.Close()
+
+	d := NewDecoder(bytes.NewReader(buf.Bytes()))
+	d.SetTracer(context.Background(), tracer)
+	if _, err := ioutil.ReadAll(d); err != nil {
+		t.Fatal(err)
+	}
+	d.Read(make([]byte, 1))
+
+	d.Reset(bytes.NewBufferString(`g#"`))
+	ioutil.ReadAll(d)
+
+	if len(tracer.spans) != 3 {
+		t.Fatalf("%d spans", len(tracer.spans))
+	}
+	for i, want := range []struct {
+		name string
+		raw  int64
+		err  error
+	}{
+		{"jase93.Encode", int64(len(src)), nil},
+		{"jase93.Decode", int64(len(src)), nil},
+		{"jase93.Decode", 1, ErrInvalidData},
+	} {
+		s := tracer.spans[i]
+		if s.name != want.name || s.ended != 1 || s.stats.RawBytes != want.raw || !errors.Is(s.err, want.err) {
+			t.Errorf("span %d = %+v", i, s)
+		}
+	}
+}
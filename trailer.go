@@ -0,0 +1,102 @@
+package jase93
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrLengthMismatch indicates that the length of decoded data did not match the length recorded in its trailer.
+var ErrLengthMismatch = errors.New("jase93: length mismatch")
+
+// TrailerError reports a length trailer that was missing, malformed, or did not match the decoded data, typically
+// because the stream was truncated. It matches ErrLengthMismatch and ErrInvalidData.
+type TrailerError struct {
+	Length int64 // The length recorded in the trailer, or -1 if the trailer was missing or malformed
+	Got    int64 // The length of the decoded data
+}
+
+func (e *TrailerError) Error() string {
+	if e.Length < 0 {
+		return fmt.Sprintf("jase93: missing length trailer after %d bytes", e.Got)
+	}
+	return fmt.Sprintf("jase93: decoded %d bytes, want %d", e.Got, e.Length)
+}
+
+// Is reports whether target is ErrLengthMismatch or ErrInvalidData.
+func (e *TrailerError) Is(target error) bool {
+	return target == ErrLengthMismatch || target == ErrInvalidData
+}
+
+// maxTrailerLen is the maximum length of a trailer, that of a 64-bit varint.
+const maxTrailerLen = 10
+
+// WithLengthTrailer creates a new Encoding identical to enc except that the end of each encoded stream records the
+// number of raw bytes it encodes, so the Decoder detects truncation of streams whose length is not known up front.
+// The length is encoded along with the data as a varint with its bytes reversed, so the decoder can find it at the end
+// of the stream. Decoders hold back up to 10 decoded bytes until the end of the stream, where they return a
+// *TrailerError if the length does not match.
+//
+// Both ends must use a length trailer; it is not identified by a stream header.
+func (enc Encoding) WithLengthTrailer() *Encoding {
+	enc.trailer = true
+	return &enc
+}
+
+// appendTrailer appends the reversed varint encoding of n to dst: the least significant 7 bits are in the last byte,
+// and every byte but the first has its high bit set.
+func appendTrailer(dst []byte, n int64) []byte {
+	var buf [maxTrailerLen]byte
+	i := len(buf) - 1
+	u := uint64(n)
+	buf[i] = byte(u & 0x7f)
+	for u >>= 7; u > 0; u >>= 7 {
+		buf[i] |= 0x80
+		i--
+		buf[i] = byte(u & 0x7f)
+	}
+	return append(dst, buf[i:]...)
+}
+
+// holdTrailer holds back the final maxTrailerLen bytes decoded so far, of which those from dst[start:] are new, if the
+// Encoding has a trailer.
+func (d *decoder) holdTrailer(dst []byte, start int) []byte {
+	if !d.encoding.trailer {
+		return dst
+	}
+
+	d.tail = append(d.tail, dst[start:]...)
+	dst = dst[:start]
+	if n := len(d.tail) - maxTrailerLen; n > 0 {
+		dst = append(dst, d.tail[:n]...)
+		d.raw += int64(n)
+		d.tail = d.tail[:copy(d.tail, d.tail[n:])]
+	}
+	return dst
+}
+
+// verifyTrailer parses the trailer from the end of the held back data, appends the data preceding it to dst, and
+// verifies the total length.
+func (d *decoder) verifyTrailer(dst []byte) ([]byte, error) {
+	var length uint64
+	i := len(d.tail)
+	for shift := uint(0); ; shift += 7 {
+		if i == 0 || shift >= 64 {
+			got := d.raw + int64(len(d.tail))
+			return append(dst, d.tail...), &TrailerError{Length: -1, Got: got}
+		}
+		i--
+		c := d.tail[i]
+		length |= uint64(c&0x7f) << shift
+		if c < 0x80 {
+			break
+		}
+	}
+
+	dst = append(dst, d.tail[:i]...)
+	got := d.raw + int64(i)
+	d.raw, d.tail = got, d.tail[:0]
+	if int64(length) != got {
+		return dst, &TrailerError{Length: int64(length), Got: got}
+	}
+	return dst, nil
+}
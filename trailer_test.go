@@ -0,0 +1,81 @@
+package jase93
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"testing"
+	"testing/iotest"
+)
+
+func TestAppendTrailer(t *testing.T) {
+	for _, tt := range []struct {
+		n    int64
+		want []byte
+	}{
+		{0, []byte{0}},
+		{127, []byte{0x7f}},
+		{128, []byte{0x01, 0x80}},
+		{300, []byte{0x02, 0xac}},
+		{math.MaxInt64, []byte{0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+	} {
+		if got := appendTrailer(nil, tt.n); !bytes.Equal(got, tt.want) {
+			t.Errorf("appendTrailer(%d) = %x, want %x", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestWithLengthTrailer(t *testing.T) {
+	enc := StdEncoding.WithLengthTrailer()
+	rng := rand.New(rand.NewSource(1))
+
+	for _, n := range []int{0, 1, 9, 10, 11, 127, 128, 1000, 70000} {
+		src := make([]byte, n)
+		rng.Read(src)
+
+		encoded := enc.Encode(nil, src)
+		if len(encoded) > enc.MaxEncodedLen(n) {
+			t.Errorf("len(Encode(%d bytes)) = %d > MaxEncodedLen", n, len(encoded))
+		}
+
+		var buf bytes.Buffer
+		e := enc.NewEncoder(&buf)
+		e.Write(src[:n/2])
+		e.Write(src[n/2:])
+		e.Close()
+		if !bytes.Equal(buf.Bytes(), encoded) {
+			t.Errorf("Encoder(%d bytes) differs from Encode", n)
+		}
+
+		if dec, err := enc.Decode(nil, encoded); err != nil || !bytes.Equal(dec, src) {
+			t.Errorf("Decode(%d bytes) = %d bytes, %v", n, len(dec), err)
+		}
+		dec, err := ioutil.ReadAll(enc.NewDecoder(iotest.OneByteReader(bytes.NewReader(encoded))))
+		if err != nil || !bytes.Equal(dec, src) {
+			t.Errorf("Decoder(%d bytes) = %d bytes, %v", n, len(dec), err)
+		}
+	}
+}
+
+func TestWithLengthTrailerTruncated(t *testing.T) {
+	enc := StdEncoding.WithLengthTrailer()
+	src := bytes.Repeat([]byte("truncate me "), 100)
+	encoded := enc.Encode(nil, src)
+
+	for _, cut := range []int{2, 4, 20, 200} {
+		_, err := enc.Decode(nil, encoded[:len(encoded)-cut])
+		var te *TrailerError
+		if !errors.As(err, &te) || !errors.Is(err, ErrLengthMismatch) || !errors.Is(err, ErrInvalidData) {
+			t.Errorf("Decode(cut %d) = %v", cut, err)
+		}
+	}
+
+	if _, err := enc.Decode(nil, nil); !errors.Is(err, ErrLengthMismatch) {
+		t.Errorf("Decode(empty) = %v", err)
+	}
+	if _, err := enc.Decode(nil, Encode(nil, []byte("abc"))); !errors.Is(err, ErrLengthMismatch) {
+		t.Errorf("Decode(no trailer) = %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+package jase93
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+)
+
+// Transcoder is the streaming half of a Codec, all that Transcode requires.
+type Transcoder interface {
+	// NewWriter returns an io.WriteCloser that encodes to w. Close flushes the encoding, but does not close w.
+	NewWriter(w io.Writer) io.WriteCloser
+	// NewReader returns an io.Reader that decodes from r.
+	NewReader(r io.Reader) io.Reader
+}
+
+// Transcode decodes src with srcEnc and writes its encoding with dstEnc to dst, in a single streaming pass, so tools
+// can convert between any pair of encodings, such as a jase93 variant and base64 or hex. The encoding is flushed at the
+// end, but dst is not closed. If both are Encodings, Transcode re-encodes with a Reencoder.
+func Transcode(dst io.Writer, dstEnc Transcoder, src io.Reader, srcEnc Transcoder) error {
+	from, ok := srcEnc.(*Encoding)
+	to, ok2 := dstEnc.(*Encoding)
+	if ok && ok2 {
+		_, err := NewReencoder(dst, src, from, to).Reencode()
+		return err
+	}
+
+	w := dstEnc.NewWriter(dst)
+	if _, err := io.Copy(w, srcEnc.NewReader(src)); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+// base64Codec adapts a base64 encoding to Codec.
+type base64Codec struct {
+	enc *base64.Encoding
+}
+
+// NewBase64Codec returns a Codec that encodes with enc, such as base64.StdEncoding, so it can be registered with
+// Register or passed to Transcode.
+func NewBase64Codec(enc *base64.Encoding) Codec {
+	return base64Codec{enc}
+}
+
+func (c base64Codec) Encode(dst, src []byte) []byte {
+	n := len(dst)
+	dst = append(dst, make([]byte, c.enc.EncodedLen(len(src)))...)
+	c.enc.Encode(dst[n:], src)
+	return dst
+}
+
+func (c base64Codec) Decode(dst, src []byte) ([]byte, error) {
+	n := len(dst)
+	dst = append(dst, make([]byte, c.enc.DecodedLen(len(src)))...)
+	m, err := c.enc.Decode(dst[n:], src)
+	return dst[:n+m], err
+}
+
+func (c base64Codec) NewWriter(w io.Writer) io.WriteCloser {
+	return base64.NewEncoder(c.enc, w)
+}
+
+func (c base64Codec) NewReader(r io.Reader) io.Reader {
+	return base64.NewDecoder(c.enc, r)
+}
+
+// HexCodec is a Codec for lowercase hexadecimal. It decodes either case.
+var HexCodec Codec = hexCodec{}
+
+type hexCodec struct{}
+
+func (hexCodec) Encode(dst, src []byte) []byte {
+	n := len(dst)
+	dst = append(dst, make([]byte, hex.EncodedLen(len(src)))...)
+	hex.Encode(dst[n:], src)
+	return dst
+}
+
+func (hexCodec) Decode(dst, src []byte) ([]byte, error) {
+	n := len(dst)
+	dst = append(dst, make([]byte, hex.DecodedLen(len(src)))...)
+	m, err := hex.Decode(dst[n:], src)
+	return dst[:n+m], err
+}
+
+func (hexCodec) NewWriter(w io.Writer) io.WriteCloser {
+	return hexWriter{hex.NewEncoder(w)}
+}
+
+func (hexCodec) NewReader(r io.Reader) io.Reader {
+	return hex.NewDecoder(r)
+}
+
+// hexWriter adds a Close method to a hex encoder, which has no state to flush.
+type hexWriter struct {
+	io.Writer
+}
+
+func (hexWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,52 @@
+package jase93
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"math/rand"
+	"testing"
+	"testing/iotest"
+)
+
+func TestTranscode(t *testing.T) {
+	src := make([]byte, 10000)
+	rand.New(rand.NewSource(0)).Read(src)
+
+	for _, tc := range []struct {
+		name     string
+		from, to Codec
+	}{
+		{"jase93 to base64", StdEncoding, NewBase64Codec(base64.StdEncoding)},
+		{"base64 to jase93", NewBase64Codec(base64.RawURLEncoding), HumanEncoding.WithHeader()},
+		{"hex to base64", HexCodec, NewBase64Codec(base64.URLEncoding)},
+		{"jase93 to hex", StdEncoding.WithLengthTrailer(), HexCodec},
+		{"jase93 to jase93", StdEncoding, FilenameEncoding},
+	} {
+		var buf bytes.Buffer
+		err := Transcode(&buf, tc.to, iotest.HalfReader(bytes.NewReader(tc.from.Encode(nil, src))), tc.from)
+		if want := tc.to.Encode(nil, src); err != nil || !bytes.Equal(buf.Bytes(), want) {
+			t.Errorf("%s: Transcode wrote %d bytes, %v, want %d", tc.name, buf.Len(), err, len(want))
+		}
+	}
+
+	var buf bytes.Buffer
+	err := Transcode(&buf, StdEncoding, bytes.NewReader([]byte("0g")), HexCodec)
+	if !errors.As(err, new(hex.InvalidByteError)) {
+		t.Errorf("Transcode(invalid hex) = %v", err)
+	}
+}
+
+func TestCodecAdapters(t *testing.T) {
+	src := []byte("Man is distinguished")
+	for _, c := range []Codec{NewBase64Codec(base64.StdEncoding), HexCodec} {
+		encoded := c.Encode([]byte("prefix"), src)
+		if dec, err := c.Decode([]byte("prefix"), encoded[6:]); err != nil || string(dec) != "prefix"+string(src) {
+			t.Errorf("%T: Decode(Encode) = %q, %v", c, dec, err)
+		}
+	}
+	if got := string(HexCodec.Encode(nil, []byte{0xab})); got != "ab" {
+		t.Errorf("HexCodec.Encode = %q", got)
+	}
+}
@@ -0,0 +1,118 @@
+package jase93
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// MaxTXTLen is the maximum length of a string in a DNS TXT record (RFC 1035, section 3.3).
+const MaxTXTLen = 255
+
+// ErrIncompleteTXT indicates that strings written by SplitTXT were missing.
+var ErrIncompleteTXT = errors.New("jase93: TXT strings missing")
+
+// SplitTXT encodes data and splits it into strings of at most MaxTXTLen bytes, each to be published as a TXT record
+// of the same DNS name, for distributing keys and proofs via DNS. Since the records of a name are returned in no
+// particular order, each string is labeled with its 1-based index and the number of strings:
+//
+//	1/3:<encoded data>
+//	2/3:<encoded data>
+//	3/3:<encoded data>
+//
+// The encoded data never contains '"' or '\\', so the strings can be quoted in zone files as they are.
+func (enc *Encoding) SplitTXT(data []byte) []string {
+	encoded := string(enc.Encode(nil, data))
+
+	// The length of the labels depends on the number of strings, and vice versa
+	n := 1
+	for {
+		per := MaxTXTLen - len(txtLabel(n, n))
+		need := (len(encoded) + per - 1) / per
+		if need <= n {
+			break
+		}
+		n = need
+	}
+
+	per := MaxTXTLen - len(txtLabel(n, n))
+	txt := make([]string, 0, n)
+	for i := 1; i <= n; i++ {
+		end := per
+		if end > len(encoded) {
+			end = len(encoded)
+		}
+		txt = append(txt, txtLabel(i, n)+encoded[:end])
+		encoded = encoded[end:]
+	}
+	return txt
+}
+
+// SplitTXT encodes data with StdEncoding and splits it into TXT strings; see Encoding.SplitTXT.
+func SplitTXT(data []byte) []string {
+	return StdEncoding.SplitTXT(data)
+}
+
+// JoinTXT reassembles and decodes the strings written by SplitTXT, in any order, such as the records returned by
+// net.LookupTXT. Strings without a label, such as other TXT records of the name, are ignored, as are duplicates. It
+// returns ErrIncompleteTXT if any string is missing, and ErrInvalidData if strings conflict.
+func (enc *Encoding) JoinTXT(txt []string) ([]byte, error) {
+	var parts []string
+	var have []bool
+	found := 0
+	for _, s := range txt {
+		i, n, part, ok := parseTXT(s)
+		if !ok {
+			continue
+		}
+		if n > len(txt) {
+			return nil, ErrIncompleteTXT
+		}
+		if parts == nil {
+			parts, have = make([]string, n), make([]bool, n)
+		} else if n != len(parts) {
+			return nil, ErrInvalidData
+		}
+
+		if !have[i-1] {
+			parts[i-1], have[i-1] = part, true
+			found++
+		} else if parts[i-1] != part {
+			return nil, ErrInvalidData
+		}
+	}
+	if parts == nil || found < len(parts) {
+		return nil, ErrIncompleteTXT
+	}
+
+	return enc.Decode(nil, []byte(strings.Join(parts, "")))
+}
+
+// JoinTXT reassembles and decodes TXT strings written by SplitTXT with StdEncoding; see Encoding.JoinTXT.
+func JoinTXT(txt []string) ([]byte, error) {
+	return StdEncoding.JoinTXT(txt)
+}
+
+// txtLabel returns the label of the ith of n strings.
+func txtLabel(i, n int) string {
+	return strconv.Itoa(i) + "/" + strconv.Itoa(n) + ":"
+}
+
+// parseTXT parses a string written by SplitTXT.
+func parseTXT(s string) (i, n int, part string, ok bool) {
+	colon := strings.IndexByte(s, ':')
+	slash := strings.IndexByte(s, '/')
+	if slash < 1 || colon < slash+2 {
+		return 0, 0, "", false
+	}
+
+	i, err := strconv.Atoi(s[:slash])
+	if err != nil {
+		return 0, 0, "", false
+	}
+	n, err = strconv.Atoi(s[slash+1 : colon])
+	if err != nil || i < 1 || i > n || txtLabel(i, n) != s[:colon+1] {
+		return 0, 0, "", false
+	}
+	return i, n, s[colon+1:], true
+}
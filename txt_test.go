@@ -0,0 +1,59 @@
+package jase93
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestSplitTXT(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	for _, n := range []int{0, 1, 200, 206, 207, 1000, 30000} {
+		src := make([]byte, n)
+		rng.Read(src)
+
+		txt := SplitTXT(src)
+		for _, s := range txt {
+			if len(s) > MaxTXTLen || strings.ContainsAny(s, "\"\\") {
+				t.Errorf("%d bytes: invalid string of %d bytes", n, len(s))
+			}
+		}
+		// Every string but the last is as full as the longest label allows
+		per := MaxTXTLen - len(txtLabel(len(txt), len(txt)))
+		if len(txt) > 1 && len(txt[0]) != len(txtLabel(1, len(txt)))+per {
+			t.Errorf("%d bytes: first of %d strings has %d bytes", n, len(txt), len(txt[0]))
+		}
+
+		// Records arrive in any order, mixed with others and duplicated
+		shuffled := append([]string{"v=spf1 -all", "1/x:"}, txt...)
+		shuffled = append(shuffled, txt[0])
+		rng.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+		if dec, err := JoinTXT(shuffled); err != nil || !bytes.Equal(dec, src) {
+			t.Errorf("%d bytes: JoinTXT = %v", n, err)
+		}
+	}
+}
+
+func TestJoinTXTErrors(t *testing.T) {
+	src := make([]byte, 1000)
+	rand.New(rand.NewSource(0)).Read(src)
+	txt := SplitTXT(src)
+
+	for _, tc := range []struct {
+		name string
+		txt  []string
+		err  error
+	}{
+		{"none", nil, ErrIncompleteTXT},
+		{"missing", txt[1:], ErrIncompleteTXT},
+		{"count", append([]string{"1/99:x"}, txt...), ErrIncompleteTXT},
+		{"conflicting counts", append([]string{"1/2:x"}, txt...), ErrInvalidData},
+		{"conflicting duplicate", append(append([]string(nil), txt...), txt[0][:10]), ErrInvalidData},
+	} {
+		if _, err := JoinTXT(tc.txt); !errors.Is(err, tc.err) {
+			t.Errorf("%s: JoinTXT = %v, want %v", tc.name, err, tc.err)
+		}
+	}
+}
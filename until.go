@@ -0,0 +1,68 @@
+package jase93
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// NewDecoderUntil creates a new Decoder that decodes from r up to the first occurrence of sentinel, which must not be
+// in the alphabet, and then reports io.EOF. The input following the sentinel remains in r, so an encoded payload can
+// be extracted from a larger text stream in place. The sentinel itself is consumed from r only if consume is true.
+//
+// If r ends before the sentinel, the Decoder reports io.ErrUnexpectedEOF.
+func (enc *Encoding) NewDecoderUntil(r *bufio.Reader, sentinel byte, consume bool) *Decoder {
+	if enc.decode[sentinel] != -1 {
+		panic("jase93: sentinel is in the encoding alphabet")
+	}
+	return enc.NewDecoder(&untilReader{r: r, sentinel: sentinel, consume: consume})
+}
+
+// NewDecoderUntil creates a new Decoder that decodes from r with StdEncoding up to the first occurrence of sentinel.
+// See Encoding.NewDecoderUntil.
+func NewDecoderUntil(r *bufio.Reader, sentinel byte, consume bool) *Decoder {
+	return StdEncoding.NewDecoderUntil(r, sentinel, consume)
+}
+
+// untilReader reads from r up to the first occurrence of sentinel without reading past it.
+type untilReader struct {
+	r        *bufio.Reader
+	sentinel byte
+	consume  bool
+	done     bool
+}
+
+func (u *untilReader) Read(data []byte) (int, error) {
+	if u.done {
+		return 0, io.EOF
+	}
+
+	if u.r.Buffered() == 0 {
+		if _, err := u.r.Peek(1); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, err
+		}
+	}
+
+	buf, _ := u.r.Peek(u.r.Buffered())
+	found := false
+	if i := bytes.IndexByte(buf, u.sentinel); i >= 0 {
+		buf = buf[:i]
+		found = true
+	}
+
+	n := copy(data, buf)
+	u.r.Discard(n)
+	if found && n == len(buf) {
+		u.done = true
+		if u.consume {
+			u.r.Discard(1)
+		}
+		if n == 0 {
+			return 0, io.EOF
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,51 @@
+package jase93
+
+import (
+	"bufio"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestNewDecoderUntil(t *testing.T) {
+	src := []byte("embedded payload\x00\xff")
+	encoded := string(Encode(nil, src))
+
+	for _, consume := range []bool{false, true} {
+		for _, size := range []int{16, 64} {
+			r := bufio.NewReaderSize(strings.NewReader(`{"k":"`+encoded+`","n":1}`), size)
+			r.Discard(6)
+
+			dec, err := ioutil.ReadAll(NewDecoderUntil(r, '"', consume))
+			if err != nil || string(dec) != string(src) {
+				t.Errorf("consume %v, size %d: ReadAll = %q, %v", consume, size, dec, err)
+			}
+
+			rest, _ := ioutil.ReadAll(r)
+			want := `","n":1}`
+			if consume {
+				want = want[1:]
+			}
+			if string(rest) != want {
+				t.Errorf("consume %v, size %d: rest = %q, want %q", consume, size, rest, want)
+			}
+		}
+	}
+}
+
+func TestNewDecoderUntilUnterminated(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader(string(Encode(nil, []byte("data")))))
+	if _, err := ioutil.ReadAll(NewDecoderUntil(r, '\n', true)); err != io.ErrUnexpectedEOF {
+		t.Errorf("ReadAll = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
+func TestNewDecoderUntilAlphabet(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewDecoderUntil did not panic for a sentinel in the alphabet")
+		}
+	}()
+	NewDecoderUntil(bufio.NewReader(strings.NewReader("")), 'a', false)
+}
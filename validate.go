@@ -0,0 +1,57 @@
+package jase93
+
+import (
+	"errors"
+	"io"
+)
+
+// NewValidatingReader returns an io.Reader that reads encoded data from r unchanged, while verifying that it would
+// decode successfully. Reading invalid data returns the data preceding the invalid character along with the error the
+// Decoder would return, whose offset is that of the character. Decoded data is discarded as it is verified.
+func (enc *Encoding) NewValidatingReader(r io.Reader) io.Reader {
+	v := &validatingReader{r: r}
+	v.dec.encoding = enc
+	v.dec.reset()
+	return v
+}
+
+// NewValidatingReader returns an io.Reader that reads data encoded with StdEncoding from r unchanged, while verifying
+// it. See Encoding.NewValidatingReader.
+func NewValidatingReader(r io.Reader) io.Reader {
+	return StdEncoding.NewValidatingReader(r)
+}
+
+type validatingReader struct {
+	r   io.Reader
+	dec decoder
+	buf []byte
+	err error
+}
+
+func (v *validatingReader) Read(data []byte) (int, error) {
+	if v.err != nil {
+		return 0, v.err
+	}
+
+	offset := v.dec.offset
+	n, err := v.r.Read(data)
+	var derr error
+	if n > 0 {
+		v.buf, derr = v.dec.write(v.buf[:0], data[:n])
+	}
+	if derr == nil && err == io.EOF {
+		v.buf, derr = v.dec.flush(v.buf[:0])
+	}
+
+	if derr != nil {
+		var ce *CorruptInputError
+		if errors.As(derr, &ce) {
+			if valid := ce.Offset - offset; valid >= 0 && valid < int64(n) {
+				n = int(valid)
+			}
+		}
+		v.err = derr
+		return n, derr
+	}
+	return n, err
+}
@@ -0,0 +1,44 @@
+package jase93
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestNewValidatingReader(t *testing.T) {
+	encoded := Encode(nil, []byte("Man is distinguished, not only by his reason"))
+
+	got, err := ioutil.ReadAll(NewValidatingReader(iotest.OneByteReader(bytes.NewReader(encoded))))
+	if err != nil || !bytes.Equal(got, encoded) {
+		t.Errorf("ReadAll = %q, %v", got, err)
+	}
+}
+
+func TestNewValidatingReaderInvalid(t *testing.T) {
+	in := "valid prefix" + `\` + "rest"
+
+	got, err := ioutil.ReadAll(NewValidatingReader(iotest.HalfReader(strings.NewReader(in))))
+	var ce *CorruptInputError
+	if !errors.As(err, &ce) || ce.Offset != 12 || ce.Char != '\\' {
+		t.Errorf("ReadAll error = %v", err)
+	}
+	if string(got) != "valid prefix" {
+		t.Errorf("ReadAll = %q", got)
+	}
+}
+
+func TestNewValidatingReaderHeader(t *testing.T) {
+	enc := StdEncoding.WithHeader()
+	if _, err := ioutil.ReadAll(enc.NewValidatingReader(strings.NewReader("a"))); !errors.Is(err, ErrInvalidHeader) {
+		t.Errorf("ReadAll(truncated header) = %v", err)
+	}
+
+	encoded := enc.Encode(nil, []byte("data"))
+	if got, err := ioutil.ReadAll(enc.NewValidatingReader(bytes.NewReader(encoded))); err != nil || !bytes.Equal(got, encoded) {
+		t.Errorf("ReadAll = %q, %v", got, err)
+	}
+}
@@ -0,0 +1,130 @@
+package jase93
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testVariant tests that enc round-trips, on its own and with a header, and that its output excludes the characters
+// in forbidden.
+func testVariant(t *testing.T, name string, enc *Encoding, forbidden string) {
+	rng := rand.New(rand.NewSource(0))
+	for _, n := range []int{0, 1, 2, 31, 32, 1000} {
+		src := make([]byte, n)
+		rng.Read(src)
+
+		encoded := enc.Encode(nil, src)
+		if i := bytes.IndexAny(encoded, forbidden); i >= 0 {
+			t.Errorf("%s: output contains %q", name, encoded[i])
+		}
+		if dec, err := enc.Decode(nil, encoded); err != nil || !bytes.Equal(dec, src) {
+			t.Errorf("%s: Decode = %v", name, err)
+		}
+		if dec, err := StdEncoding.WithHeader().Decode(nil, enc.WithHeader().Encode(nil, src)); err != nil || !bytes.Equal(dec, src) {
+			t.Errorf("%s: Decode(header) = %v", name, err)
+		}
+	}
+
+	for i := 0; i < len(enc.encode); i++ {
+		if c := enc.encode[i]; c <= ' ' || c > '~' || strings.IndexByte(forbidden, c) >= 0 {
+			t.Errorf("%s: alphabet contains %q", name, c)
+		}
+	}
+}
+
+func TestHTTPHeaderEncoding(t *testing.T) {
+	testVariant(t, "HTTPHeaderEncoding", HTTPHeaderEncoding, " \t\"(),/:;<=>?@[\\]{}")
+
+	// Values survive a round trip through net/http unchanged
+	src := make([]byte, 100)
+	rand.New(rand.NewSource(0)).Read(src)
+	value := string(HTTPHeaderEncoding.Encode(nil, src))
+
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("X-Token")
+	}))
+	defer srv.Close()
+	req, _ := http.NewRequest("GET", srv.URL, nil)
+	req.Header.Set("X-Token", value)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if got != value {
+		t.Errorf("received %q, sent %q", got, value)
+	}
+}
+
+func TestCookieEncoding(t *testing.T) {
+	testVariant(t, "CookieEncoding", CookieEncoding, " \t\",;\\")
+
+	// Values survive net/http unchanged, without quoting
+	src := make([]byte, 100)
+	rand.New(rand.NewSource(0)).Read(src)
+	value := string(CookieEncoding.Encode(nil, src))
+
+	c := &http.Cookie{Name: "session", Value: value}
+	if s := c.String(); s != "session="+value {
+		t.Errorf("Set-Cookie: %s", s)
+	}
+	req := httptest.NewRequest("GET", "/", nil)
+	req.AddCookie(c)
+	if got, err := req.Cookie("session"); err != nil || got.Value != value {
+		t.Errorf("Cookie = %v, %v", got, err)
+	}
+}
+
+func TestURLEncodings(t *testing.T) {
+	testVariant(t, "URLPathEncoding", URLPathEncoding, " \"#%./;<>?[\\]^`{|}")
+	testVariant(t, "URLQueryEncoding", URLQueryEncoding, " \"#%&+;<=>[\\]^`{|}")
+
+	rng := rand.New(rand.NewSource(0))
+	for i := 0; i < 100; i++ {
+		src := make([]byte, 1+rng.Intn(50))
+		rng.Read(src)
+		segment := string(URLPathEncoding.Encode(nil, src))
+		value := string(URLQueryEncoding.Encode(nil, src))
+
+		u, err := url.Parse("https://example.com/a/" + segment + "/b?k=" + value + "&x=1")
+		if err != nil {
+			t.Fatalf("url.Parse: %v", err)
+		}
+		if want := "/a/" + segment + "/b"; u.Path != want || path.Clean(u.Path) != want {
+			t.Errorf("path %q, want %q", u.Path, want)
+		}
+		if q, err := url.ParseQuery(u.RawQuery); err != nil || q.Get("k") != value || q.Get("x") != "1" {
+			t.Errorf("query %v, %v, want k=%q", q, err, value)
+		}
+	}
+}
+
+func TestFilenameEncoding(t *testing.T) {
+	testVariant(t, "FilenameEncoding", FilenameEncoding, " \"*./:<>?\\|")
+
+	// Names of digests can be used as they are
+	dir := t.TempDir()
+	for i := 0; i < 10; i++ {
+		sum := sha256.Sum256([]byte{byte(i)})
+		name := string(FilenameEncoding.Encode(nil, sum[:]))
+		if filepath.Base(name) != name {
+			t.Errorf("Base(%q) = %q", name, filepath.Base(name))
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), sum[:], 0644); err != nil {
+			t.Error(err)
+		}
+	}
+	if entries, err := os.ReadDir(dir); err != nil || len(entries) != 10 {
+		t.Errorf("ReadDir: %d entries, %v", len(entries), err)
+	}
+}
@@ -0,0 +1,73 @@
+package jase93
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+)
+
+type testVectors struct {
+	Encoding string `json:"encoding"`
+	Vectors  []struct {
+		Name    string `json:"name"`
+		Hex     string `json:"hex"`
+		Encoded string `json:"encoded"`
+	} `json:"vectors"`
+}
+
+func readTestVectors(t *testing.T) testVectors {
+	data, err := ioutil.ReadFile("testdata/vectors.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tv testVectors
+	if err := json.Unmarshal(data, &tv); err != nil {
+		t.Fatal(err)
+	}
+	return tv
+}
+
+func TestVectors(t *testing.T) {
+	tv := readTestVectors(t)
+	if tv.Encoding != "jase93" {
+		t.Fatalf("encoding = %q", tv.Encoding)
+	}
+
+	for _, v := range tv.Vectors {
+		raw, err := hex.DecodeString(v.Hex)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if enc := Encode(nil, raw); string(enc) != v.Encoded {
+			t.Errorf("%s: Encode(%s) = %q != %q", v.Name, v.Hex, enc, v.Encoded)
+		}
+
+		var buf bytes.Buffer
+		e := NewEncoder(&buf)
+		for i := range raw {
+			if _, err := e.Write(raw[i : i+1]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := e.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != v.Encoded {
+			t.Errorf("%s: Encoder(%s) = %q != %q", v.Name, v.Hex, buf.Bytes(), v.Encoded)
+		}
+
+		dec, err := Decode(nil, []byte(v.Encoded))
+		if err != nil || !bytes.Equal(dec, raw) {
+			t.Errorf("%s: Decode(%q) = %x, %v != %s", v.Name, v.Encoded, dec, err, v.Hex)
+		}
+
+		dec, err = ioutil.ReadAll(NewDecoder(bytes.NewBufferString(v.Encoded)))
+		if err != nil || !bytes.Equal(dec, raw) {
+			t.Errorf("%s: Decoder(%q) = %x, %v != %s", v.Name, v.Encoded, dec, err, v.Hex)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package jase93
+
+// encodeSpaceFree is the alphabet of SpaceFreeEncoding: that of StdEncoding without ' '.
+const encodeSpaceFree = "!#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[]^_`abcdefghijklmnopqrstuvwxyz{|}~"
+
+// SpaceFreeEncoding is an encoding whose 92-character alphabet is that of StdEncoding without ' ', so its output
+// survives transports that strip or collapse whitespace, and can be reflowed when decoded with IgnoreWhitespace. Each
+// word of two characters carries 13 or 14 bits, as with StdEncoding, but the extra bit fits less often.
+var SpaceFreeEncoding = NewEncoding(encodeSpaceFree).withID('c')
+
+// IgnoreWhitespace creates a new Encoding identical to enc except that decoding skips ASCII whitespace (' ', '\t',
+// '\n', '\v', '\f', and '\r') wherever it occurs, so encoded text can be freely reflowed, as by pretty-printers and
+// terminals that hard-wrap lines, without corrupting the data. Offsets reported by errors include the skipped
+// whitespace.
+//
+// IgnoreWhitespace panics if the alphabet of enc includes whitespace, as that of StdEncoding does; use it with
+// SpaceFreeEncoding or HumanEncoding. Likewise, if enc has a header, streams whose header selects such an alphabet are
+// rejected with a *HeaderError.
+func (enc Encoding) IgnoreWhitespace() *Encoding {
+	for _, c := range []byte(" \t\n\v\f\r") {
+		if enc.decode[c] != -1 {
+			panic("jase93: cannot ignore whitespace in the alphabet")
+		}
+	}
+	enc.ignoreSpace = true
+	return &enc
+}
+
+// skipped reports whether c, which is not in the alphabet, is skipped when decoding with enc.
+func (enc *Encoding) skipped(c byte) bool {
+	switch c {
+	case '\r', '\n':
+		return enc.lineLen > 0 || enc.ignoreBreaks || enc.ignoreSpace
+	case ' ', '\t', '\v', '\f':
+		return enc.ignoreSpace
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package jase93
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestSpaceFreeEncoding(t *testing.T) {
+	src := make([]byte, 1000)
+	rand.New(rand.NewSource(0)).Read(src)
+
+	enc := SpaceFreeEncoding.Encode(nil, src)
+	if bytes.ContainsAny(enc, " \t\r\n") {
+		t.Errorf("Encode output contains whitespace")
+	}
+	if dec, err := SpaceFreeEncoding.Decode(nil, enc); err != nil || !bytes.Equal(dec, src) {
+		t.Errorf("Decode = %v", err)
+	}
+
+	// Streams with headers identify the encoding
+	headered := SpaceFreeEncoding.WithHeader()
+	if dec, err := StdEncoding.WithHeader().Decode(nil, headered.Encode(nil, src)); err != nil || !bytes.Equal(dec, src) {
+		t.Errorf("Decode(header) = %v", err)
+	}
+
+	// Without IgnoreWhitespace, whitespace is invalid
+	if _, err := SpaceFreeEncoding.Decode(nil, append([]byte(" "), enc...)); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("Decode(space) = %v", err)
+	}
+}
+
+func TestIgnoreWhitespace(t *testing.T) {
+	src := make([]byte, 1000)
+	rand.New(rand.NewSource(0)).Read(src)
+
+	for _, enc := range []*Encoding{SpaceFreeEncoding, HumanEncoding, SpaceFreeEncoding.WithHeader()} {
+		encoded := string(enc.Encode(nil, src))
+
+		// Reflow into ragged, indented lines
+		var b strings.Builder
+		for i := 0; i < len(encoded); i += 37 {
+			end := i + 37
+			if end > len(encoded) {
+				end = len(encoded)
+			}
+			b.WriteString("\t  " + encoded[i:end] + " \r\n\v\f")
+		}
+
+		ignore := enc.IgnoreWhitespace()
+		if dec, err := ignore.Decode(nil, []byte(b.String())); err != nil || !bytes.Equal(dec, src) {
+			t.Errorf("%q: Decode = %v", enc.encode, err)
+		}
+		dec, err := ioutil.ReadAll(ignore.ConstantTime().NewDecoder(strings.NewReader(b.String())))
+		if err != nil || !bytes.Equal(dec, src) {
+			t.Errorf("%q: Decoder = %v", enc.encode, err)
+		}
+	}
+
+	var cerr *CorruptInputError
+	if _, err := SpaceFreeEncoding.IgnoreWhitespace().Decode(nil, []byte("ab  \"")); !errors.As(err, &cerr) || cerr.Offset != 4 {
+		t.Errorf("Decode of invalid input = %v, want offset 4", err)
+	}
+
+	if _, err := SpaceFreeEncoding.WithHeader().IgnoreWhitespace().Decode(nil, StdEncoding.WithHeader().Encode(nil, src)); !errors.Is(err, ErrInvalidHeader) {
+		t.Errorf("Decode of StdEncoding stream = %v, want %v", err, ErrInvalidHeader)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("IgnoreWhitespace did not panic for StdEncoding")
+		}
+	}()
+	StdEncoding.IgnoreWhitespace()
+}